@@ -0,0 +1,158 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package benchmarks
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+func replace2(s string) string {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 8 || r == 11 || r >= 14 && r <= 31 || r >= 33 && r <= 43 || r >= 45 && r <= 58 || r >= 60:
+			goto f1
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			goto f2
+		case r == 44 || r == 59:
+			end = i
+			goto f3
+		}
+		goto reverse
+	f2:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 8 || r == 11 || r >= 14 && r <= 31 || r >= 33 && r <= 43 || r >= 45 && r <= 58 || r >= 60:
+			goto f1
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			goto f2
+		case r == 44 || r == 59:
+			end = i
+			goto f3
+		}
+		goto reverse
+	f3:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			end = i
+			goto f4
+		}
+		goto reverse
+	f4:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			end = i
+			goto f4
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			goto r2
+		case r == 44 || r == 59:
+			start = i
+			goto r3
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			goto r2
+		case r == 44 || r == 59:
+			start = i
+			goto r3
+		}
+		return
+	r3:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			start = i
+			goto r4
+		}
+		return
+	r4:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			start = i
+			goto r4
+		}
+		return
+	}
+	var b strings.Builder
+	last := 0
+	for pos := 0; pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		b.WriteString(s[last:start])
+		// An empty match right after the previous match is not replaced.
+		if end > last || start == 0 {
+			b.WriteString("; ")
+		}
+		last = end
+		if _, width := utf8.DecodeRuneInString(s[pos:]); pos+width > end {
+			pos += width
+		} else if pos+1 > end {
+			pos++
+		} else {
+			pos = end
+		}
+	}
+	if last == 0 && b.Len() == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
@@ -0,0 +1,41 @@
+package benchmarks
+
+import (
+	"regexp"
+	"testing"
+)
+
+var rx2 = regexp.MustCompile(`\s*[,;]\s*`)
+
+var rx2TestStrings = []string{
+	"2017-03-01 12:00:00 , INFO ; server started ,port=8080",
+	"user=alice;action=login ;  status=ok",
+	"no separators in this line at all",
+	"a,b,c,d,e,f,g,h,i,j,k,l,m,n,o,p",
+	"",
+}
+
+func TestFSM2(t *testing.T) {
+	for _, s := range rx2TestStrings {
+		want := rx2.ReplaceAllString(s, "; ")
+		if got := replace2(s); got != want {
+			t.Errorf("replace2(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func BenchmarkFSM2(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for _, s := range rx2TestStrings {
+			replace2(s)
+		}
+	}
+}
+
+func BenchmarkRegexp2(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for _, s := range rx2TestStrings {
+			rx2.ReplaceAllString(s, "; ")
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Generate matching functions from //re2dfa:match directives in Go source code.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/opennota/re2dfa/codegen"
+	"github.com/opennota/re2dfa/dfa"
+	"github.com/opennota/re2dfa/nfa"
+)
+
+func main() {
+	log.SetFlags(0)
+
+	withTest := flag.Bool("test", false, "Also write PACKAGE_re2dfa_test.go")
+	flag.Usage = func() {
+		fmt.Print(`Usage: re2dfagen [-test] [directory...]
+
+Scans the Go files in each directory (the current directory by default)
+for directives of the form
+
+    //re2dfa:match matchDate ^\d{4}-\d{2}-\d{2}$
+    var _ func(string) int = matchDate
+
+and writes the matching functions to PACKAGE_re2dfa.go. The stub declaration
+following the directive determines the type of the argument, which is either
+string or []byte. The regexp may be given as a quoted Go string.
+
+With -test, PACKAGE_re2dfa_test.go checking the generated functions against
+the regexp package on sampled inputs is written as well.
+`)
+	}
+	flag.Parse()
+
+	dirs := flag.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	failed := false
+	for _, dir := range dirs {
+		for _, err := range generateDir(dir, *withTest) {
+			fmt.Fprintln(os.Stderr, err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func generateDir(dir string, withTest bool) []error {
+	pkgs, errs := scanDir(dir)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	names := make([]string, 0, len(pkgs))
+	for name := range pkgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		// An invalid regexp only prevents the generation of the files of its own package.
+		var funcs []codegen.Func
+		valid := true
+		for _, d := range pkgs[name] {
+			nfanode, err := nfa.New(d.pattern)
+			if err != nil {
+				errs = append(errs, &posError{d.pos, err.Error()})
+				valid = false
+				continue
+			}
+			funcs = append(funcs, codegen.Func{
+				Name:    d.name,
+				Type:    d.typ,
+				Pattern: d.pattern,
+				Root:    dfa.NewFromNFA(nfanode),
+			})
+		}
+		if !valid {
+			continue
+		}
+
+		source, err := codegen.GoGenerateFile(name, funcs...)
+		if err != nil {
+			errs = append(errs, directiveError(pkgs[name], err))
+			continue
+		}
+		if err := os.WriteFile(outputFile(dir, name), []byte(source), 0644); err != nil {
+			errs = append(errs, err)
+		}
+
+		if withTest {
+			source, err := codegen.GoGenerateTest(name, funcs...)
+			if err != nil {
+				errs = append(errs, directiveError(pkgs[name], err))
+				continue
+			}
+			if err := os.WriteFile(testFile(dir, name), []byte(source), 0644); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errs
+}
+
+// directiveError ties a code generation error to the position of the directive of the offending function.
+func directiveError(dd []directive, err error) error {
+	var e *codegen.Error
+	if errors.As(err, &e) {
+		for _, d := range dd {
+			if d.name == e.Func {
+				return &posError{d.pos, err.Error()}
+			}
+		}
+	}
+	return err
+}
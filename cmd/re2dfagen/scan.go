@@ -0,0 +1,224 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	directivePrefix = "//re2dfa:"
+	matchDirective  = directivePrefix + "match"
+	generatedSuffix = "_re2dfa.go"
+	testSuffix      = "_re2dfa_test.go"
+)
+
+// A directive is a //re2dfa:match comment together with its stub declaration.
+type directive struct {
+	pos     token.Position
+	name    string // name of the matching function
+	pattern string // regular expression
+	typ     string // string or []byte
+}
+
+// A posError is an error tied to a position in the source code.
+type posError struct {
+	pos token.Position
+	msg string
+}
+
+func (e *posError) Error() string {
+	return fmt.Sprintf("%s: %s", e.pos, e.msg)
+}
+
+// scanDir parses the Go files in dir and returns the directives found, grouped by package name.
+func scanDir(dir string) (map[string][]directive, []error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		name := fi.Name()
+		return !strings.HasSuffix(name, "_test.go") && !strings.HasSuffix(name, generatedSuffix)
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	var errs []error
+	result := make(map[string][]directive)
+	for name, pkg := range pkgs {
+		filenames := make([]string, 0, len(pkg.Files))
+		for fn := range pkg.Files {
+			filenames = append(filenames, fn)
+		}
+		sort.Strings(filenames)
+
+		for _, fn := range filenames {
+			dd, ee := scanFile(fset, pkg.Files[fn])
+			result[name] = append(result[name], dd...)
+			errs = append(errs, ee...)
+		}
+	}
+
+	for name, dd := range result {
+		seen := make(map[string]token.Position)
+		for _, d := range dd {
+			if pos, ok := seen[d.name]; ok {
+				errs = append(errs, &posError{d.pos, fmt.Sprintf("%s redeclared (previous declaration at %s)", d.name, pos)})
+				continue
+			}
+			seen[d.name] = d.pos
+		}
+		if len(dd) == 0 {
+			delete(result, name)
+		}
+	}
+
+	return result, errs
+}
+
+func scanFile(fset *token.FileSet, file *ast.File) (directives []directive, errs []error) {
+	used := make(map[*ast.Comment]bool)
+
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			doc := vs.Doc
+			if doc == nil && !gd.Lparen.IsValid() {
+				doc = gd.Doc
+			}
+			if doc == nil {
+				continue
+			}
+
+			var found *ast.Comment
+			for _, c := range doc.List {
+				if !strings.HasPrefix(c.Text, directivePrefix) {
+					continue
+				}
+				used[c] = true
+				if found != nil {
+					errs = append(errs, &posError{fset.Position(c.Pos()), "more than one directive for a single stub declaration"})
+					continue
+				}
+				found = c
+			}
+			if found == nil {
+				continue
+			}
+
+			d, err := parseDirective(fset, found, vs)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			directives = append(directives, d)
+		}
+	}
+
+	for _, cg := range file.Comments {
+		for _, c := range cg.List {
+			if strings.HasPrefix(c.Text, directivePrefix) && !used[c] {
+				errs = append(errs, &posError{fset.Position(c.Pos()), "directive is not followed by a stub declaration"})
+			}
+		}
+	}
+
+	return
+}
+
+func parseDirective(fset *token.FileSet, c *ast.Comment, vs *ast.ValueSpec) (directive, error) {
+	d := directive{pos: fset.Position(c.Pos())}
+
+	text := c.Text
+	if !strings.HasPrefix(text, matchDirective+" ") && text != matchDirective {
+		verb := strings.TrimPrefix(strings.Fields(text)[0], directivePrefix)
+		return d, &posError{d.pos, fmt.Sprintf("unknown directive %q", verb)}
+	}
+	text = strings.TrimSpace(strings.TrimPrefix(text, matchDirective))
+
+	i := strings.IndexAny(text, " \t")
+	if i < 0 {
+		return d, &posError{d.pos, "usage: " + matchDirective + " function regexp"}
+	}
+	d.name = text[:i]
+	d.pattern = strings.TrimSpace(text[i:])
+	if !token.IsIdentifier(d.name) {
+		return d, &posError{d.pos, fmt.Sprintf("invalid function name %q", d.name)}
+	}
+	if len(d.pattern) > 0 && (d.pattern[0] == '"' || d.pattern[0] == '`') {
+		pattern, err := strconv.Unquote(d.pattern)
+		if err != nil {
+			return d, &posError{d.pos, fmt.Sprintf("invalid quoted regexp %s", d.pattern)}
+		}
+		d.pattern = pattern
+	}
+
+	typ, ok := stubType(vs.Type)
+	if !ok {
+		return d, &posError{fset.Position(vs.Pos()), "stub must be declared as func(string) int or func([]byte) int"}
+	}
+	d.typ = typ
+
+	if len(vs.Values) > 0 {
+		id, ok := vs.Values[0].(*ast.Ident)
+		if len(vs.Values) != 1 || !ok || id.Name != d.name {
+			return d, &posError{fset.Position(vs.Pos()), fmt.Sprintf("stub must refer to %s", d.name)}
+		}
+	}
+
+	return d, nil
+}
+
+// stubType returns the argument type of a stub declared as func(string) int or func([]byte) int.
+func stubType(expr ast.Expr) (string, bool) {
+	ft, ok := expr.(*ast.FuncType)
+	if !ok || ft.Params.NumFields() != 1 || ft.Results.NumFields() != 1 {
+		return "", false
+	}
+
+	if id, ok := ft.Results.List[0].Type.(*ast.Ident); !ok || id.Name != "int" {
+		return "", false
+	}
+
+	switch t := ft.Params.List[0].Type.(type) {
+	case *ast.Ident:
+		if t.Name == "string" {
+			return "string", true
+		}
+	case *ast.ArrayType:
+		if id, ok := t.Elt.(*ast.Ident); ok && t.Len == nil && id.Name == "byte" {
+			return "[]byte", true
+		}
+	}
+	return "", false
+}
+
+func outputFile(dir, pkg string) string {
+	return filepath.Join(dir, pkg+generatedSuffix)
+}
+
+func testFile(dir, pkg string) string {
+	return filepath.Join(dir, pkg+testSuffix)
+}
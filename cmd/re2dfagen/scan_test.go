@@ -0,0 +1,145 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const source = `package dates
+
+//re2dfa:match matchDate ^\d{4}-\d{2}-\d{2}$
+var _ func(string) int = matchDate
+
+var (
+	//re2dfa:match matchWord "\\w+"
+	_ func([]byte) int = matchWord
+)
+`
+
+func TestScanDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "dates.go"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pkgs, errs := scanDir(dir)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	dd := pkgs["dates"]
+	if len(dd) != 2 {
+		t.Fatalf("got %d directives, want 2", len(dd))
+	}
+	want := []directive{
+		{name: "matchDate", pattern: `^\d{4}-\d{2}-\d{2}$`, typ: "string"},
+		{name: "matchWord", pattern: `\w+`, typ: "[]byte"},
+	}
+	for i, d := range dd {
+		if d.name != want[i].name || d.pattern != want[i].pattern || d.typ != want[i].typ {
+			t.Errorf("directive #%d = %s %q %s, want %s %q %s", i, d.name, d.pattern, d.typ, want[i].name, want[i].pattern, want[i].typ)
+		}
+	}
+
+	if errs := generateDir(dir, false); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "dates_re2dfa.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"package dates", "func matchDate(s string) (end int)", "func matchWord(s []byte) (end int)"} {
+		if !strings.Contains(string(data), s) {
+			t.Errorf("generated file does not contain %q", s)
+		}
+	}
+}
+
+func TestScanDirErrors(t *testing.T) {
+	dir := t.TempDir()
+	src := `package bad
+
+//re2dfa:match matchA a(
+var _ func(string) int = matchA
+
+//re2dfa:match matchB b
+var _ func(rune) int = matchB
+
+//re2dfa:match matchC c
+func f() {}
+
+//re2dfa:search matchD d
+var _ func(string) int = matchD
+`
+	if err := os.WriteFile(filepath.Join(dir, "bad.go"), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := generateDir(dir, false)
+	var got []string
+	for _, err := range errs {
+		got = append(got, strings.TrimPrefix(err.Error(), dir+string(filepath.Separator)))
+	}
+	want := []string{
+		"bad.go:7:5: stub must be declared as func(string) int or func([]byte) int",
+		"bad.go:12:1: unknown directive \"search\"",
+		"bad.go:9:1: directive is not followed by a stub declaration",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got errors %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("error #%d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	// Errors in regular expressions are only reported when the directives themselves are valid.
+	src = "package bad\n\n//re2dfa:match matchA a(\nvar _ func(string) int = matchA\n"
+	if err := os.WriteFile(filepath.Join(dir, "bad.go"), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	errs = generateDir(dir, false)
+	if len(errs) != 1 || !strings.HasSuffix(errs[0].Error(), "bad.go:3:1: parse: error parsing regexp: missing closing ): `a(` at offset 0") {
+		t.Errorf("got errors %v", errs)
+	}
+}
+
+func TestGenerateDirPackages(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.go": "package a\n\n//re2dfa:match matchA a(\nvar _ func(string) int = matchA\n",
+		"b.go": "package b\n\n//re2dfa:match matchB b+\nvar _ func(string) int = matchB\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The invalid regexp of package a doesn't prevent the generation of package b.
+	errs := generateDir(dir, false)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "a.go:3:1:") {
+		t.Errorf("got errors %v", errs)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a_re2dfa.go")); err == nil {
+		t.Error("a_re2dfa.go was generated")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "b_re2dfa.go")); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,197 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/opennota/re2dfa/dfa"
+	"github.com/opennota/re2dfa/nfa"
+)
+
+// cAssertions maps pseudo-runes to C expressions.
+var cAssertions = map[rune]string{
+	nfa.RuneBeginText:      "i == 0",
+	nfa.RuneEndText:        "i == n",
+	nfa.RuneBeginLine:      `i == 0 || s[i - 1] == '\n'`,
+	nfa.RuneEndLine:        `i == n || s[i] == '\n'`,
+	nfa.RuneWordBoundary:   "(i > 0 && %[1]s_is_word_char(s[i - 1])) != (i < n && %[1]s_is_word_char(s[i]))",
+	nfa.RuneNoWordBoundary: "(i > 0 && %[1]s_is_word_char(s[i - 1])) == (i < n && %[1]s_is_word_char(s[i]))",
+}
+
+const cDecodeRune = `
+static int32_t %[1]s_decode_rune(const uint8_t *s, size_t n, size_t *len)
+{
+	uint8_t lo = 0x80, hi = 0xbf;
+	int32_t r;
+	size_t k, i;
+
+	if (n == 0) {
+		*len = 0;
+		return 0xfffd;
+	}
+	if (s[0] < 0x80) {
+		*len = 1;
+		return s[0];
+	}
+	if (s[0] >= 0xc2 && s[0] <= 0xdf) {
+		k = 2;
+		r = s[0] & 0x1f;
+	} else if (s[0] >= 0xe0 && s[0] <= 0xef) {
+		k = 3;
+		r = s[0] & 0x0f;
+		if (s[0] == 0xe0)
+			lo = 0xa0;
+		else if (s[0] == 0xed)
+			hi = 0x9f;
+	} else if (s[0] >= 0xf0 && s[0] <= 0xf4) {
+		k = 4;
+		r = s[0] & 0x07;
+		if (s[0] == 0xf0)
+			lo = 0x90;
+		else if (s[0] == 0xf4)
+			hi = 0x8f;
+	} else {
+		*len = 1;
+		return 0xfffd;
+	}
+	if (n < k) {
+		*len = 1;
+		return 0xfffd;
+	}
+	for (i = 1; i < k; i++) {
+		if (s[i] < lo || s[i] > hi) {
+			*len = 1;
+			return 0xfffd;
+		}
+		lo = 0x80;
+		hi = 0xbf;
+		r = (r << 6) | (s[i] & 0x3f);
+	}
+	*len = k;
+	return r;
+}
+`
+
+const cIsWordChar = `
+static int %[1]s_is_word_char(uint8_t c)
+{
+	return ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || c == '_';
+}
+`
+
+// CGenerate generates a self-contained C source file containing the matching function
+//
+//	ptrdiff_t funcName(const uint8_t *s, size_t n);
+//
+// which returns the end of the match at the beginning of the n bytes pointed to by s, or -1 if there is no match.
+// The input is decoded as UTF-8 the same way as in Go, invalid bytes are treated as U+FFFD.
+// Automata with counters are not supported; they are reported as an *Error.
+func CGenerate(root *dfa.Node, funcName string) (string, error) {
+	if root.Counts() {
+		return "", &Error{Stage: "generate", Func: funcName, Err: errCounts}
+	}
+	m := newMachine(root)
+
+	var buf bytes.Buffer
+
+	assertions := make(map[rune]string, len(cAssertions))
+	for r, a := range cAssertions {
+		assertions[r] = strings.Replace(a, "%[1]s", funcName, -1)
+	}
+
+	for _, s := range m.states {
+		if m.label(s) {
+			fmt.Fprintf(&buf, "s%d:\n", s.n)
+		}
+
+		if len(s.empty) > 0 {
+			for bi, b := range s.empty {
+				elseIf := "if"
+				if bi > 0 {
+					elseIf = "} else if"
+				}
+				fmt.Fprintf(&buf, "\t%s (%s) {\n", elseIf, rangesToParenExpr(b.r, assertions))
+				if b.final {
+					fmt.Fprintln(&buf, "\t\tend = i;")
+				}
+				if b.next != 0 {
+					fmt.Fprintf(&buf, "\t\tgoto s%d;\n", b.next)
+				} else if len(s.runes) > 0 {
+					fmt.Fprintln(&buf, "\t\tgoto done;")
+				}
+			}
+			fmt.Fprintln(&buf, "\t}")
+		}
+
+		if len(s.runes) > 0 {
+			fmt.Fprintf(&buf, `	r = %s_decode_rune(s + i, n - i, &rlen);
+	if (rlen == 0)
+		goto done;
+	i += rlen;
+`, funcName)
+			for bi, b := range s.runes {
+				elseIf := "if"
+				if bi > 0 {
+					elseIf = "} else if"
+				}
+				fmt.Fprintf(&buf, "\t%s (%s) {\n", elseIf, rangesToParenExpr(b.r, assertions))
+				if b.final {
+					fmt.Fprintln(&buf, "\t\tend = i;")
+				}
+				if b.next != 0 {
+					fmt.Fprintf(&buf, "\t\tgoto s%d;\n", b.next)
+				}
+			}
+			fmt.Fprintln(&buf, "\t}")
+		}
+		fmt.Fprintln(&buf, "\tgoto done;")
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintln(&out, `/* Code generated by re2dfa (https://github.com/opennota/re2dfa). */
+
+#include <stddef.h>
+#include <stdint.h>`)
+	if m.decodes() {
+		fmt.Fprintf(&out, cDecodeRune, funcName)
+	}
+	if m.wordBoundary {
+		fmt.Fprintf(&out, cIsWordChar, funcName)
+	}
+
+	end := -1
+	if m.final {
+		end = 0
+	}
+	fmt.Fprintf(&out, `
+ptrdiff_t %s(const uint8_t *s, size_t n)
+{
+	ptrdiff_t end = %d;
+	int32_t r = 0;
+	size_t rlen = 0;
+	size_t i = 0;
+`, funcName, end)
+	fmt.Fprintln(&out, "\n\t(void)s;\n\t(void)n;\n\t(void)r;\n\t(void)rlen;\n\t(void)i;")
+	out.Write(buf.Bytes())
+	if len(m.states) > 0 {
+		// Every state ends with goto done.
+		fmt.Fprintln(&out, "done:")
+	}
+	fmt.Fprintln(&out, "\treturn end;\n}")
+
+	return strings.Replace(out.String(), "\n\n\n", "\n\n", -1), nil
+}
@@ -0,0 +1,215 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/opennota/re2dfa/dfa"
+	"github.com/opennota/re2dfa/nfa"
+)
+
+// Patterns checked against the regexp package in the tests of the backends.
+var backendPatterns = []string{
+	"abcdef",
+	"[a-z]",
+	"a*",
+	"a?",
+	"a+",
+	"(abc|def)",
+	"a{1,3}",
+	"a{0,3}",
+	"ab+c",
+	"^a",
+	"^",
+	"a$",
+	"(?m)^a",
+	"(?m)^",
+	"(?m)a$",
+	`a\b`,
+	`a\B`,
+	`\b`,
+	`\B`,
+	`(?s).*?\bb`,
+	`(?m)a*?^b`,
+	"a??",
+	"a??b",
+	"a*?",
+	"a*?b",
+	"a+?",
+	"a+?b",
+	"ab??c",
+	"(?i)aZ",
+	"(?i)[a-z]",
+	"",
+	"a|ab",
+	"日本+語?",
+	`[^a]\x{10000}.`,
+	`(?s).`,
+	`\pL+\d*`,
+	`<[?].*?[?]>`,
+	`<!--(?:-?[^>-])(?:-?[^-])*-->`,
+}
+
+type backendCase struct {
+	pattern int
+	in      string
+	want    int
+}
+
+// backendCases returns inputs sampled for each pattern with the results expected from the matching function.
+func backendCases(t *testing.T) ([]*dfa.Node, []backendCase) {
+	var roots []*dfa.Node
+	var cases []backendCase
+	for i, pattern := range backendPatterns {
+		nfanode, err := nfa.New(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		root := dfa.NewFromNFA(nfanode)
+		roots = append(roots, root)
+
+		re := regexp.MustCompile(anchoredPattern(pattern))
+		if !root.LeftmostFirst {
+			re.Longest()
+		}
+		matching, nonMatching := sample(root, testSamples)
+		for _, s := range append(matching, nonMatching...) {
+			want := -1
+			if loc := re.FindStringIndex(s); loc != nil {
+				want = loc[1]
+			}
+			cases = append(cases, backendCase{i, s, want})
+		}
+	}
+	return roots, cases
+}
+
+// countingRoot returns an automaton counting a bounded repetition, which the backends other than Go reject.
+func countingRoot(t *testing.T) *dfa.Node {
+	r, err := nfa.Options{CountThreshold: 8}.Parse("[a-z]{1,100}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nfanode, err := nfa.NewFromRegexp(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dfa.NewFromNFA(nfanode)
+}
+
+// checkBackend runs the command for all the cases and compares the results. The command reads lines containing
+// the pattern number and the hex-encoded input, and writes the result for each line.
+func checkBackend(t *testing.T, cmd *exec.Cmd, cases []backendCase) {
+	var in bytes.Buffer
+	for _, c := range cases {
+		fmt.Fprintf(&in, "%d %s\n", c.pattern, hex.EncodeToString([]byte(c.in)))
+	}
+	cmd.Stdin = &in
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for _, c := range cases {
+		if !sc.Scan() {
+			t.Fatal("unexpected end of output")
+		}
+		got, err := strconv.Atoi(strings.TrimSpace(sc.Text()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Errorf("%q: match(%q) = %d, want %d", backendPatterns[c.pattern], c.in, got, c.want)
+		}
+	}
+}
+
+const cMain = `
+#include <stdio.h>
+
+static int unhex(int c)
+{
+	return c <= '9' ? c - '0' : c - 'a' + 10;
+}
+
+int main(void)
+{
+	static char line[65536];
+	static uint8_t buf[32768];
+	int p;
+	size_t n;
+	char *h;
+
+	while (fgets(line, sizeof(line), stdin) != NULL) {
+		p = atoi(line);
+		h = strchr(line, ' ') + 1;
+		for (n = 0; h[0] != '\n' && h[0] != 0; h += 2)
+			buf[n++] = unhex(h[0]) << 4 | unhex(h[1]);
+		switch (p) {
+%s		}
+	}
+	return 0;
+}
+`
+
+func TestCGenerate(t *testing.T) {
+	cc, err := exec.LookPath("cc")
+	if err != nil {
+		t.Skip("no C compiler")
+	}
+
+	roots, cases := backendCases(t)
+
+	var src bytes.Buffer
+	fmt.Fprintln(&src, "#include <stdlib.h>\n#include <string.h>")
+	var calls bytes.Buffer
+	for i, root := range roots {
+		code, err := CGenerate(root, fmt.Sprintf("match%d", i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		src.WriteString(code)
+		fmt.Fprintf(&calls, "\t\tcase %d:\n\t\t\tprintf(\"%%td\\n\", match%[1]d(buf, n));\n\t\t\tbreak;\n", i)
+	}
+	fmt.Fprintf(&src, cMain, calls.String())
+
+	dir := t.TempDir()
+	fn := filepath.Join(dir, "match.c")
+	if err := writeToFile(fn, src.String()); err != nil {
+		t.Fatal(err)
+	}
+	bin := filepath.Join(dir, "match")
+	if out, err := exec.Command(cc, "-std=c99", "-Wall", "-Werror", "-o", bin, fn).CombinedOutput(); err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+
+	checkBackend(t, exec.Command(bin), cases)
+}
+
+func TestCGenerateCounts(t *testing.T) {
+	if _, err := CGenerate(countingRoot(t), "match"); err == nil {
+		t.Error("no error for an automaton with counters")
+	}
+}
@@ -16,10 +16,20 @@ package codegen
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"go/ast"
 	"go/format"
+	goimporter "go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"hash/fnv"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"unicode"
 
 	"github.com/opennota/re2dfa/dfa"
 	"github.com/opennota/re2dfa/nfa"
@@ -49,27 +59,110 @@ func filter(nodes []*dfa.Node, fn func(n *dfa.Node) bool) []*dfa.Node {
 	return nn
 }
 
-func rangesToBoolExpr(rr []rune) string {
+// goAssertions maps pseudo-runes to Go expressions.
+var goAssertions = map[rune]string{
+	nfa.RuneBeginText:      "i == 0",
+	nfa.RuneEndText:        "i == len(s)",
+	nfa.RuneBeginLine:      `i == 0 || s[i-1] == '\n'`,
+	nfa.RuneEndLine:        `i == len(s) || s[i] == '\n'`,
+	nfa.RuneWordBoundary:   "(i > 0 && %[1]sIsWordChar(s[i-1])) != (i < len(s) && %[1]sIsWordChar(s[i]))",
+	nfa.RuneNoWordBoundary: "(i > 0 && %[1]sIsWordChar(s[i-1])) == (i < len(s) && %[1]sIsWordChar(s[i]))",
+}
+
+// goBackwardAssertions maps pseudo-runes of a reversed pattern to Go expressions.
+var goBackwardAssertions = map[rune]string{
+	nfa.RuneBeginText:      "i == len(s)",
+	nfa.RuneEndText:        "i == 0",
+	nfa.RuneBeginLine:      `i == len(s) || s[i] == '\n'`,
+	nfa.RuneEndLine:        `i == 0 || s[i-1] == '\n'`,
+	nfa.RuneWordBoundary:   goAssertions[nfa.RuneWordBoundary],
+	nfa.RuneNoWordBoundary: goAssertions[nfa.RuneNoWordBoundary],
+}
+
+// assertions returns the assertions adjusted to the options of the function, calling the helpers of the file.
+func (f *goFile) assertions(assertions map[rune]string, fn Func, backward bool) map[rune]string {
+	assertions = goLineAssertions(assertions, fn.LineTerminators, backward)
+
+	fa := make(map[rune]string, len(assertions))
+	for r, a := range assertions {
+		fa[r] = strings.Replace(a, "%[1]s", f.prefix, -1)
+	}
+	if fn.UnicodeWordBoundary {
+		instr := ""
+		if fn.Type == "string" {
+			instr = "InString"
+		}
+		fa[nfa.RuneWordBoundary] = fmt.Sprintf("%sIsUnicodeWordBoundary%s(s, i)", f.prefix, instr)
+		fa[nfa.RuneNoWordBoundary] = fmt.Sprintf("!%sIsUnicodeWordBoundary%s(s, i)", f.prefix, instr)
+	}
+	return fa
+}
+
+// goLineAssertions returns a copy of the assertions with the line anchors recognizing the line terminators lt,
+// or the assertions themselves for the default terminator.
+func goLineAssertions(assertions map[rune]string, lt nfa.LineTerminators, backward bool) map[rune]string {
+	if lt == 0 || lt == nfa.LineLF {
+		return assertions
+	}
+
+	begin := []string{"i == 0"}
+	end := []string{"i == len(s)"}
+	if lt&nfa.LineLF != 0 {
+		begin = append(begin, `s[i-1] == '\n'`)
+		if lt&nfa.LineCRLF != 0 {
+			// Not between \r and \n.
+			end = append(end, `s[i] == '\n' && (i == 0 || s[i-1] != '\r')`)
+		} else {
+			end = append(end, `s[i] == '\n'`)
+		}
+	} else if lt&nfa.LineCRLF != 0 {
+		begin = append(begin, `i >= 2 && s[i-2] == '\r' && s[i-1] == '\n'`)
+	}
+	if lt&nfa.LineCR != 0 {
+		if lt&nfa.LineCRLF != 0 {
+			begin = append(begin, `s[i-1] == '\r' && (i == len(s) || s[i] != '\n')`)
+		} else {
+			begin = append(begin, `s[i-1] == '\r'`)
+		}
+		end = append(end, `s[i] == '\r'`)
+	} else if lt&nfa.LineCRLF != 0 {
+		end = append(end, `s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n'`)
+	}
+	if lt&nfa.LineUnicode != 0 {
+		// U+0085 is encoded as C2 85, U+2028 and U+2029 as E2 80 A8 and E2 80 A9.
+		begin = append(begin,
+			`i >= 2 && s[i-2] == 0xc2 && s[i-1] == 0x85`,
+			`i >= 3 && s[i-3] == 0xe2 && s[i-2] == 0x80 && (s[i-1] == 0xa8 || s[i-1] == 0xa9)`)
+		end = append(end,
+			`i+1 < len(s) && s[i] == 0xc2 && s[i+1] == 0x85`,
+			`i+2 < len(s) && s[i] == 0xe2 && s[i+1] == 0x80 && (s[i+2] == 0xa8 || s[i+2] == 0xa9)`)
+	}
+
+	la := make(map[rune]string, len(assertions))
+	for r, a := range assertions {
+		la[r] = a
+	}
+	la[nfa.RuneBeginLine] = strings.Join(begin, " || ")
+	la[nfa.RuneEndLine] = strings.Join(end, " || ")
+	if backward {
+		// The assertions of a reversed pattern are swapped.
+		la[nfa.RuneBeginLine], la[nfa.RuneEndLine] = la[nfa.RuneEndLine], la[nfa.RuneBeginLine]
+	}
+	return la
+}
+
+// rangesToConds returns the conditions checking that the rune r is in the ranges rr, one for each range.
+// Pseudo-runes are translated using the assertions map.
+func rangesToConds(rr []rune, assertions map[rune]string) []string {
 	if len(rr) == 4 && rr[0] == 0 && rr[3] == nfa.RuneLast {
-		return fmt.Sprintf("r <= %d || r >= %d", rr[1], rr[2])
+		return []string{fmt.Sprintf("r <= %d", rr[1]), fmt.Sprintf("r >= %d", rr[2])}
 	}
 
 	s := make([]string, 0, len(rr))
 	for i := 0; i < len(rr); i += 2 {
 		if rr[i] < 0 {
-			switch rr[i] {
-			case nfa.RuneBeginText:
-				s = append(s, "i == 0")
-			case nfa.RuneEndText:
-				s = append(s, "i == len(s)")
-			case nfa.RuneBeginLine:
-				s = append(s, `i == 0 || r == '\n'`)
-			case nfa.RuneEndLine:
-				s = append(s, `i == len(s) || s[i] == '\n'`)
-			case nfa.RuneWordBoundary:
-				s = append(s, "(i >= rlen && isWordChar(s[i-rlen])) != (rlen > 0 && i < len(s) && isWordChar(s[i]))")
-			case nfa.RuneNoWordBoundary:
-				s = append(s, "(i >= rlen && isWordChar(s[i-rlen])) == (rlen > 0 && i < len(s) && isWordChar(s[i]))")
+			if a, ok := assertions[rr[i]]; ok {
+				s = append(s, a)
 			}
 		} else if rr[i] == rr[i+1] {
 			s = append(s, fmt.Sprintf("r == %d", rr[i]))
@@ -81,7 +174,26 @@ func rangesToBoolExpr(rr []rune) string {
 			s = append(s, fmt.Sprintf("r >= %d && r <= %d", rr[i], rr[i+1]))
 		}
 	}
-	return strings.Join(s, "||")
+	return s
+}
+
+// rangesToBoolExpr returns a Go expression checking that the rune r is in the ranges rr.
+func rangesToBoolExpr(rr []rune, assertions map[rune]string) string {
+	return strings.Join(rangesToConds(rr, assertions), "||")
+}
+
+// rangesToParenExpr returns an expression checking that the rune r is in the ranges rr
+// with compound conditions parenthesized.
+func rangesToParenExpr(rr []rune, assertions map[rune]string) string {
+	conds := rangesToConds(rr, assertions)
+	if len(conds) > 1 {
+		for i, c := range conds {
+			if strings.Contains(c, "&&") || strings.Contains(c, "||") {
+				conds[i] = "(" + c + ")"
+			}
+		}
+	}
+	return strings.Join(conds, " || ")
 }
 
 func positive(rr []rune) []rune {
@@ -98,224 +210,858 @@ func (s nodesByState) Len() int           { return len(s) }
 func (s nodesByState) Less(i, j int) bool { return s[i].S < s[j].S }
 func (s nodesByState) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 
-func GoGenerate(root *dfa.Node, packageName, funcName, typ string) string {
-	if !(typ == "string" || typ == "[]byte") {
-		panic(fmt.Sprintf("invalid type: %s; expected either string or []byte", typ))
+// Mode is the kind of the generated function.
+type Mode int
+
+const (
+	// ModeMatch generates func(s) (end int) returning the end of the match at the beginning of s, or -1.
+	ModeMatch Mode = iota
+	// ModeSearch generates func(s) (start, end int) returning the leftmost match in s, or -1, -1.
+	ModeSearch
+	// ModeFindAll generates func(s, n int) [][2]int returning the successive non-overlapping matches in s,
+	// like regexp.FindAllStringIndex, and funcFunc(s, n int, yield func(start, end int) bool) calling yield
+	// for each of them until it returns false.
+	ModeFindAll
+	// ModeReplaceAll generates func(s) returning a copy of s with the matches replaced by the expansion of
+	// the template, like regexp.ReplaceAllString.
+	ModeReplaceAll
+	// ModeSplit generates func(s, n int) returning the substrings of s between the matches, like regexp.Split.
+	// As regexp.Split tells the empty pattern apart by its text, an empty Pattern is taken as the empty pattern
+	// if the automaton has a single final state, and an automaton matching an empty string is rejected
+	// otherwise.
+	ModeSplit
+	// ModeBool generates func(s) bool reporting whether there is a match at the beginning of s. It returns
+	// as soon as a final state is reached.
+	ModeBool
+	// ModeMatcher generates a type with the methods MatchString, Match, FindStringIndex, FindIndex, String
+	// (returning Pattern) and LiteralPrefix of *regexp.Regexp, implementing matcher.Matcher. Type is ignored.
+	// LiteralPrefix returns the prefix the regexp package finds in Pattern.
+	ModeMatcher
+)
+
+// Func describes a matching function.
+type Func struct {
+	Name    string    // name of the function
+	Type    string    // type of the argument: string or []byte
+	Mode    Mode      // kind of the function
+	Pattern string    // regular expression (optional, used in generated tests and to check Template)
+	Root    *dfa.Node // automaton (see Search)
+
+	// Line terminators recognized by (?m)^ and (?m)$; they should be the ones used to construct the automaton.
+	LineTerminators nfa.LineTerminators
+
+	// Evaluate \b and \B against the Unicode word characters (letters, marks, decimal digits and connector
+	// punctuation) instead of the ASCII ones of the regexp package.
+	UnicodeWordBoundary bool
+
+	// Replacement template in ModeReplaceAll (see CheckTemplate).
+	Template string
+
+	// Automata for the forward-then-reverse scan in the modes finding the matches anywhere in s (optional):
+	// the unanchored search automaton and the anchored automaton of the reversed pattern (see dfa.NewSearchFromNFA).
+	// If they are not set, or Root has counters, the search tries Root at every position.
+	//
+	// For the matches of the regexp package, Root and Search find the leftmost-first match in these modes
+	// (see dfa.NewLeftmostFirstFromNFA and dfa.NewLeftmostFirstSearchFromNFA), or Root is a counting automaton
+	// whose leftmost-longest matches are the same (see nfa.FirstIsLongest). For the matches of regexp.CompilePOSIX,
+	// they find the leftmost-longest match (see dfa.NewFromNFA and dfa.NewSearchFromNFA).
+	Search, Reverse *dfa.Node
+}
+
+// An Error describes a failure to generate a file.
+type Error struct {
+	Stage string // "generate", "format" or "typecheck"
+	Func  string // name of the offending function, or "" if the whole file is concerned
+	Err   error
+}
+
+func (e *Error) Error() string {
+	if e.Func == "" {
+		return e.Stage + ": " + e.Err.Error()
 	}
+	return e.Stage + ": " + e.Func + ": " + e.Err.Error()
+}
 
-	instr := ""
-	if typ == "string" {
-		instr = "InString"
+func (e *Error) Unwrap() error { return e.Err }
+
+// errCounts is returned by the backends which don't implement the counters of bounded repetitions.
+var errCounts = errors.New("counted repetitions are not supported")
+
+// GoGenerate generates a Go source file containing a single matching function.
+func GoGenerate(root *dfa.Node, packageName, funcName, typ string) (string, error) {
+	return GoGenerateFile(packageName, Func{Name: funcName, Type: typ, Root: root})
+}
+
+// GoGenerateFile generates a Go source file containing a matching function for each of funcs.
+// The file is self-contained: the helper functions it needs are named after the first function and
+// a hash of its name (see helperPrefix), so that several generated files can be put in the same package.
+// The file is type-checked before being returned; failures are reported as an *Error.
+func GoGenerateFile(packageName string, funcs ...Func) (string, error) {
+	f := goFile{imports: make(map[string]bool), unicodeWord: make(map[string]bool)}
+	if len(funcs) > 0 {
+		f.prefix = helperPrefix(funcs[0].Name)
+	}
+	var body bytes.Buffer
+	for _, fn := range funcs {
+		if err := f.function(&body, fn); err != nil {
+			return "", &Error{Stage: "generate", Func: fn.Name, Err: err}
+		}
 	}
+	body.WriteString(f.helpers())
 
-	nodes := allNodes(root, make(map[*dfa.Node]struct{}))
-	nodes = filter(nodes, func(n *dfa.Node) bool {
-		return len(n.T) > 0
-	})
-	sort.Sort(nodesByState(nodes))
+	paths := make([]string, 0, len(f.imports))
+	for path := range f.imports {
+		paths = append(paths, strconv.Quote(path))
+	}
+	sort.Strings(paths)
+	imports := ""
+	if len(paths) == 1 {
+		imports = "import " + paths[0]
+	} else if len(paths) > 1 {
+		imports = "import (\n" + strings.Join(paths, "\n") + "\n)"
+	}
 
-	labelFirstState := false
-	enableLazy := false
-	lazyCount := 0
-	var lazyStates map[int]struct{}
-	for i, n := range nodes {
-		for _, t := range n.T {
-			if t.N == nodes[0] {
-				labelFirstState = true
-			}
-			hasLazy := false
-			for i := 0; i < len(t.R); i += 2 {
-				if t.R[i] == nfa.RuneLazy {
-					hasLazy = true
-					break
-				}
-			}
-			if hasLazy {
-				enableLazy = true
-				lazyCount++
-				if i == 0 {
-					labelFirstState = true
-				}
-			}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+			package %s
+			%s
+`, packageName, imports)
+	buf.Write(body.Bytes())
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return "", &Error{Stage: "format", Err: err}
+	}
+	if err := typeCheck(packageName, source); err != nil {
+		return "", &Error{Stage: "typecheck", Err: err}
+	}
+
+	return string(source), nil
+}
+
+var (
+	importerMu sync.Mutex
+	importer   = goimporter.Default()
+)
+
+// typeCheck type-checks the sources of generated files as a package on their own.
+func typeCheck(packageName string, sources ...[]byte) error {
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, source := range sources {
+		file, err := parser.ParseFile(fset, "", source, 0)
+		if err != nil {
+			return err
 		}
+		files = append(files, file)
 	}
-	returnOrBacktrack := "return"
-	if enableLazy {
-		lazyStates = make(map[int]struct{})
-		returnOrBacktrack = "goto bt"
+
+	importerMu.Lock()
+	defer importerMu.Unlock()
+	conf := types.Config{Importer: importer}
+	_, err := conf.Check(packageName, fset, files, nil)
+	return err
+}
+
+// helperPrefix returns the prefix of the names of the helper functions of a file whose first function is
+// named name. The hash of the name tells apart the names which only differ by the case of the initial,
+// e.g. MatchDate and matchDate.
+func helperPrefix(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return fmt.Sprintf("%s%08x", lowercaseInitial(name), h.Sum32())
+}
+
+func lowercaseInitial(s string) string {
+	for i, r := range s {
+		return string(unicode.ToLower(r)) + s[i+len(string(r)):]
 	}
+	return ""
+}
+
+type goFile struct {
+	imports        map[string]bool
+	prefix         string          // prefix of the names of the helper functions
+	usesIsWordChar bool            // the helper checking ASCII word characters is needed
+	usesCounter    bool            // the type of the registers of the counters is needed
+	unicodeWord    map[string]bool // helpers checking Unicode word boundaries, by the suffix of the name
+}
 
-	needUtf8 := false
-	atLeastOneSwitch := false
-	usesIsWordChar := false
+// useWordBoundary records that the function checks word boundaries.
+func (f *goFile) useWordBoundary(fn Func) {
+	if !fn.UnicodeWordBoundary {
+		f.usesIsWordChar = true
+		return
+	}
+	f.imports["unicode"] = true
+	f.imports["unicode/utf8"] = true
+	if fn.Type == "string" {
+		f.unicodeWord["InString"] = true
+	} else {
+		f.unicodeWord[""] = true
+	}
+}
 
+// helpers returns the helper functions used in the file.
+func (f *goFile) helpers() string {
 	var buf bytes.Buffer
+	if f.usesIsWordChar {
+		fmt.Fprintf(&buf, `
+			func %sIsWordChar(c byte) bool {
+				return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_'
+			}
+`, f.prefix)
+	}
+	if f.usesCounter {
+		fmt.Fprintf(&buf, `
+			// %[1]sCounter holds the numbers of runes counted by the threads in a bounded repetition,
+			// as the numbers of runes counted by the register when they entered the repetition, oldest first.
+			type %[1]sCounter struct {
+				entered []int // ring buffer of the maximum number of runes + 1 numbers
+				head, n int
+				count   int
+			}
 
-	for ni, n := range nodes {
-		if n.S != 1 || labelFirstState {
-			fmt.Fprintf(&buf, "s%d:\n", n.S)
-		}
+			func (c *%[1]sCounter) increment() {
+				c.count++
+				if c.n > 0 && c.count-c.entered[c.head] >= len(c.entered) {
+					// The oldest thread has counted too many runes.
+					c.head = (c.head + 1) %% len(c.entered)
+					c.n--
+				}
+			}
 
-		hasEmpty := false
-		hasNonEmpty := false
-		hasLazy := false
-		for _, t := range n.T {
-			for i := 0; i < len(t.R); i += 2 {
-				if t.R[i] < 0 {
-					if t.R[i] == nfa.RuneLazy {
-						hasLazy = true
-					} else {
-						hasEmpty = true
-					}
-				} else {
-					hasNonEmpty = true
+			func (c *%[1]sCounter) reset() {
+				c.n = 0
+			}
+
+			func (c *%[1]sCounter) enter() {
+				if c.n > 0 && c.entered[(c.head+c.n-1)%%len(c.entered)] == c.count {
+					return
 				}
+				c.entered[(c.head+c.n)%%len(c.entered)] = c.count
+				c.n++
 			}
-		}
 
-		if hasLazy {
-			lazyStates[n.S] = struct{}{}
-			for _, t := range n.T {
-				for i := 0; i < len(t.R) && t.R[i] < 0; i += 2 {
-					if t.R[i] != nfa.RuneLazy {
-						continue
-					}
-					fmt.Fprintf(&buf, `if lazy {
-								lazy = false
-								goto s%d
-							}
-							lazyStack = append(lazyStack, jmp{s:%d, i:i})
-							`, t.N.S, n.S)
-					break
+			// max returns the number of runes counted by the oldest thread, or -1 if there are no threads.
+			func (c *%[1]sCounter) max() int {
+				if c.n == 0 {
+					return -1
 				}
+				return c.count - c.entered[c.head]
 			}
+`, f.prefix)
+	}
+	if len(f.unicodeWord) == 0 {
+		return buf.String()
+	}
+
+	for _, instr := range []string{"", "InString"} {
+		if !f.unicodeWord[instr] {
+			continue
+		}
+		typ := "[]byte"
+		if instr != "" {
+			typ = "string"
 		}
+		fmt.Fprintf(&buf, `
+			func %[1]sIsUnicodeWordBoundary%[2]s(s %[3]s, i int) bool {
+				before, after := false, false
+				if i > 0 {
+					r, _ := utf8.DecodeLastRune%[2]s(s[:i])
+					before = %[1]sIsUnicodeWordChar(r)
+				}
+				if i < len(s) {
+					r, _ := utf8.DecodeRune%[2]s(s[i:])
+					after = %[1]sIsUnicodeWordChar(r)
+				}
+				return before != after
+			}
+`, f.prefix, instr, typ)
+	}
+	fmt.Fprintf(&buf, `
+			func %sIsUnicodeWordChar(r rune) bool {
+				return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.In(r, unicode.Mark, unicode.Pc)
+			}
+`, f.prefix)
+	return buf.String()
+}
 
-		if hasEmpty {
-			atLeastOneSwitch = true
-			fmt.Fprintln(&buf, "switch {")
-			for _, t := range n.T {
-				for i := 0; i < len(t.R) && t.R[i] < 0; i += 2 {
-					if t.R[i] == nfa.RuneLazy {
-						continue
-					}
-					if t.R[i] == nfa.RuneWordBoundary || t.R[i] == nfa.RuneNoWordBoundary {
-						usesIsWordChar = true
-					}
-					fmt.Fprintf(&buf, "case %s:\n", rangesToBoolExpr(t.R[i:i+2]))
-					if t.N.F {
-						fmt.Fprintln(&buf, "end = i")
-					}
-					if len(t.N.T) > 0 {
-						fmt.Fprintf(&buf, "goto s%d\n", t.N.S)
-					} else if hasNonEmpty {
-						fmt.Fprintln(&buf, returnOrBacktrack)
-					}
+func (f *goFile) function(out *bytes.Buffer, fn Func) error {
+	if fn.Mode == ModeMatcher {
+		return f.matcher(out, fn)
+	}
+
+	typ := fn.Type
+	if !(typ == "string" || typ == "[]byte") {
+		return fmt.Errorf("invalid type: %s; expected either string or []byte", typ)
+	}
+
+	m := newMachine(fn.Root)
+
+	switch fn.Mode {
+	case ModeMatch:
+		f.match(out, fn, m)
+	case ModeSearch:
+		f.search(out, fn, m)
+	case ModeFindAll:
+		f.findAll(out, fn, m)
+	case ModeReplaceAll:
+		return f.replaceAll(out, fn, m)
+	case ModeSplit:
+		return f.split(out, fn, m)
+	case ModeBool:
+		f.matchBool(out, fn, m)
+	default:
+		return fmt.Errorf("invalid mode: %d", fn.Mode)
+	}
+	return nil
+}
+
+// A scan describes how the code of a machine reads the input.
+type scan struct {
+	label    string // prefix of the state labels
+	result   string // variable set to the position after reaching a final state
+	accept   string // statement executed after reaching a final state instead of setting result
+	finish   string // statement executed when the scan is over
+	backward bool   // the input is read from right to left, starting at i
+	at       string // the position where the backward reading stops, if not at the beginning of s
+}
+
+// automaton writes the code of the machine.
+func (f *goFile) automaton(buf *bytes.Buffer, m *machine, fn Func, sc scan) {
+	instr := ""
+	if fn.Type == "string" {
+		instr = "InString"
+	}
+	if m.decodes() {
+		f.imports["unicode/utf8"] = true
+	}
+
+	assertions := f.assertions(goAssertions, fn, false)
+	decode := fmt.Sprintf(`r, rlen = utf8.DecodeRune%s(s[i:])
+						if rlen == 0 { %%s }
+						i += rlen`, instr)
+	if sc.backward {
+		assertions = f.assertions(goBackwardAssertions, fn, true)
+		decode = fmt.Sprintf(`r, rlen = utf8.DecodeLastRune%s(s[%s:i])
+						if rlen == 0 { %%s }
+						i -= rlen`, instr, sc.at)
+	}
+
+	// body writes the statements executed after a transition on a rune.
+	body := func(b branch) {
+		counterOps(buf, b.ops)
+		if b.cases != nil {
+			fmt.Fprintln(buf, "switch {")
+			for _, c := range b.cases {
+				fmt.Fprintf(buf, "case %s:\n", counterConds(c.cond))
+				if c.final && sc.accept != "" {
+					fmt.Fprintln(buf, sc.accept)
+					continue
+				}
+				counterOps(buf, c.ops)
+				if c.final {
+					fmt.Fprintf(buf, "%s = i\n", sc.result)
+				}
+				if c.next != 0 {
+					fmt.Fprintf(buf, "goto %s%d\n", sc.label, c.next)
 				}
 			}
-			fmt.Fprintln(&buf, "}")
+			fmt.Fprintln(buf, "}")
+			return
+		}
+		if b.final && sc.accept != "" {
+			fmt.Fprintln(buf, sc.accept)
+			return
 		}
+		if b.final {
+			fmt.Fprintf(buf, "%s = i\n", sc.result)
+		}
+		if b.next != 0 {
+			fmt.Fprintf(buf, "goto %s%d\n", sc.label, b.next)
+		}
+	}
 
-		if hasNonEmpty {
-			atLeastOneSwitch = true
-			needUtf8 = true
-			fmt.Fprintf(&buf, `r, rlen = utf8.DecodeRune%s(s[i:])
-						if rlen == 0 { %s }
-						i += rlen
-						switch {
-						`, instr, returnOrBacktrack)
-			for _, t := range n.T {
-				rr := positive(t.R)
-				if len(rr) == 0 {
+	for _, s := range m.states {
+		if m.label(s) {
+			fmt.Fprintf(buf, "%s%d:\n", sc.label, s.n)
+		}
+
+		if len(s.empty) > 0 {
+			fmt.Fprintln(buf, "switch {")
+			for _, b := range s.empty {
+				fmt.Fprintf(buf, "case %s:\n", rangesToBoolExpr(b.r, assertions))
+				if b.final && sc.accept != "" {
+					fmt.Fprintln(buf, sc.accept)
 					continue
 				}
-
-				fmt.Fprintf(&buf, "case %s:\n", rangesToBoolExpr(rr))
-				if t.N.F {
-					fmt.Fprintln(&buf, "end = i")
+				counterOps(buf, b.ops)
+				if b.final {
+					fmt.Fprintf(buf, "%s = i\n", sc.result)
 				}
-				if len(t.N.T) > 0 {
-					fmt.Fprintf(&buf, "goto s%d\n", t.N.S)
+				if b.next != 0 {
+					fmt.Fprintf(buf, "goto %s%d\n", sc.label, b.next)
+				} else if len(s.runes) > 0 {
+					fmt.Fprintln(buf, sc.finish)
 				}
 			}
-			fmt.Fprintln(&buf, "}")
+			fmt.Fprintln(buf, "}")
 		}
-		if !enableLazy || ni != len(nodes)-1 {
-			fmt.Fprintln(&buf, returnOrBacktrack)
+
+		if len(s.runes) > 0 {
+			fmt.Fprintf(buf, decode+`
+						switch {
+						`, sc.finish)
+			for _, b := range s.runes {
+				fmt.Fprintf(buf, "case %s:\n", rangesToBoolExpr(b.r, assertions))
+				body(b)
+			}
+			fmt.Fprintln(buf, "}")
 		}
+		fmt.Fprintln(buf, sc.finish)
 	}
+}
+
+// declareCounters writes the declarations of the registers of the counters of the machine.
+func (f *goFile) declareCounters(buf *bytes.Buffer, m *machine) {
+	for _, c := range m.counters {
+		f.usesCounter = true
+		fmt.Fprintf(buf, "cnt%d := %sCounter{entered: make([]int, %d)}\n", c.N, f.prefix, c.Max+1)
+	}
+}
 
-	if enableLazy {
-		fmt.Fprintln(&buf, `bt:
-					if end >= 0 || len(lazyStack) == 0 { return }
-					var to jmp
-					to, lazyStack = lazyStack[len(lazyStack)-1], lazyStack[:len(lazyStack)-1]
-					lazy = true
-					i = to.i
-					switch to.s {`)
-		states := make([]int, 0, len(lazyStates))
-		for s := range lazyStates {
-			states = append(states, s)
+// initCounters writes the operations on the counters before reading the input, after emptying the registers
+// if the machine runs again.
+func initCounters(buf *bytes.Buffer, m *machine, reset bool) {
+	if reset {
+		for _, c := range m.counters {
+			fmt.Fprintf(buf, "cnt%d.reset()\n", c.N)
 		}
-		sort.Ints(states)
-		for _, s := range states {
-			fmt.Fprintf(&buf, "case %d: goto s%[1]d\n", s)
+	}
+	counterOps(buf, m.init)
+}
+
+// counterOps writes the operations on the counters.
+func counterOps(buf *bytes.Buffer, ops []dfa.CounterOp) {
+	for _, op := range ops {
+		switch op.Action {
+		case dfa.CounterIncrement:
+			fmt.Fprintf(buf, "cnt%d.increment()\n", op.C.N)
+		case dfa.CounterReset:
+			fmt.Fprintf(buf, "cnt%d.reset()\n", op.C.N)
+		case dfa.CounterEnter:
+			fmt.Fprintf(buf, "cnt%d.enter()\n", op.C.N)
 		}
-		fmt.Fprintln(&buf, "}")
-		fmt.Fprintln(&buf, "return")
 	}
+}
 
-	imports := ""
-	if needUtf8 {
-		imports = `import "unicode/utf8"`
+// counterConds returns the Go expression of the conditions on the counters.
+func counterConds(cond []dfa.CounterCond) string {
+	conds := make([]string, 0, len(cond))
+	for _, c := range cond {
+		switch c.State {
+		case dfa.CounterEmpty:
+			conds = append(conds, fmt.Sprintf("cnt%d.n == 0", c.C.N))
+		case dfa.CounterBelowMin:
+			conds = append(conds, fmt.Sprintf("cnt%[1]d.n > 0 && cnt%[1]d.max() < %[2]d", c.C.N, c.C.Min))
+		case dfa.CounterAtLeastMin:
+			conds = append(conds, fmt.Sprintf("cnt%d.max() >= %d", c.C.N, c.C.Min))
+		}
 	}
+	return strings.Join(conds, " && ")
+}
 
-	helperFuncs := ""
-	if usesIsWordChar {
-		helperFuncs = `
-			//func isWordChar(r byte) bool {
-			//        return 'A' <= r && r <= 'Z' || 'a' <= r && r <= 'z' || '0' <= r && r <= '9' || r == '_'
-			//}`
+// match writes a function returning the end of the match at the beginning of s. Unlike in the search modes,
+// the strings required in every match aren't looked for upfront: the automaton only reads as far as the match
+// goes, whereas the check would read the whole of s, making repeated matches over an input (as in a lexer)
+// take quadratic time.
+func (f *goFile) match(out *bytes.Buffer, fn Func, m *machine) {
+	if m.wordBoundary {
+		f.useWordBoundary(fn)
 	}
 
+	var buf bytes.Buffer
+	f.automaton(&buf, m, fn, scan{label: "s", result: "end", finish: "return"})
+
 	end := -1
-	if nodes[0].F {
+	if m.final {
 		end = 0
 	}
 
 	decls := `var r rune
 		var rlen int
 		i := 0`
-	if enableLazy {
-		decls += fmt.Sprintf(`
-			lazy := false
-			type jmp struct { s, i int }
-			var lazyArr [%d]jmp
-			lazyStack := lazyArr[:0]`, lazyCount)
-	}
-
-	var buf2 bytes.Buffer
-	fmt.Fprintf(&buf2, `// Code generated by re2dfa (https://github.com/opennota/re2dfa).
-
-			package %s
-			%s
-			%s
 
+	fmt.Fprintf(out, `
 			func %s(s %s) (end int) {
 				end = %d
 				%s
 				_, _, _ = r, rlen, i
-`, packageName, imports, helperFuncs, funcName, typ, end, decls)
-	buf2.Write(buf.Bytes())
-	if !atLeastOneSwitch {
-		fmt.Fprintln(&buf2, "return")
+`, fn.Name, fn.Type, end, decls)
+	f.declareCounters(out, m)
+	initCounters(out, m, false)
+	out.Write(buf.Bytes())
+	if len(m.states) == 0 {
+		fmt.Fprintln(out, "return")
 	}
-	fmt.Fprintln(&buf2, "}")
+	fmt.Fprintln(out, "}")
+}
 
-	source, err := format.Source(buf2.Bytes())
-	if err != nil {
-		panic(err)
+// matchBool writes a function reporting whether there is a match at the beginning of s. As in match,
+// the strings required in every match aren't looked for upfront.
+func (f *goFile) matchBool(out *bytes.Buffer, fn Func, m *machine) {
+	m = m.earliest()
+	if m.wordBoundary {
+		f.useWordBoundary(fn)
 	}
 
-	return string(source)
+	fmt.Fprintf(out, "\nfunc %s(s %s) bool {\n", fn.Name, fn.Type)
+	if m.final {
+		fmt.Fprintln(out, "return true\n}")
+		return
+	}
+	fmt.Fprintln(out, `var r rune
+				var rlen int
+				i := 0
+				_, _, _ = r, rlen, i`)
+	f.declareCounters(out, m)
+	initCounters(out, m, false)
+	f.automaton(out, m, fn, scan{label: "s", accept: "return true", finish: "return false"})
+	if len(m.states) == 0 {
+		fmt.Fprintln(out, "return false")
+	}
+	fmt.Fprintln(out, "}")
+}
+
+// search writes a function finding the leftmost match in s.
+func (f *goFile) search(out *bytes.Buffer, fn Func, m *machine) {
+	cond, body := f.searchBody(fn, m, "")
+	fmt.Fprintf(out, "\nfunc %s(s %s) (start, end int) {\n", fn.Name, fn.Type)
+	if cond != "" {
+		fmt.Fprintf(out, "if %s {\nreturn -1, -1\n}\n", cond)
+	}
+	fmt.Fprintf(out, "%s}\n", body)
+}
+
+// searchBody returns the body of a function with the results (start, end int) finding the leftmost match
+// in s starting at the position at, or at the beginning of s if at is empty. If the matches start with
+// a literal prefix, the positions where the prefix doesn't occur are skipped.
+//
+// The returned condition is true if s lacks any of the strings required in every match, so that the input
+// can be rejected upfront.
+func (f *goFile) searchBody(fn Func, m *machine, at string) (cond, body string) {
+	pkg := "strings"
+	if fn.Type == "[]byte" {
+		pkg = "bytes"
+	}
+	prefix, _ := fn.Root.LiteralPrefix()
+
+	var missing []string
+	for _, factor := range fn.Root.RequiredFactors() {
+		if strings.Contains(prefix, factor) {
+			// The prefix is looked for anyway.
+			continue
+		}
+		f.imports[pkg] = true
+		if len(factor) == 1 {
+			missing = append(missing, fmt.Sprintf("%s.IndexByte(s, %s) < 0", pkg, strconv.QuoteRune(rune(factor[0]))))
+		} else if fn.Type == "[]byte" {
+			missing = append(missing, fmt.Sprintf("!bytes.Contains(s, []byte(%s))", strconv.Quote(factor)))
+		} else {
+			missing = append(missing, fmt.Sprintf("!strings.Contains(s, %s)", strconv.Quote(factor)))
+		}
+	}
+
+	if fn.Search != nil && fn.Reverse != nil && !fn.Root.Counts() {
+		body = f.scanSearch(fn, pkg, prefix, at)
+	} else {
+		body = f.loopSearch(fn, m, pkg, prefix, at)
+	}
+	return strings.Join(missing, " || "), body
+}
+
+// scanSearch returns the code running the search automaton forward to find the end of the leftmost match,
+// and then the reverse automaton backward from the end to find the start, as RE2 does.
+func (f *goFile) scanSearch(fn Func, pkg, prefix, at string) string {
+	fm := newMachine(fn.Search)
+	rm := newMachine(fn.Reverse)
+	if fm.wordBoundary || rm.wordBoundary {
+		f.useWordBoundary(fn)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, `var r rune
+				var rlen int
+				var i int
+				_, _, _ = r, rlen, i`)
+
+	from := "s"
+	if at != "" {
+		from = "s[" + at + ":]"
+	}
+	index := ""
+	if len(prefix) == 1 {
+		index = fmt.Sprintf("%s.IndexByte(%s, %s)", pkg, from, strconv.QuoteRune(rune(prefix[0])))
+	} else if prefix != "" {
+		arg := strconv.Quote(prefix)
+		if fn.Type == "[]byte" {
+			arg = "[]byte(" + arg + ")"
+		}
+		index = fmt.Sprintf("%s.Index(%s, %s)", pkg, from, arg)
+	}
+	if index != "" {
+		f.imports[pkg] = true
+		fmt.Fprintf(&buf, `i = %s
+				if i < 0 {
+					return -1, -1
+				}
+				`, index)
+		if at != "" {
+			fmt.Fprintf(&buf, "i += %s\n", at)
+		}
+	} else if at != "" {
+		fmt.Fprintf(&buf, "i = %s\n", at)
+	}
+
+	if fm.final {
+		fmt.Fprintln(&buf, "end = i")
+	} else {
+		fmt.Fprintln(&buf, "end = -1")
+	}
+	f.automaton(&buf, fm, fn, scan{label: "f", result: "end", finish: "goto reverse"})
+	if len(fm.states) == 0 {
+		fmt.Fprintln(&buf, "goto reverse")
+	}
+
+	fmt.Fprintln(&buf, "reverse:")
+	fmt.Fprintln(&buf, "if end < 0 { return -1, -1 }")
+	if rm.final {
+		fmt.Fprintln(&buf, "start = end")
+	} else {
+		fmt.Fprintln(&buf, "start = -1")
+	}
+	fmt.Fprintln(&buf, "i = end")
+	f.automaton(&buf, rm, fn, scan{label: "r", result: "start", finish: "return", backward: true, at: at})
+	if len(rm.states) == 0 {
+		fmt.Fprintln(&buf, "return")
+	}
+
+	return buf.String()
+}
+
+// loopSearch returns the code trying the machine at every position in s, from left to right.
+func (f *goFile) loopSearch(fn Func, m *machine, pkg, prefix, at string) string {
+	if m.wordBoundary {
+		f.useWordBoundary(fn)
+	}
+
+	var code bytes.Buffer
+	f.automaton(&code, m, fn, scan{label: "s", result: "end", finish: "goto done"})
+
+	end := "-1"
+	if m.final {
+		end = "start"
+	}
+
+	decls := `var r rune
+		var rlen int
+		var i int`
+	if at != "" {
+		decls += "\nstart = " + at
+	}
+
+	skip := ""
+	if len(prefix) == 1 {
+		f.imports[pkg] = true
+		skip = fmt.Sprintf(`if j := %s.IndexByte(s[start:], %s); j >= 0 {
+					start += j
+				} else {
+					break
+				}
+				`, pkg, strconv.QuoteRune(rune(prefix[0])))
+	} else if prefix != "" {
+		f.imports[pkg] = true
+		arg := strconv.Quote(prefix)
+		if fn.Type == "[]byte" {
+			decls += "\nprefix := []byte(" + arg + ")"
+			arg = "prefix"
+		}
+		skip = fmt.Sprintf(`if j := %s.Index(s[start:], %s); j >= 0 {
+					start += j
+				} else {
+					break
+				}
+				`, pkg, arg)
+	}
+
+	instr := ""
+	if fn.Type == "string" {
+		instr = "InString"
+	}
+	f.imports["unicode/utf8"] = true
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s\n_, _, _ = r, rlen, i\n", decls)
+	f.declareCounters(&buf, m)
+	fmt.Fprintf(&buf, `for {
+					%send = %s
+					i = start
+`, skip, end)
+	initCounters(&buf, m, true)
+	buf.Write(code.Bytes())
+	if len(m.states) == 0 {
+		fmt.Fprintln(&buf, "goto done")
+	}
+	fmt.Fprintf(&buf, `done:
+					if end >= 0 {
+						return
+					}
+					_, rlen = utf8.DecodeRune%s(s[start:])
+					if rlen == 0 {
+						break
+					}
+					start += rlen
+				}
+				return -1, -1
+`, instr)
+	return buf.String()
+}
+
+// findAll writes a function collecting the matches in s and a function passing them to a callback.
+func (f *goFile) findAll(out *bytes.Buffer, fn Func, m *machine) {
+	cond, body := f.searchBody(fn, m, "at")
+	reject := ""
+	if cond != "" {
+		reject = fmt.Sprintf("if %s {\nreturn\n}\n", cond)
+	}
+
+	fmt.Fprintf(out, `
+			func %[1]s(s %[2]s, n int) [][2]int {
+				var matches [][2]int
+				%[1]sFunc(s, n, func(start, end int) bool {
+					matches = append(matches, [2]int{start, end})
+					return true
+				})
+				return matches
+			}
+
+			func %[1]sFunc(s %[2]s, n int, yield func(start, end int) bool) {
+				%[3]ssearch := func(at int) (start, end int) {
+					%[4]s}
+				%[5]s
+			}
+`, fn.Name, fn.Type, reject, body, f.findAllLoop(fn, `if !yield(start, end) {
+					return
+				}`))
+}
+
+// findAllLoop returns a loop calling search for the successive non-overlapping matches in s, at most n of them
+// if n >= 0, and executing the code yield for each match with the variables start and end set.
+// Empty matches are treated as in the regexp package: an empty match right after the previous match is ignored.
+func (f *goFile) findAllLoop(fn Func, yield string) string {
+	instr := ""
+	if fn.Type == "string" {
+		instr = "InString"
+	}
+	f.imports["unicode/utf8"] = true
+
+	return fmt.Sprintf(`limit := n
+				if limit < 0 {
+					limit = len(s) + 1
+				}
+				for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+					start, end := search(pos)
+					if start < 0 {
+						break
+					}
+					accept := true
+					if end <= pos {
+						// An empty match; end < pos is not expected, but pos moves forward anyway.
+						if start == prevEnd {
+							accept = false
+						}
+						if _, width := utf8.DecodeRune%s(s[pos:]); width > 0 {
+							pos += width
+						} else {
+							pos = len(s) + 1
+						}
+					} else {
+						pos = end
+					}
+					prevEnd = end
+					if accept {
+						%s
+						k++
+					}
+				}`, instr, yield)
+}
+
+// split writes a function slicing s into the substrings separated by the matches, like regexp.Split.
+func (f *goFile) split(out *bytes.Buffer, fn Func, m *machine) error {
+	// regexp.Split checks the pattern text rather than whether it can match an empty string: an empty s is
+	// a single substring unless the pattern is empty.
+	empty := fn.Pattern == "" && fn.Root.F && len(fn.Root.T) == 0
+	if fn.Pattern == "" && !empty && matchesEmptyInput(fn) {
+		return errors.New("the pattern matches an empty string, which can't be split like regexp.Split does without the pattern")
+	}
+
+	cond, body := f.searchBody(fn, m, "at")
+	reject := ""
+	if cond != "" {
+		reject = fmt.Sprintf("if %s {\nreturn []%s{s}\n}\n", cond, fn.Type)
+	}
+	if !empty {
+		reject = fmt.Sprintf("if len(s) == 0 {\nreturn []%s{s}\n}\n", fn.Type) + reject
+	}
+
+	fmt.Fprintf(out, `
+			func %[1]s(s %[2]s, n int) []%[2]s {
+				if n == 0 {
+					return nil
+				}
+				%[3]ssearch := func(at int) (start, end int) {
+					%[4]s}
+				parts := []%[2]s{}
+				beg, last := 0, 0
+				%[5]s
+				if last != len(s) {
+					parts = append(parts, s[beg:])
+				}
+				return parts
+			}
+`, fn.Name, fn.Type, reject, body, f.findAllLoop(fn, `if n > 0 && len(parts) == n-1 {
+					break
+				}
+				last = start
+				if end != 0 {
+					parts = append(parts, s[beg:start])
+				}
+				beg = end`))
+	return nil
+}
+
+// matchesEmptyInput reports whether the automaton of the function matches an empty input.
+func matchesEmptyInput(fn Func) bool {
+	seen := make(map[*dfa.Node]bool)
+	var visit func(n *dfa.Node) bool
+	visit = func(n *dfa.Node) bool {
+		if seen[n] {
+			return false
+		}
+		seen[n] = true
+		if n.F {
+			return true
+		}
+		for _, t := range n.T {
+			for k := 0; k < len(t.R) && t.R[k] < 0; k += 2 {
+				if nfa.Assert(t.R[k], "", 0, fn.LineTerminators, fn.UnicodeWordBoundary) && visit(t.N) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return visit(fn.Root)
 }
@@ -14,7 +14,13 @@
 package codegen
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"reflect"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
 	"strings"
 	"testing"
 	"unicode"
@@ -67,6 +73,7 @@ func TestGenerateTests(t *testing.T) {
 		{"(?m)^", "StartOfLineEmpty"},
 		{"(?m)a$", "EndOfLine"},
 		{`a\b`, "WordBoundary"},
+		{`\B`, "NoWordBoundary"},
 		{`a??`, "lazy1"},
 		{`a??b`, "lazy2"},
 		{`a*?`, "lazy3"},
@@ -77,17 +84,772 @@ func TestGenerateTests(t *testing.T) {
 		{`(?i)aZ`, "IgnoreCase1"},
 		{`(?i)[a-z]`, "IgnoreCase2"},
 	}
+	searchTests := []test{
+		{"<!--.*?-->", "SearchComment"},
+		{"ERROR: [0-9]+", "SearchError"},
+		{"x[a-c]*", "SearchByte"},
+		{"日本+", "SearchMultibyte"},
+		{"(?m)^a+$", "SearchLine"},
+		{`\bab+\b`, "SearchWord"},
+		{"a*", "SearchEmpty"},
+		{"a+?b", "SearchLazy"},
+		{`[a-z.]+@[a-z]+\.(com|org)`, "SearchEmail"},
+		{`[a-z]+://[^ ]+`, "SearchURL"},
+		{"a|bcd", "SearchLeftmost"},
+		{"abcd|c", "SearchLeftmostEnd"},
+		{`(?m)^[a-z]+$|\d+\b`, "SearchAssertions"},
+		{`^ab|ab$|\Bb`, "SearchText"},
+		{"(a|ab)(c|bcd)", "SearchFirst"},
+	}
+	findAllTests := []test{
+		{"a*", "FindAllEmpty"},
+		{`\b`, "FindAllWordBoundary"},
+		{`[a-z]+`, "FindAllWords"},
+		{`x*?y`, "FindAllLazy"},
+		{`(?m)^.*$`, "FindAllLines"},
+		{"ab", "FindAllLiteral"},
+		{"(.)??", "FindAllLazyEmpty"},
+		{"a|ab", "FindAllFirst"},
+	}
+	replaceTests := []test{
+		{"a*", "ReplaceEmpty"},
+		{`[a-z]+`, "ReplaceWords"},
+		{`(\d+)-(?P<n>\d+)`, "ReplaceMissingGroups"},
+		{`\s*[,;]\s*`, "ReplaceSeparators"},
+		{`x*?y`, "ReplaceLazy"},
+		{"a??", "ReplaceLazyEmpty"},
+		{"a|ab", "ReplaceFirst"},
+	}
+	templates := map[string]string{
+		"ReplaceEmpty":         "<$0>",
+		"ReplaceWords":         "${0}_$$",
+		"ReplaceMissingGroups": "[$3$x$]",
+		"ReplaceSeparators":    ",",
+		"ReplaceLazy":          "",
+		"ReplaceLazyEmpty":     "<$0>",
+		"ReplaceFirst":         "<$0>",
+		"CountReplaceAllEmpty": "<$0>",
+	}
+	splitTests := []test{
+		{`\s*[,;]\s*`, "SplitSeparators"},
+		{"", "SplitEmpty"},
+		{"a*", "SplitStar"},
+		{`\b`, "SplitWordBoundary"},
+		{"(?m)$", "SplitEndOfLine"},
+		{"x*?y", "SplitLazy"},
+		{"a??", "SplitLazyEmpty"},
+		{"a|ab", "SplitFirst"},
+	}
+	matcherTests := []test{
+		{`[a-z]+@[a-z]+\.com`, "MatcherEmail"},
+		{"abc", "MatcherLiteral"},
+		{`\bfoo\b|bar`, "MatcherWordBoundary"},
+		{"<!--.*?-->", "MatcherLazy"},
+		{"", "MatcherEmpty"},
+		{"a|ab", "MatcherFirst"},
+	}
 	for _, tst := range tests {
 		nfanode, err := nfa.New(tst.pattern)
 		if err != nil {
 			t.Error(err)
 		} else {
 			node := dfa.NewFromNFA(nfanode)
-			source := GoGenerate(node, "test", "match"+uppercaseInitial(tst.name), "string")
-			err := writeToFile("test/"+strings.ToLower(tst.name)+".go", source)
+			funcName := "match" + uppercaseInitial(tst.name)
+			source, err := GoGenerate(node, "test", funcName, "string")
+			if err == nil {
+				err = writeToFile("test/"+strings.ToLower(tst.name)+".go", source)
+			}
 			if err != nil {
 				t.Error(err)
 			}
+			fn := Func{Name: funcName, Type: "string", Pattern: tst.pattern, Root: node}
+			source, err = GoGenerateTest("test", fn)
+			if err == nil {
+				err = writeToFile("test/"+strings.ToLower(tst.name)+"_regexp_test.go", source)
+			}
+			if err != nil {
+				t.Error(err)
+			}
+		}
+	}
+	optionTests := []struct {
+		test
+		opts nfa.Options
+	}{
+		{test{"abc", "OptionsIgnoreCase"}, nfa.Options{IgnoreCase: true}},
+		{test{"^a.b$", "OptionsPOSIX"}, nfa.Options{POSIX: true}},
+		{test{"a+b?", "OptionsUngreedy"}, nfa.Options{Ungreedy: true}},
+		{test{"a.b*", "OptionsLiteral"}, nfa.Options{Literal: true}},
+		{test{"a.b", "OptionsDotNL"}, nfa.Options{DotNL: true}},
+	}
+	for _, tst := range optionTests {
+		r, err := tst.opts.Parse(tst.pattern)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		pattern, err := tst.opts.Pattern(tst.pattern)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		nfanode, err := nfa.NewFromRegexp(r)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		fn := Func{
+			Name:    "match" + uppercaseInitial(tst.name),
+			Type:    "string",
+			Pattern: pattern,
+			Root:    dfa.NewFromNFA(nfanode),
+		}
+		name := "test/" + strings.ToLower(tst.name)
+		if source, err := GoGenerateFile("test", fn); err != nil {
+			t.Error(err)
+		} else if err := writeToFile(name+".go", source); err != nil {
+			t.Error(err)
+		}
+		if source, err := GoGenerateTest("test", fn); err != nil {
+			t.Error(err)
+		} else if err := writeToFile(name+"_regexp_test.go", source); err != nil {
+			t.Error(err)
+		}
+	}
+	// The regexp package only knows \n, so the functions are tested in test/test_test.go.
+	var lineFuncs []Func
+	for _, tst := range []struct {
+		name string
+		lt   nfa.LineTerminators
+		lazy bool
+	}{
+		{"LinesCR", nfa.LineCR, false},
+		{"LinesCRLF", nfa.LineCRLF, false},
+		{"LinesLFCRLF", nfa.LineLF | nfa.LineCRLF, false},
+		{"LinesUnicode", nfa.LineUnicode, false},
+		{"LinesAll", nfa.LineLF | nfa.LineCR | nfa.LineCRLF | nfa.LineUnicode, false},
+		{"LinesLazyCRLF", nfa.LineCRLF, true},
+	} {
+		pattern := "(?m)^.*$"
+		if tst.lazy {
+			pattern = "(?m)^.*?$"
+		}
+		opts := nfa.Options{LineTerminators: tst.lt}
+		r, err := opts.Parse(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nfanode, err := nfa.NewFromRegexp(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reverse, err := nfa.NewReverseFromRegexp(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lineFuncs = append(lineFuncs, Func{
+			Name:    "match" + tst.name,
+			Type:    "string",
+			Mode:    ModeFindAll,
+			Pattern: pattern,
+			Root:    dfa.NewLeftmostFirstFromNFA(nfanode),
+			Search:  dfa.NewLeftmostFirstSearchFromNFA(nfanode),
+			Reverse: dfa.NewSearchFromNFA(reverse, true),
+
+			LineTerminators: tst.lt,
+		})
+	}
+	if source, err := GoGenerateFile("test", lineFuncs...); err != nil {
+		t.Error(err)
+	} else if err := writeToFile("test/lines.go", source); err != nil {
+		t.Error(err)
+	}
+	// Neither does it know Unicode word boundaries.
+	var wordFuncs []Func
+	for _, tst := range []test{
+		{`\b`, "UnicodeWordBoundaries"},
+		{`\B`, "UnicodeNoWordBoundaries"},
+		{`\b\w+\b`, "UnicodeWordASCII"},
+		{`\b.+?\b`, "UnicodeWordLazy"},
+	} {
+		nfanode, err := nfa.New(tst.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reverse, err := nfa.NewReverse(tst.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fn := Func{
+			Name:    "match" + tst.name,
+			Type:    "string",
+			Mode:    ModeFindAll,
+			Pattern: tst.pattern,
+			Root:    dfa.NewLeftmostFirstFromNFA(nfanode),
+			Search:  dfa.NewLeftmostFirstSearchFromNFA(nfanode),
+			Reverse: dfa.NewSearchFromNFA(reverse, true),
+
+			UnicodeWordBoundary: true,
+		}
+		fnBytes := fn
+		fnBytes.Name += "Bytes"
+		fnBytes.Type = "[]byte"
+		wordFuncs = append(wordFuncs, fn, fnBytes)
+	}
+	if source, err := GoGenerateFile("test", wordFuncs...); err != nil {
+		t.Error(err)
+	} else if err := writeToFile("test/unicodeword.go", source); err != nil {
+		t.Error(err)
+	}
+	boolTests := []test{
+		{"a+", "BoolPlus"},
+		{"ab*c$", "BoolEndOfText"},
+		{"(?m)[a-z]+$", "BoolEndOfLine"},
+		{`a+\b`, "BoolWordBoundary"},
+		{"a*?b", "BoolLazy"},
+		{"x*", "BoolEmpty"},
+		{`[^\x00-\x{10FFFF}]`, "BoolNoMatch"},
+	}
+	for _, tst := range boolTests {
+		nfanode, err := nfa.NewGreedy(tst.pattern)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		fn := Func{
+			Name:    "match" + uppercaseInitial(tst.name),
+			Type:    "string",
+			Mode:    ModeBool,
+			Pattern: tst.pattern,
+			Root:    dfa.NewFromNFA(nfanode),
+		}
+		fnBytes := fn
+		fnBytes.Name += "Bytes"
+		fnBytes.Type = "[]byte"
+		name := "test/" + strings.ToLower(tst.name)
+		if source, err := GoGenerateFile("test", fn, fnBytes); err != nil {
+			t.Error(err)
+		} else if err := writeToFile(name+".go", source); err != nil {
+			t.Error(err)
+		}
+		if source, err := GoGenerateTest("test", fn, fnBytes); err != nil {
+			t.Error(err)
+		} else if err := writeToFile(name+"_regexp_test.go", source); err != nil {
+			t.Error(err)
+		}
+	}
+	// The counted functions are also compared with the expanded ones in test/test_test.go.
+	countTests := []struct {
+		test
+		mode Mode
+	}{
+		{test{"[a-z]{1,20}", "CountRange"}, ModeMatch},
+		{test{".{10}x", "CountFixed"}, ModeMatch},
+		{test{`\b[a-z]{3,10}\b`, "CountWordBoundary"}, ModeMatch},
+		{test{"(?:[0-9a-f]{8})+", "CountLoop"}, ModeMatch},
+		{test{"a{6}b{6}", "CountSequence"}, ModeMatch},
+		{test{"[0-9]{2,12}$", "CountBool"}, ModeBool},
+		{test{"[a-z]{3,12}@x", "CountSearch"}, ModeSearch},
+		{test{"[0-9]{4,10}", "CountFindAll"}, ModeFindAll},
+		{test{"b?a{0,5}", "CountFindAllEmpty"}, ModeFindAll},
+		{test{"b?a{0,5}", "CountReplaceAllEmpty"}, ModeReplaceAll},
+		{test{"b?a{0,5}", "CountSplitEmpty"}, ModeSplit},
+	}
+	for _, tst := range countTests {
+		var funcs []Func
+		for _, threshold := range []int{4, 0} {
+			r, err := nfa.Options{CountThreshold: threshold}.Parse(tst.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			nfanode, err := nfa.NewFromRegexp(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fn := Func{
+				Name:    "match" + tst.name,
+				Type:    "string",
+				Mode:    tst.mode,
+				Pattern: tst.pattern,
+				Root:    dfa.NewFromNFA(nfanode),
+
+				Template: templates[tst.name],
+			}
+			if !fn.Root.Counts() != (threshold == 0) {
+				t.Errorf("%s: counts = %v with threshold %d", tst.pattern, fn.Root.Counts(), threshold)
+			}
+			if threshold == 0 {
+				fn.Name += "Expanded"
+			}
+			if tst.mode != ModeMatch && tst.mode != ModeBool && threshold == 0 {
+				reverse, err := nfa.NewReverseFromRegexp(r)
+				if err != nil {
+					t.Fatal(err)
+				}
+				fn.Root = dfa.NewLeftmostFirstFromNFA(nfanode)
+				fn.Search = dfa.NewLeftmostFirstSearchFromNFA(nfanode)
+				fn.Reverse = dfa.NewSearchFromNFA(reverse, true)
+			}
+			fnBytes := fn
+			fnBytes.Name += "Bytes"
+			fnBytes.Type = "[]byte"
+			funcs = append(funcs, fn, fnBytes)
+		}
+		name := "test/" + strings.ToLower(tst.name)
+		if source, err := GoGenerateFile("test", funcs...); err != nil {
+			t.Error(err)
+		} else if err := writeToFile(name+".go", source); err != nil {
+			t.Error(err)
+		}
+		if source, err := GoGenerateTest("test", funcs...); err != nil {
+			t.Error(err)
+		} else if err := writeToFile(name+"_regexp_test.go", source); err != nil {
+			t.Error(err)
+		}
+	}
+	for _, tst := range append(append(append(append(searchTests, findAllTests...), replaceTests...), splitTests...), matcherTests...) {
+		nfanode, err := nfa.New(tst.pattern)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		reverse, err := nfa.NewReverse(tst.pattern)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		node := dfa.NewLeftmostFirstFromNFA(nfanode)
+		mode := ModeSearch
+		if strings.HasPrefix(tst.name, "FindAll") {
+			mode = ModeFindAll
+		} else if strings.HasPrefix(tst.name, "Replace") {
+			mode = ModeReplaceAll
+		} else if strings.HasPrefix(tst.name, "Split") {
+			mode = ModeSplit
+		} else if strings.HasPrefix(tst.name, "Matcher") {
+			mode = ModeMatcher
+		}
+		fn := Func{
+			Name:    "match" + uppercaseInitial(tst.name),
+			Type:    "string",
+			Mode:    mode,
+			Pattern: tst.pattern,
+			Root:    node,
+			Search:  dfa.NewLeftmostFirstSearchFromNFA(nfanode),
+			Reverse: dfa.NewSearchFromNFA(reverse, true),
+
+			Template: templates[tst.name],
+		}
+		fnBytes := fn
+		fnBytes.Name += "Bytes"
+		fnBytes.Type = "[]byte"
+		funcs := []Func{fn, fnBytes}
+		if mode == ModeMatcher {
+			// A single type for both strings and byte slices.
+			fn.Name = tst.name
+			funcs = []Func{fn}
+		}
+		name := "test/" + strings.ToLower(tst.name)
+		if source, err := GoGenerateFile("test", funcs...); err != nil {
+			t.Error(err)
+		} else if err := writeToFile(name+".go", source); err != nil {
+			t.Error(err)
+		}
+		if source, err := GoGenerateTest("test", funcs...); err != nil {
+			t.Error(err)
+		} else if err := writeToFile(name+"_regexp_test.go", source); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestTypeCheck(t *testing.T) {
+	nfanode, err := nfa.New(`a\b`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := GoGenerate(dfa.NewFromNFA(nfanode), "test", "MatchA", "string")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(source, "func "+helperPrefix("MatchA")+"IsWordChar(") {
+		t.Errorf("the helper function is missing or misnamed:\n%s", source)
+	}
+	if err := typeCheck("test", []byte(source)); err != nil {
+		t.Error(err)
+	}
+
+	// The helpers of the files of functions whose names only differ by the case of the initial don't collide.
+	other, err := GoGenerate(dfa.NewFromNFA(nfanode), "test", "matchA", "string")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := typeCheck("test", []byte(source), []byte(other)); err != nil {
+		t.Error(err)
+	}
+
+	for _, source := range []string{
+		"package test\nfunc f(s string) bool { return isWordChar(s[0]) }",
+		"package test\nfunc f() {\ns1:\n}",
+		"package test\nimport \"strings\"",
+	} {
+		if err := typeCheck("test", []byte(source)); err == nil {
+			t.Errorf("typeCheck(%q) = nil, want an error", source)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	parseTests := []struct {
+		pattern string
+		offset  int
+	}{
+		{`a(b`, 0},
+		{`ab\q`, 2},
+		{`x{2,1}`, 1},
+	}
+	for _, tst := range parseTests {
+		_, err := nfa.New(tst.pattern)
+		var e *nfa.Error
+		if !errors.As(err, &e) {
+			t.Errorf("nfa.New(%q): want an *nfa.Error, got %v", tst.pattern, err)
+			continue
+		}
+		if e.Stage != "parse" || e.Offset != tst.offset {
+			t.Errorf("nfa.New(%q): stage %q, offset %d, want parse, %d", tst.pattern, e.Stage, e.Offset, tst.offset)
+		}
+	}
+
+	r := &syntax.Regexp{Op: syntax.OpConcat, Sub: []*syntax.Regexp{
+		{Op: syntax.OpLiteral, Rune: []rune("a")},
+		{Op: syntax.OpNoMatch},
+	}}
+	_, err := nfa.NewFromRegexp(r)
+	var e *nfa.Error
+	if !errors.As(err, &e) {
+		t.Fatalf("nfa.NewFromRegexp: want an *nfa.Error, got %v", err)
+	}
+	if e.Stage != "construct" || e.Op != syntax.OpNoMatch {
+		t.Errorf("nfa.NewFromRegexp: stage %q, op %v, want construct, OpNoMatch", e.Stage, e.Op)
+	}
+
+	nfanode, err := nfa.New("a*?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := dfa.NewFromNFA(nfanode)
+	for _, tst := range []struct {
+		fn    Func
+		stage string
+	}{
+		{Func{Name: "matchA", Type: "rune", Root: node}, "generate"},
+		{Func{Name: "matchA", Type: "string", Mode: ModeReplaceAll, Pattern: "(a)", Template: "$1", Root: node}, "generate"},
+		{Func{Name: "matchA", Type: "string", Mode: ModeReplaceAll, Template: "$1", Root: node}, "generate"},
+		{Func{Name: "func", Type: "string", Root: node}, "format"},
+	} {
+		_, err := GoGenerateFile("test", tst.fn)
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Errorf("%+v: want an *Error, got %v", tst.fn, err)
+			continue
+		}
+		if e.Stage != tst.stage {
+			t.Errorf("%+v: stage %q, want %q", tst.fn, e.Stage, tst.stage)
+		}
+	}
+	if _, err := GoGenerateFile("test", Func{Name: "matchA", Type: "string", Mode: ModeBool, Root: node}); err != nil {
+		t.Errorf("ModeBool: %v", err)
+	}
+	_, err = GoGenerateTest("test", Func{Name: "matchA", Type: "string", Root: node, UnicodeWordBoundary: true})
+	if err == nil {
+		t.Error("GoGenerateTest: want an error for Unicode word boundaries")
+	}
+}
+
+func TestLazyWithoutStack(t *testing.T) {
+	for _, pattern := range []string{"<!--.*?-->", "a*?b", `\b.+?\b`, "(a+?)(b|ab)"} {
+		nfanode, err := nfa.New(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		node := dfa.NewFromNFA(nfanode)
+		if !node.LeftmostFirst {
+			t.Errorf("%q: want a leftmost-first automaton", pattern)
+		}
+		source, err := GoGenerateFile("test", Func{Name: "match", Type: "string", Mode: ModeSearch, Root: node})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(source, "lazy") || strings.Contains(source, "goto bt") {
+			t.Errorf("%q: the generated code backtracks:\n%s", pattern, source)
+		}
+	}
+}
+
+func TestCountThreshold(t *testing.T) {
+	generate := func(pattern string, threshold int) string {
+		r, err := nfa.Options{CountThreshold: threshold}.Parse(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nfanode, err := nfa.NewFromRegexp(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		source, err := GoGenerateFile("test", Func{Name: "match", Type: "string", Root: dfa.NewFromNFA(nfanode)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return source
+	}
+
+	expanded := generate("[a-z]{1,100}", 0)
+	for _, tst := range []struct {
+		threshold int
+		counted   bool
+	}{
+		{0, false},
+		{8, true},
+		{100, true},
+		{101, false},
+	} {
+		source := generate("[a-z]{1,100}", tst.threshold)
+		if counted := strings.Contains(source, "cnt1.increment()"); counted != tst.counted {
+			t.Errorf("threshold %d: counted = %v, want %v", tst.threshold, counted, tst.counted)
+		}
+		if tst.counted && len(source)*5 > len(expanded) {
+			t.Errorf("threshold %d: %d bytes, want less than a fifth of %d", tst.threshold, len(source), len(expanded))
+		}
+	}
+
+	// The code doesn't grow with the bound: only the numbers differ.
+	numbers := regexp.MustCompile(`[0-9]+`)
+	for _, pattern := range []string{"[a-z]{1,%d}", ".{%d}x", "a.{%d}x", "(?:[0-9a-f]{%d})+"} {
+		small := generate(fmt.Sprintf(pattern, 10), 8)
+		large := generate(fmt.Sprintf(pattern, 1000), 8)
+		if numbers.ReplaceAllString(small, "0") != numbers.ReplaceAllString(large, "0") {
+			t.Errorf("%s: the code with the bound 1000 differs from the one with 10 by more than the numbers", pattern)
+		}
+	}
+}
+
+func TestCountBoolUnreachable(t *testing.T) {
+	// The counted states can only be entered after a final state, where the bool mode returns.
+	r, err := nfa.Options{CountThreshold: 2}.Parse("a|ab$c{3}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nfanode, err := nfa.NewFromRegexp(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := dfa.NewFromNFA(nfanode)
+	if !node.Counts() {
+		t.Fatal("want an automaton with counters")
+	}
+	source, err := GoGenerateFile("test", Func{Name: "match", Type: "string", Mode: ModeBool, Root: node})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(source, "cnt1") {
+		t.Errorf("the unused register is declared:\n%s", source)
+	}
+}
+
+func TestSplitWithoutPattern(t *testing.T) {
+	for _, tst := range []struct {
+		pattern string
+		single  bool // an empty input is returned as a single substring
+		err     bool
+	}{
+		{"a", true, false},
+		{`\ba`, true, false},
+		{"", false, false},
+		{"a*", false, true},
+		{`^$`, false, true},
+		{`\B`, false, true},
+	} {
+		nfanode, err := nfa.New(tst.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		source, err := GoGenerateFile("test", Func{Name: "split", Type: "string", Mode: ModeSplit, Root: dfa.NewFromNFA(nfanode)})
+		if (err != nil) != tst.err {
+			t.Errorf("%q: got error %v", tst.pattern, err)
+			continue
+		}
+		if err == nil && strings.Contains(source, "if len(s) == 0 {") != tst.single {
+			t.Errorf("%q: an empty input is returned as a single substring: %v, want %v", tst.pattern, !tst.single, tst.single)
+		}
+	}
+}
+
+func TestParseTemplate(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		template string
+		parts    []templatePart
+		err      bool
+	}{
+		{"a", "", nil, false},
+		{"a", "x", []templatePart{{literal: "x"}}, false},
+		{"a", "<$0>", []templatePart{{literal: "<"}, {match: true}, {literal: ">"}}, false},
+		{"a", "${0}$0", []templatePart{{match: true}, {match: true}}, false},
+		{"a", "$$0$", []templatePart{{literal: "$0$"}}, false},
+		{"a", "${0", []templatePart{{literal: "${0"}}, false},
+		{"a", "x$1y$name", []templatePart{{literal: "x"}}, false},
+		{"a", "$0x", nil, false},
+		{"(a)", "$2", nil, false},
+		{"(a)", "$1", nil, true},
+		{"(?P<n>a)", "${n}", nil, true},
+		{"(?P<n>a)", "$1", nil, true},
+		{"", "<$0>", []templatePart{{literal: "<"}, {match: true}, {literal: ">"}}, false},
+		{"", "$1", nil, true},
+		{"", "${n}", nil, true},
+	}
+	for _, tst := range tests {
+		parts, err := parseTemplate(tst.pattern, tst.template)
+		if tst.err {
+			if err == nil {
+				t.Errorf("%q, %q: want an error", tst.pattern, tst.template)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q, %q: %v", tst.pattern, tst.template, err)
+		} else if !reflect.DeepEqual(parts, tst.parts) {
+			t.Errorf("%q, %q: parseTemplate() = %v, want %v", tst.pattern, tst.template, parts, tst.parts)
+		}
+	}
+}
+
+func TestTemplateGroupErrors(t *testing.T) {
+	for _, tst := range []struct {
+		pattern  string
+		template string
+		group    string
+	}{
+		{`(\d+)-(?P<name>\d+)`, "$1", `"1"`},
+		{`(\d+)-(?P<name>\d+)`, "<${name}>", `"name"`},
+		{`(\d+)-(?P<name>\d+)`, "$name", `"name"`},
+		{`(\d+)-(?P<name>\d+)`, "${2}", `"2"`},
+		{"", "$1", `"1"`},
+	} {
+		err := CheckTemplate(tst.pattern, tst.template)
+		if err == nil || !strings.Contains(err.Error(), "capture group "+tst.group) {
+			t.Errorf("%q, %q: got %v, want an error naming the group %s", tst.pattern, tst.template, err, tst.group)
+		}
+	}
+}
+
+func TestRequiredFactorsOnlyInSearch(t *testing.T) {
+	nfanode, err := nfa.New(`[a-z]+@[a-z]+\.com`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := dfa.NewFromNFA(nfanode)
+	for _, tst := range []struct {
+		mode  Mode
+		check bool
+	}{
+		{ModeMatch, false},
+		{ModeBool, false},
+		{ModeSearch, true},
+		{ModeFindAll, true},
+	} {
+		source, err := GoGenerateFile("test", Func{Name: "match", Type: "string", Mode: tst.mode, Root: node})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// An anchored match reads only as far as the match goes.
+		if check := strings.Contains(source, "strings.IndexByte(s, '@') < 0"); check != tst.check {
+			t.Errorf("mode %d: the required factors are checked: %v, want %v", tst.mode, check, tst.check)
+		}
+	}
+}
+
+func TestLiteralPrefix(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		prefix   string
+		complete bool
+	}{
+		{"abc", "abc", true},
+		{"<!--.*?-->", "<!--", false},
+		{"ERROR: [0-9]+", "ERROR: ", false},
+		{"日本+", "日本", false},
+		{"ab|ac", "a", false},
+		{"a*", "", false},
+		{"(?i)a", "", false},
+		{"^a", "", false},
+		{"", "", true},
+	}
+	for _, tst := range tests {
+		nfanode, err := nfa.New(tst.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		prefix, complete := dfa.NewFromNFA(nfanode).LiteralPrefix()
+		if prefix != tst.prefix || complete != tst.complete {
+			t.Errorf("%q: LiteralPrefix() = %q, %v, want %q, %v", tst.pattern, prefix, complete, tst.prefix, tst.complete)
+		}
+	}
+}
+
+func TestMatcherLiteralPrefix(t *testing.T) {
+	for _, pattern := range []string{
+		"abc",
+		"<!--.*?-->",
+		"ERROR: [0-9]+",
+		"ab|ac",
+		"a*",
+		"(?i)a",
+		"^abc",
+		`(?:)+?abc(?:ab)+abc.x`,
+		`(?:\B)??`,
+		"a(b)c",
+		"",
+	} {
+		nfanode, err := nfa.New(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		source, err := GoGenerateFile("test", Func{Name: "Matcher", Mode: ModeMatcher, Pattern: pattern, Root: dfa.NewFromNFA(nfanode)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		prefix, complete := regexp.MustCompile(pattern).LiteralPrefix()
+		if want := fmt.Sprintf("return %s, %v", strconv.Quote(prefix), complete); !strings.Contains(source, want) {
+			t.Errorf("%q: the generated LiteralPrefix doesn't %s", pattern, want)
+		}
+	}
+}
+
+func TestRequiredFactors(t *testing.T) {
+	tests := []struct {
+		pattern string
+		factors []string
+	}{
+		{"abc", []string{"abc"}},
+		{`[a-z.]+@[a-z]+\.(com|org)`, []string{".", "@", "o"}},
+		{`[a-z]+\.com`, []string{".com"}},
+		{`[a-z]+://[^ ]+`, []string{"://"}},
+		{"x(ab|cd)+y", []string{"x", "y"}},
+		{"ab*c", []string{"a", "c"}},
+		{"a|b", nil},
+		{"a*", nil},
+		{"(?i)ab", nil},
+		{`^ab$`, []string{"ab"}},
+		{"", nil},
+	}
+	for _, tst := range tests {
+		nfanode, err := nfa.New(tst.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		factors := dfa.NewFromNFA(nfanode).RequiredFactors()
+		if !reflect.DeepEqual(factors, tst.factors) {
+			t.Errorf("%q: RequiredFactors() = %q, want %q", tst.pattern, factors, tst.factors)
 		}
 	}
 }
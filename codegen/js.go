@@ -0,0 +1,170 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/opennota/re2dfa/dfa"
+	"github.com/opennota/re2dfa/nfa"
+)
+
+// jsAssertions maps pseudo-runes to JavaScript expressions.
+var jsAssertions = map[rune]string{
+	nfa.RuneBeginText:      "i === 0",
+	nfa.RuneEndText:        "i === s.length",
+	nfa.RuneBeginLine:      "i === 0 || s.charCodeAt(i - 1) === 10",
+	nfa.RuneEndLine:        "i === s.length || s.charCodeAt(i) === 10",
+	nfa.RuneWordBoundary:   "(i > 0 && %[1]sIsWordChar(s.charCodeAt(i - 1))) !== (i < s.length && %[1]sIsWordChar(s.charCodeAt(i)))",
+	nfa.RuneNoWordBoundary: "(i > 0 && %[1]sIsWordChar(s.charCodeAt(i - 1))) === (i < s.length && %[1]sIsWordChar(s.charCodeAt(i)))",
+}
+
+const jsIsWordChar = `
+function %[1]sIsWordChar(c%[2]s)%[3]s {
+	return (c >= 65 && c <= 90) || (c >= 97 && c <= 122) || (c >= 48 && c <= 57) || c === 95;
+}
+`
+
+// JSGenerate generates a JavaScript module exporting the matching function
+//
+//	function funcName(s)
+//
+// which returns the end of the match at the beginning of the string s, or -1 if there is no match.
+// The string is decoded as UTF-16; surrogate pairs are combined into a single code point,
+// unpaired surrogates are treated as U+FFFD. The end of the match is an index into s.
+// Automata with counters are not supported; they are reported as an *Error.
+func JSGenerate(root *dfa.Node, funcName string) (string, error) {
+	return jsGenerate(root, funcName, false)
+}
+
+// TSGenerate generates a TypeScript module exporting the matching function
+//
+//	function funcName(s: string): number
+//
+// with the same semantics as the function generated by JSGenerate.
+func TSGenerate(root *dfa.Node, funcName string) (string, error) {
+	return jsGenerate(root, funcName, true)
+}
+
+func jsGenerate(root *dfa.Node, funcName string, ts bool) (string, error) {
+	if root.Counts() {
+		return "", &Error{Stage: "generate", Func: funcName, Err: errCounts}
+	}
+	m := newMachine(root)
+
+	typ := func(t string) string {
+		if ts {
+			return ": " + t
+		}
+		return ""
+	}
+
+	assertions := make(map[rune]string, len(jsAssertions))
+	for r, a := range jsAssertions {
+		assertions[r] = strings.Replace(a, "%[1]s", funcName, -1)
+	}
+
+	var buf bytes.Buffer
+	for _, s := range m.states {
+		fmt.Fprintf(&buf, "case %d: {\n", s.n)
+
+		for bi, b := range s.empty {
+			if bi > 0 {
+				fmt.Fprint(&buf, "} else ")
+			}
+			fmt.Fprintf(&buf, "if (%s) {\n", rangesToParenExpr(b.r, assertions))
+			if b.final {
+				fmt.Fprintln(&buf, "end = i;")
+			}
+			if b.next != 0 {
+				fmt.Fprintf(&buf, "st = %d;\ncontinue;\n", b.next)
+			} else if len(s.runes) > 0 {
+				fmt.Fprintln(&buf, "return end;")
+			}
+		}
+		if len(s.empty) > 0 {
+			fmt.Fprintln(&buf, "}")
+		}
+
+		if len(s.runes) > 0 {
+			fmt.Fprint(&buf, `if (i >= s.length) {
+					return end;
+				}
+				r = s.charCodeAt(i);
+				rlen = 1;
+				if (r >= 0xd800 && r <= 0xdfff) {
+					const lo = s.charCodeAt(i + 1);
+					if (r <= 0xdbff && lo >= 0xdc00 && lo <= 0xdfff) {
+						r = (r - 0xd800) * 0x400 + (lo - 0xdc00) + 0x10000;
+						rlen = 2;
+					} else {
+						r = 0xfffd;
+					}
+				}
+				i += rlen;
+`)
+			for bi, b := range s.runes {
+				if bi > 0 {
+					fmt.Fprint(&buf, "} else ")
+				}
+				fmt.Fprintf(&buf, "if (%s) {\n", rangesToParenExpr(b.r, assertions))
+				if b.final {
+					fmt.Fprintln(&buf, "end = i;")
+				}
+				if b.next != 0 {
+					fmt.Fprintf(&buf, "st = %d;\ncontinue;\n", b.next)
+				}
+			}
+			fmt.Fprintln(&buf, "}")
+		}
+
+		fmt.Fprintln(&buf, "return end;")
+		fmt.Fprintln(&buf, "}")
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintln(&out, "// Code generated by re2dfa (https://github.com/opennota/re2dfa).")
+	if m.wordBoundary {
+		fmt.Fprintf(&out, jsIsWordChar, funcName, typ("number"), typ("boolean"))
+	}
+
+	end := -1
+	if m.final {
+		end = 0
+	}
+	fmt.Fprintf(&out, `
+		export function %s(s%s)%s {
+			let end = %d;
+			let r = 0;
+			let rlen = 0;
+			let i = 0;
+`, funcName, typ("string"), typ("number"), end)
+	if len(m.states) > 0 {
+		fmt.Fprintf(&out, `let st = %d;
+			for (;;) {
+				switch (st) {
+`, m.states[0].n)
+		out.Write(buf.Bytes())
+		fmt.Fprintln(&out, `default: throw new Error("unreachable");
+				}
+			}`)
+	} else {
+		fmt.Fprintln(&out, "return end;")
+	}
+	fmt.Fprintln(&out, "}")
+
+	return indent(out.String(), "\t"), nil
+}
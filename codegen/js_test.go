@@ -0,0 +1,120 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const jsMain = `
+import { createInterface } from "node:readline";
+
+const results = [];
+createInterface({ input: process.stdin })
+	.on("line", (line) => {
+		const sp = line.indexOf(" ");
+		const s = Buffer.from(line.slice(sp + 1), "hex").toString("utf8");
+		switch (Number(line.slice(0, sp))) {
+%s		}
+	})
+	.on("close", () => process.stdout.write(results.map((e) => e + "\n").join("")));
+`
+
+func TestJSGenerate(t *testing.T) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("no node")
+	}
+
+	roots, cases := backendCases(t)
+
+	// JavaScript strings can't contain invalid UTF-8, and the end of the match is an index into UTF-16.
+	var jsCases []backendCase
+	for _, c := range cases {
+		if !utf8.ValidString(c.in) {
+			continue
+		}
+		if c.want > 0 {
+			c.want = len(utf16.Encode([]rune(c.in[:c.want])))
+		}
+		jsCases = append(jsCases, c)
+	}
+	for i, p := range backendPatterns {
+		switch p {
+		case "(?s).":
+			jsCases = append(jsCases, backendCase{i, "\U0001F600", 2}, backendCase{i, "\uFFFDx", 1})
+		case `[^a]\x{10000}.`:
+			jsCases = append(jsCases, backendCase{i, "\U0001F600\U00010000\U0001F600x", 6})
+		}
+	}
+
+	var src bytes.Buffer
+	var calls bytes.Buffer
+	for i, root := range roots {
+		code, err := JSGenerate(root, fmt.Sprintf("match%d", i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		src.WriteString(code)
+		fmt.Fprintf(&calls, "\t\t\tcase %d:\n\t\t\t\tresults.push(match%[1]d(s));\n\t\t\t\tbreak;\n", i)
+	}
+	fmt.Fprintf(&src, jsMain, calls.String())
+
+	fn := filepath.Join(t.TempDir(), "main.mjs")
+	if err := writeToFile(fn, src.String()); err != nil {
+		t.Fatal(err)
+	}
+
+	checkBackend(t, exec.Command(node, fn), jsCases)
+}
+
+func TestTSGenerate(t *testing.T) {
+	tsc, err := exec.LookPath("tsc")
+	if err != nil {
+		t.Skip("no tsc")
+	}
+
+	roots, _ := backendCases(t)
+
+	dir := t.TempDir()
+	for i, root := range roots {
+		code, err := TSGenerate(root, fmt.Sprintf("match%d", i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		fn := filepath.Join(dir, fmt.Sprintf("match%d.ts", i))
+		if err := writeToFile(fn, code); err != nil {
+			t.Fatal(err)
+		}
+		if out, err := exec.Command(tsc, "--strict", "--noEmit", "--target", "es2015", fn).CombinedOutput(); err != nil {
+			t.Errorf("%s: %v\n%s", backendPatterns[i], err, out)
+		}
+	}
+}
+
+func TestJSGenerateCounts(t *testing.T) {
+	root := countingRoot(t)
+	if _, err := JSGenerate(root, "match"); err == nil {
+		t.Error("JSGenerate: no error for an automaton with counters")
+	}
+	if _, err := TSGenerate(root, "match"); err == nil {
+		t.Error("TSGenerate: no error for an automaton with counters")
+	}
+}
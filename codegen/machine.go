@@ -0,0 +1,252 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package codegen
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/opennota/re2dfa/dfa"
+	"github.com/opennota/re2dfa/nfa"
+	"github.com/opennota/re2dfa/runerange"
+)
+
+// A machine is a language-independent description of the code generated for an automaton.
+type machine struct {
+	states       []*state        // states having outgoing transitions, ordered by number
+	final        bool            // the initial state is final
+	labelFirst   bool            // the initial state is a jump target
+	wordBoundary bool            // word boundary assertions are used
+	counters     []*dfa.Counter  // registers of the bounded repetitions, ordered by number
+	init         []dfa.CounterOp // operations on the counters before reading the input
+}
+
+type state struct {
+	n     int      // number
+	empty []branch // transitions on assertions
+	runes []branch // transitions on runes
+}
+
+type branch struct {
+	r     []rune // a single pseudo-rune pair for assertions, or positive rune ranges
+	final bool   // the target is final
+	next  int    // the target, or 0 if the target has no outgoing transitions
+
+	ops   []dfa.CounterOp   // operations on the counters, before checking the conditions of the cases
+	cond  []dfa.CounterCond // conditions on the counters of a case
+	cases []branch          // if not nil, the runes move to the target of the first case whose conditions hold
+}
+
+func newMachine(root *dfa.Node) *machine {
+	nodes := allNodes(root, make(map[*dfa.Node]struct{}))
+	nodes = filter(nodes, func(n *dfa.Node) bool {
+		return len(n.T) > 0
+	})
+	sort.Sort(nodesByState(nodes))
+
+	m := &machine{final: root.F, init: root.Init}
+	counters := make(map[*dfa.Counter]bool)
+	for _, op := range root.Init {
+		counters[op.C] = true
+	}
+
+	for _, n := range nodes {
+		for _, t := range n.T {
+			if t.N == nodes[0] {
+				m.labelFirst = true
+			}
+		}
+	}
+
+	for _, n := range nodes {
+		s := &state{n: n.S}
+		var conditional []dfa.T
+		for _, t := range n.T {
+			for _, op := range t.Ops {
+				counters[op.C] = true
+			}
+
+			for i := 0; i < len(t.R) && t.R[i] < 0; i += 2 {
+				switch t.R[i] {
+				case nfa.RuneWordBoundary, nfa.RuneNoWordBoundary:
+					m.wordBoundary = true
+					fallthrough
+				default:
+					b := target(t)
+					b.r = t.R[i : i+2]
+					s.empty = append(s.empty, b)
+				}
+			}
+
+			if rr := positive(t.R); len(rr) > 0 && len(t.Cond) > 0 {
+				conditional = append(conditional, dfa.T{R: rr, N: t.N, Ops: t.Ops, Cond: t.Cond})
+			} else if len(rr) > 0 {
+				b := target(t)
+				b.r = rr
+				s.runes = append(s.runes, b)
+			}
+		}
+		s.runes = append(s.runes, cases(conditional)...)
+		m.states = append(m.states, s)
+	}
+
+	for c := range counters {
+		m.counters = append(m.counters, c)
+	}
+	sort.Slice(m.counters, func(i, j int) bool { return m.counters[i].N < m.counters[j].N })
+
+	return m
+}
+
+// target returns the branch to the target of the transition, without the runes.
+func target(t dfa.T) branch {
+	b := branch{final: t.N.F, ops: t.Ops, cond: t.Cond}
+	if len(t.N.T) > 0 {
+		b.next = t.N.S
+	}
+	return b
+}
+
+// cases returns the branches of the transitions on runes with conditions on the counters: the runes
+// with the same operations and the same targets under the same conditions make up a branch whose cases
+// are the targets.
+func cases(conditional []dfa.T) []branch {
+	var ranges [][]rune
+	for _, t := range conditional {
+		ranges = append(ranges, t.R)
+	}
+	pieces := runerange.Split(ranges)
+
+	var branches []branch
+	index := make(map[string]int)
+	for i := 0; i < len(pieces); i += 2 {
+		rr := pieces[i : i+2]
+		var b branch
+		for _, t := range conditional {
+			if runerange.Contains(t.R, rr) {
+				c := target(t)
+				b.ops, c.ops = dfa.SplitOps(t.Ops)
+				b.cases = append(b.cases, c)
+			}
+		}
+		key := fmt.Sprint(b.ops, b.cases)
+		if k, ok := index[key]; ok {
+			branches[k].r = runerange.Sum(branches[k].r, rr)
+			continue
+		}
+		index[key] = len(branches)
+		b.r = runerange.Sum(nil, rr)
+		branches = append(branches, b)
+	}
+	return branches
+}
+
+// decodes returns true if the machine reads runes from the input.
+func (m *machine) decodes() bool {
+	for _, s := range m.states {
+		if len(s.runes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// targets returns the branches of the state, and the cases of the branches with conditions.
+func (s *state) targets() []branch {
+	var branches []branch
+	for _, b := range append(s.empty[:len(s.empty):len(s.empty)], s.runes...) {
+		if b.cases != nil {
+			branches = append(branches, b.cases...)
+		} else {
+			branches = append(branches, b)
+		}
+	}
+	return branches
+}
+
+// label returns true if the state needs a label.
+func (m *machine) label(s *state) bool {
+	return s.n != 1 || m.labelFirst
+}
+
+// earliest returns a copy of the machine for matching which stops in the first final state: the states
+// which can only be entered through a final state are dropped, and so are the counters which only the dropped
+// states and the transitions to final states use.
+func (m *machine) earliest() *machine {
+	byNumber := make(map[int]*state, len(m.states))
+	for _, s := range m.states {
+		byNumber[s.n] = s
+	}
+
+	em := &machine{final: m.final, wordBoundary: m.wordBoundary, init: m.init}
+	if len(m.states) == 0 {
+		return em
+	}
+	reachable := map[int]bool{m.states[0].n: true}
+	queue := []*state{m.states[0]}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		for _, b := range s.targets() {
+			if b.final || b.next == 0 {
+				continue
+			}
+			if b.next == m.states[0].n {
+				em.labelFirst = true
+			}
+			if !reachable[b.next] {
+				reachable[b.next] = true
+				queue = append(queue, byNumber[b.next])
+			}
+		}
+	}
+	used := make(map[*dfa.Counter]bool)
+	for _, op := range m.init {
+		used[op.C] = true
+	}
+	for _, s := range m.states {
+		if !reachable[s.n] {
+			continue
+		}
+		em.states = append(em.states, s)
+		for _, b := range s.empty {
+			if !b.final {
+				for _, op := range b.ops {
+					used[op.C] = true
+				}
+			}
+		}
+		for _, b := range s.runes {
+			for _, op := range b.ops {
+				used[op.C] = true
+			}
+			for _, c := range b.cases {
+				for _, cond := range c.cond {
+					used[cond.C] = true
+				}
+				if !c.final {
+					for _, op := range c.ops {
+						used[op.C] = true
+					}
+				}
+			}
+		}
+	}
+	for _, c := range m.counters {
+		if used[c] {
+			em.counters = append(em.counters, c)
+		}
+	}
+	return em
+}
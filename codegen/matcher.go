@@ -0,0 +1,91 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// matcher writes a type named after the function with the matching methods of *regexp.Regexp (see
+// the matcher package), and the search functions for a string and a []byte it calls. The literal prefix
+// is the one the regexp package finds in Pattern, which depends on how the pattern is compiled rather than
+// on the strings it matches.
+func (f *goFile) matcher(out *bytes.Buffer, fn Func) error {
+	re, err := regexp.Compile(fn.Pattern)
+	if err != nil {
+		return err
+	}
+	prefix, complete := re.LiteralPrefix()
+
+	name := lowercaseInitial(fn.Name)
+	findString := fn
+	findString.Name = name + "FindString"
+	findString.Type = "string"
+	findString.Mode = ModeSearch
+	f.search(out, findString, newMachine(fn.Root))
+	find := findString
+	find.Name = name + "Find"
+	find.Type = "[]byte"
+	f.search(out, find, newMachine(fn.Root))
+
+	fmt.Fprintf(out, `
+			// %[1]s matches the regular expression %[2]s. It has the matching methods of *regexp.Regexp.
+			type %[1]s struct{}
+
+			// MatchString reports whether s contains a match.
+			func (%[1]s) MatchString(s string) bool {
+				start, _ := %[3]s(s)
+				return start >= 0
+			}
+
+			// Match reports whether b contains a match.
+			func (%[1]s) Match(b []byte) bool {
+				start, _ := %[4]s(b)
+				return start >= 0
+			}
+
+			// FindStringIndex returns the location of the leftmost match in s, or nil.
+			func (%[1]s) FindStringIndex(s string) []int {
+				start, end := %[3]s(s)
+				if start < 0 {
+					return nil
+				}
+				return []int{start, end}
+			}
+
+			// FindIndex returns the location of the leftmost match in b, or nil.
+			func (%[1]s) FindIndex(b []byte) []int {
+				start, end := %[4]s(b)
+				if start < 0 {
+					return nil
+				}
+				return []int{start, end}
+			}
+
+			// String returns the regular expression.
+			func (%[1]s) String() string {
+				return %[2]s
+			}
+
+			// LiteralPrefix returns the literal string *regexp.Regexp finds every match to start with, and whether
+			// it is the whole regular expression.
+			func (%[1]s) LiteralPrefix() (prefix string, complete bool) {
+				return %[5]s, %[6]v
+			}
+`, fn.Name, strconv.Quote(fn.Pattern), findString.Name, find.Name, strconv.Quote(prefix), complete)
+	return nil
+}
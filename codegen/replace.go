@@ -0,0 +1,220 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A templatePart is either a literal string or a reference to the whole match.
+type templatePart struct {
+	literal string
+	match   bool
+}
+
+// CheckTemplate reports whether the replacement template can be expanded by a function generated in
+// ModeReplaceAll. The automaton doesn't track capture groups, so apart from $0 (the whole match) the
+// template may only refer to groups which don't exist in the pattern; those expand to an empty string,
+// as in the regexp package. As Func.Pattern is optional, an empty pattern doesn't allow any other group.
+func CheckTemplate(pattern, template string) error {
+	_, err := parseTemplate(pattern, template)
+	return err
+}
+
+// parseTemplate splits the template into parts following the rules of regexp.Expand.
+func parseTemplate(pattern, template string) ([]templatePart, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []templatePart
+	literal := func(s string) {
+		if s == "" {
+			return
+		}
+		if n := len(parts); n > 0 && !parts[n-1].match {
+			parts[n-1].literal += s
+			return
+		}
+		parts = append(parts, templatePart{literal: s})
+	}
+
+	for {
+		i := strings.IndexByte(template, '$')
+		if i < 0 {
+			break
+		}
+		literal(template[:i])
+		template = template[i+1:]
+		if template != "" && template[0] == '$' {
+			literal("$")
+			template = template[1:]
+			continue
+		}
+		name, num, rest, ok := extract(template)
+		if !ok {
+			// Malformed; treat $ as a literal.
+			literal("$")
+			continue
+		}
+		template = rest
+		if num == 0 {
+			parts = append(parts, templatePart{match: true})
+		} else if pattern == "" {
+			return nil, fmt.Errorf("template refers to capture group %q, which can't be checked without the pattern", name)
+		} else if num > 0 && num <= re.NumSubexp() || num < 0 && re.SubexpIndex(name) >= 0 {
+			return nil, fmt.Errorf("template refers to capture group %q: only $0 is supported, as the automaton doesn't track the submatches", name)
+		}
+	}
+	literal(template)
+
+	return parts, nil
+}
+
+// extract returns the name from a leading "name" or "{name}" in str, and its number if the name is
+// a group number, or -1. It is a copy of the unexported function of the regexp package.
+func extract(str string) (name string, num int, rest string, ok bool) {
+	if str == "" {
+		return
+	}
+	brace := false
+	if str[0] == '{' {
+		brace = true
+		str = str[1:]
+	}
+	i := 0
+	for i < len(str) {
+		r, size := utf8.DecodeRuneInString(str[i:])
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			break
+		}
+		i += size
+	}
+	if i == 0 {
+		// Empty name is not okay.
+		return
+	}
+	name = str[:i]
+	if brace {
+		if i >= len(str) || str[i] != '}' {
+			// Missing closing brace.
+			return
+		}
+		i++
+	}
+
+	// Parse the number.
+	num = 0
+	for j := 0; j < len(name); j++ {
+		if name[j] < '0' || '9' < name[j] || num >= 1e8 {
+			num = -1
+			break
+		}
+		num = num*10 + int(name[j]) - '0'
+	}
+	// Disallow leading zeros.
+	if name[0] == '0' && len(name) > 1 {
+		num = -1
+	}
+
+	rest = str[i:]
+	ok = true
+	return
+}
+
+// replaceAll writes a function returning a copy of s with the matches replaced by the expanded template,
+// like regexp.ReplaceAllString. A string is built with strings.Builder, a []byte by appending to a new slice.
+func (f *goFile) replaceAll(out *bytes.Buffer, fn Func, m *machine) error {
+	parts, err := parseTemplate(fn.Pattern, fn.Template)
+	if err != nil {
+		return err
+	}
+
+	cond, body := f.searchBody(fn, m, "at")
+	f.imports["unicode/utf8"] = true
+
+	var decl, write, result, unchanged string
+	if fn.Type == "string" {
+		f.imports["strings"] = true
+		decl = "var b strings.Builder"
+		write = "b.WriteString(%s)"
+		result = `b.WriteString(s[last:])
+				return b.String()`
+		unchanged = "s"
+	} else {
+		decl = "var b []byte"
+		write = "b = append(b, %s...)"
+		result = "return append(b, s[last:]...)"
+		unchanged = "append([]byte(nil), s...)"
+	}
+
+	var repl bytes.Buffer
+	for _, p := range parts {
+		if p.match {
+			fmt.Fprintf(&repl, write+"\n", "s[start:end]")
+		} else {
+			fmt.Fprintf(&repl, write+"\n", strconv.Quote(p.literal))
+		}
+	}
+
+	reject := ""
+	if cond != "" {
+		reject = fmt.Sprintf("if %s {\nreturn %s\n}\n", cond, unchanged)
+	}
+	if fn.Type == "string" {
+		result = "if last == 0 && b.Len() == 0 {\nreturn s\n}\n" + result
+	}
+
+	instr := ""
+	if fn.Type == "string" {
+		instr = "InString"
+	}
+
+	fmt.Fprintf(out, `
+			func %[1]s(s %[2]s) %[2]s {
+				%[3]ssearch := func(at int) (start, end int) {
+					%[4]s}
+				%[5]s
+				last := 0
+				for pos := 0; pos <= len(s); {
+					start, end := search(pos)
+					if start < 0 {
+						break
+					}
+					%[6]s
+					// An empty match right after the previous match is not replaced.
+					if end > last || start == 0 {
+						%[7]s}
+					last = end
+					// As in regexp.ReplaceAllString, but the position moves forward even at the end of s.
+					if _, width := utf8.DecodeRune%[8]s(s[pos:]); width > 0 && pos+width > end {
+						pos += width
+					} else if pos+1 > end {
+						pos++
+					} else {
+						pos = end
+					}
+				}
+				%[9]s
+			}
+`, fn.Name, fn.Type, reject, body, decl, fmt.Sprintf(write, "s[last:start]"), repl.String(), instr, result)
+	return nil
+}
@@ -0,0 +1,208 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/opennota/re2dfa/dfa"
+	"github.com/opennota/re2dfa/nfa"
+)
+
+// rustAssertions maps pseudo-runes to Rust expressions.
+var rustAssertions = map[rune]string{
+	nfa.RuneBeginText:      "i == 0",
+	nfa.RuneEndText:        "i == s.len()",
+	nfa.RuneBeginLine:      "i == 0 || s[i - 1] == b'\\n'",
+	nfa.RuneEndLine:        "i == s.len() || s[i] == b'\\n'",
+	nfa.RuneWordBoundary:   "(i > 0 && %[1]s_is_word_char(s[i - 1])) != (i < s.len() && %[1]s_is_word_char(s[i]))",
+	nfa.RuneNoWordBoundary: "(i > 0 && %[1]s_is_word_char(s[i - 1])) == (i < s.len() && %[1]s_is_word_char(s[i]))",
+}
+
+const rustDecodeRune = `
+fn %[1]s_decode_rune(s: &[u8]) -> (u32, usize) {
+    if s.is_empty() {
+        return (0xfffd, 0);
+    }
+    let b0 = s[0];
+    if b0 < 0x80 {
+        return (b0 as u32, 1);
+    }
+    let (k, mut r, mut lo, mut hi): (usize, u32, u8, u8) = match b0 {
+        0xc2..=0xdf => (2, (b0 & 0x1f) as u32, 0x80, 0xbf),
+        0xe0 => (3, (b0 & 0x0f) as u32, 0xa0, 0xbf),
+        0xed => (3, (b0 & 0x0f) as u32, 0x80, 0x9f),
+        0xe1..=0xef => (3, (b0 & 0x0f) as u32, 0x80, 0xbf),
+        0xf0 => (4, (b0 & 0x07) as u32, 0x90, 0xbf),
+        0xf4 => (4, (b0 & 0x07) as u32, 0x80, 0x8f),
+        0xf1..=0xf3 => (4, (b0 & 0x07) as u32, 0x80, 0xbf),
+        _ => return (0xfffd, 1),
+    };
+    if s.len() < k {
+        return (0xfffd, 1);
+    }
+    for &b in &s[1..k] {
+        if b < lo || b > hi {
+            return (0xfffd, 1);
+        }
+        lo = 0x80;
+        hi = 0xbf;
+        r = (r << 6) | (b & 0x3f) as u32;
+    }
+    (r, k)
+}
+`
+
+const rustIsWordChar = `
+fn %[1]s_is_word_char(c: u8) -> bool {
+    c.is_ascii_alphanumeric() || c == b'_'
+}
+`
+
+// RustGenerate generates Rust source code containing the matching functions
+//
+//	pub fn funcName(s: &str) -> Option<usize>
+//	pub fn funcName_bytes(s: &[u8]) -> Option<usize>
+//
+// which return the end of the match at the beginning of s. The bytes are decoded as UTF-8 the same way as in Go,
+// invalid bytes are treated as U+FFFD. Automata with counters are not supported; they are reported as an *Error.
+func RustGenerate(root *dfa.Node, funcName string) (string, error) {
+	if root.Counts() {
+		return "", &Error{Stage: "generate", Func: funcName, Err: errCounts}
+	}
+	m := newMachine(root)
+
+	assertions := make(map[rune]string, len(rustAssertions))
+	for r, a := range rustAssertions {
+		assertions[r] = strings.Replace(a, "%[1]s", funcName, -1)
+	}
+
+	var buf bytes.Buffer
+	for _, s := range m.states {
+		fmt.Fprintf(&buf, "%d => {\n", s.n)
+
+		for bi, b := range s.empty {
+			if bi > 0 {
+				fmt.Fprint(&buf, "} else ")
+			}
+			fmt.Fprintf(&buf, "if %s {\n", rangesToParenExpr(b.r, assertions))
+			if b.final {
+				fmt.Fprintln(&buf, "end = Some(i);")
+			}
+			if b.next != 0 {
+				fmt.Fprintf(&buf, "st = %d;\ncontinue;\n", b.next)
+			} else if len(s.runes) > 0 {
+				fmt.Fprintln(&buf, "return end;")
+			}
+		}
+		if len(s.empty) > 0 {
+			fmt.Fprintln(&buf, "}")
+		}
+
+		if len(s.runes) > 0 {
+			fmt.Fprintf(&buf, `let (c, n) = %s_decode_rune(&s[i..]);
+				r = c;
+				rlen = n;
+				if rlen == 0 {
+					return end;
+				}
+				i += rlen;
+`, funcName)
+			for bi, b := range s.runes {
+				if bi > 0 {
+					fmt.Fprint(&buf, "} else ")
+				}
+				fmt.Fprintf(&buf, "if %s {\n", rangesToParenExpr(b.r, assertions))
+				if b.final {
+					fmt.Fprintln(&buf, "end = Some(i);")
+				}
+				if b.next != 0 {
+					fmt.Fprintf(&buf, "st = %d;\ncontinue;\n", b.next)
+				}
+			}
+			fmt.Fprintln(&buf, "}")
+		}
+
+		fmt.Fprintln(&buf, "return end;")
+		fmt.Fprintln(&buf, "}")
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintln(&out, "// Code generated by re2dfa (https://github.com/opennota/re2dfa).")
+	if m.decodes() {
+		fmt.Fprintf(&out, rustDecodeRune, funcName)
+	}
+	if m.wordBoundary {
+		fmt.Fprintf(&out, rustIsWordChar, funcName)
+	}
+
+	end := "None"
+	if m.final {
+		end = "Some(0)"
+	}
+	fmt.Fprintf(&out, `
+		pub fn %[1]s(s: &str) -> Option<usize> {
+			%[1]s_bytes(s.as_bytes())
+		}
+
+		#[allow(unused_assignments, unused_mut, unused_variables, unreachable_code)]
+		pub fn %[1]s_bytes(s: &[u8]) -> Option<usize> {
+			let mut end: Option<usize> = %[2]s;
+			let mut r: u32 = 0;
+			let mut rlen: usize = 0;
+			let mut i: usize = 0;
+`, funcName, end)
+	if len(m.states) > 0 {
+		fmt.Fprintf(&out, `let mut st: u32 = %d;
+			loop {
+				match st {
+`, m.states[0].n)
+		out.Write(buf.Bytes())
+		fmt.Fprintln(&out, `_ => unreachable!(),
+				}
+			}`)
+	} else {
+		fmt.Fprintln(&out, "end")
+	}
+	fmt.Fprintln(&out, "}")
+
+	return indent(out.String(), "    "), nil
+}
+
+// indent re-indents C-like source code according to the nesting of braces.
+func indent(src, unit string) string {
+	var buf bytes.Buffer
+	depth := 0
+	for _, line := range strings.Split(src, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			buf.WriteByte('\n')
+			continue
+		}
+		d := depth
+		if strings.HasPrefix(line, "}") {
+			d--
+		}
+		if d < 0 {
+			d = 0
+		}
+		buf.WriteString(strings.Repeat(unit, d))
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+	}
+	return strings.TrimRight(buf.String(), "\n") + "\n"
+}
@@ -0,0 +1,91 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+const rustMain = `
+use std::io::{self, BufRead, Write};
+
+fn main() {
+    let stdin = io::stdin();
+    let stdout = io::stdout();
+    let mut out = stdout.lock();
+    for line in stdin.lock().lines() {
+        let line = line.unwrap();
+        let mut parts = line.splitn(2, ' ');
+        let p: usize = parts.next().unwrap().parse().unwrap();
+        let h = parts.next().unwrap_or("");
+        let b: Vec<u8> = (0..h.len() / 2)
+            .map(|k| u8::from_str_radix(&h[2 * k..2 * k + 2], 16).unwrap())
+            .collect();
+        let e = match p {
+%s            _ => panic!("unknown pattern"),
+        };
+        writeln!(out, "{}", e.map(|e| e as i64).unwrap_or(-1)).unwrap();
+    }
+}
+`
+
+func TestRustGenerate(t *testing.T) {
+	rustc, err := exec.LookPath("rustc")
+	if err != nil {
+		t.Skip("no rustc")
+	}
+
+	roots, cases := backendCases(t)
+
+	var src bytes.Buffer
+	var calls bytes.Buffer
+	for i, root := range roots {
+		code, err := RustGenerate(root, fmt.Sprintf("match%d", i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		src.WriteString(code)
+		fmt.Fprintf(&calls, `            %d => {
+                let e = match%[1]d_bytes(&b);
+                if let Ok(s) = std::str::from_utf8(&b) {
+                    assert_eq!(e, match%[1]d(s));
+                }
+                e
+            }
+`, i)
+	}
+	fmt.Fprintf(&src, rustMain, calls.String())
+
+	dir := t.TempDir()
+	fn := filepath.Join(dir, "main.rs")
+	if err := writeToFile(fn, src.String()); err != nil {
+		t.Fatal(err)
+	}
+	bin := filepath.Join(dir, "main")
+	if out, err := exec.Command(rustc, "--edition", "2018", "-D", "warnings", "-o", bin, fn).CombinedOutput(); err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+
+	checkBackend(t, exec.Command(bin), cases)
+}
+
+func TestRustGenerateCounts(t *testing.T) {
+	if _, err := RustGenerate(countingRoot(t), "match"); err == nil {
+		t.Error("no error for an automaton with counters")
+	}
+}
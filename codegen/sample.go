@@ -0,0 +1,193 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package codegen
+
+import (
+	"math/rand"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/opennota/re2dfa/dfa"
+)
+
+const (
+	maxSampleLen   = 32
+	sampleAttempts = 100
+)
+
+// distances returns the length of the shortest path from every node to a final node.
+// Nodes from which no final node is reachable are absent from the map.
+func distances(nodes []*dfa.Node) map[*dfa.Node]int {
+	dist := make(map[*dfa.Node]int)
+	for _, n := range nodes {
+		if n.F {
+			dist[n] = 0
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, n := range nodes {
+			for _, t := range n.T {
+				d, ok := dist[t.N]
+				if !ok {
+					continue
+				}
+				if cur, ok := dist[n]; !ok || d+1 < cur {
+					dist[n] = d + 1
+					changed = true
+				}
+			}
+		}
+	}
+	return dist
+}
+
+// randomRune returns a random rune from the rune ranges rr, preferring printable ASCII characters.
+func randomRune(rng *rand.Rand, rr []rune) (rune, bool) {
+	rr = positive(rr)
+	if len(rr) == 0 {
+		return 0, false
+	}
+
+	if rng.Intn(4) != 0 {
+		var ascii []rune
+		for i := 0; i < len(rr); i += 2 {
+			lo, hi := rr[i], rr[i+1]
+			if lo < ' ' {
+				lo = ' '
+			}
+			if hi > '~' {
+				hi = '~'
+			}
+			if lo <= hi {
+				ascii = append(ascii, lo, hi)
+			}
+		}
+		if len(ascii) > 0 {
+			rr = ascii
+		}
+	}
+
+	i := rng.Intn(len(rr)/2) * 2
+	lo, hi := rr[i], rr[i+1]
+	r := lo + rune(rng.Int63n(int64(hi-lo)+1))
+	if !utf8.ValidRune(r) {
+		if utf8.ValidRune(lo) {
+			r = lo
+		} else {
+			r = hi
+		}
+	}
+	return r, utf8.ValidRune(r)
+}
+
+// walk generates a random string leading from root to a final node.
+func walk(rng *rand.Rand, root *dfa.Node, dist map[*dfa.Node]int) (string, bool) {
+	if _, ok := dist[root]; !ok {
+		return "", false
+	}
+
+	var s []rune
+	n := root
+	for steps := 0; ; steps++ {
+		if n.F && (rng.Intn(3) == 0 || steps >= maxSampleLen) {
+			return string(s), true
+		}
+
+		var candidates []dfa.T
+		for _, t := range n.T {
+			d, ok := dist[t.N]
+			if !ok {
+				continue
+			}
+			if steps >= maxSampleLen && d >= dist[n] {
+				continue
+			}
+			candidates = append(candidates, t)
+		}
+		if len(candidates) == 0 {
+			return string(s), n.F
+		}
+
+		t := candidates[rng.Intn(len(candidates))]
+		if len(positive(t.R)) > 0 && (len(positive(t.R)) == len(t.R) || rng.Intn(2) == 0) {
+			r, ok := randomRune(rng, t.R)
+			if !ok {
+				return "", false
+			}
+			s = append(s, r)
+		}
+		n = t.N
+	}
+}
+
+// mutate derives a string from s which is likely not to match.
+func mutate(rng *rand.Rand, s string) string {
+	rs := []rune(s)
+	switch rng.Intn(4) {
+	case 0:
+		if len(rs) > 0 {
+			return string(rs[:rng.Intn(len(rs))])
+		}
+	case 1:
+		if len(rs) > 0 {
+			i := rng.Intn(len(rs))
+			return string(rs[:i]) + string(rs[i+1:])
+		}
+	case 2:
+		if len(rs) > 0 {
+			rs[rng.Intn(len(rs))] = rune(' ' + rng.Intn('~'-' '+1))
+			return string(rs)
+		}
+	}
+	return s + string(rune(' '+rng.Intn('~'-' '+1)))
+}
+
+// sample returns sorted strings which are likely to match (strings produced by random walks
+// through the automaton) and likely not to match (mutations of the former and some fixed strings).
+func sample(root *dfa.Node, n int) (matching, nonMatching []string) {
+	rng := rand.New(rand.NewSource(1))
+	nodes := allNodes(root, make(map[*dfa.Node]struct{}))
+	sort.Sort(nodesByState(nodes))
+	dist := distances(nodes)
+
+	seen := make(map[string]bool)
+	for i := 0; i < sampleAttempts && len(matching) < n; i++ {
+		s, ok := walk(rng, root, dist)
+		if !ok || seen[s] {
+			continue
+		}
+		seen[s] = true
+		matching = append(matching, s)
+	}
+
+	for _, s := range []string{"", "\n", "\x00", "\xff", "é", "日本"} {
+		if !seen[s] {
+			seen[s] = true
+			nonMatching = append(nonMatching, s)
+		}
+	}
+	for i := 0; i < sampleAttempts && len(nonMatching) < n && len(matching) > 0; i++ {
+		s := mutate(rng, matching[rng.Intn(len(matching))])
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		nonMatching = append(nonMatching, s)
+	}
+
+	sort.Strings(matching)
+	sort.Strings(nonMatching)
+	return
+}
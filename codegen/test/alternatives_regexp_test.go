@@ -0,0 +1,67 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchAlternativesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:(abc|def))")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"abc",
+		"def",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"!ef",
+		"a",
+		"ab",
+		"abcG",
+		"abcf",
+		"abcp",
+		"aoc",
+		"bc",
+		"d",
+		"d+f",
+		"defb",
+		"defs",
+		"def~",
+		"ef",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchAlternatives(s); got != want {
+			t.Errorf("matchAlternatives(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchAlternatives(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:(abc|def))")
+	re.Longest()
+	for _, s := range []string{
+		"abc",
+		"def",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchAlternatives(s); got != want {
+			t.Errorf("matchAlternatives(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,11 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+func matchBoolEmpty(s string) bool {
+	return true
+}
+
+func matchBoolEmptyBytes(s []byte) bool {
+	return true
+}
@@ -0,0 +1,186 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchBoolEmptyAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:x*)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"x",
+		"xx",
+		"xxx",
+		"xxxx",
+		"xxxxx",
+		"xxxxxx",
+		"xxxxxxx",
+		"xxxxxxxx",
+		"xxxxxxxxxxxx",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"4",
+		"b",
+		"x=xxxxx",
+		"xsxxxxx",
+		"xxD",
+		"xxx,xxx",
+		"xxxExx",
+		"xxx_",
+		"xxxxa",
+		"xxxxh",
+		"xxxxx!",
+		"xxxxxxxx6",
+		"xxxxxxxxxx",
+		"xxxxxxxxxxx",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"xxx",
+		"x x",
+		"xxxx",
+		"xx xx",
+		"xxxxx",
+		"xxx xxx",
+		"xxxxxx",
+		"xxxx xxxx",
+		"xxxxxxx",
+		"xxxxx xxxxx",
+		"xxxxxxxx",
+		"xxxxxx xxxxxx",
+		"xxxxxxxxx",
+		"xxxxxxx xxxxxxx",
+		"xxxxxxxxxx",
+		"xxxxxxxx xxxxxxxx",
+		"xxxxxxxxxxxxxx",
+		"xxxxxxxxxxxx xxxxxxxxxxxx",
+	} {
+		want := re.MatchString(s)
+		if got := matchBoolEmpty(s); got != want {
+			t.Errorf("matchBoolEmpty(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchBoolEmpty(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:x*)")
+	re.Longest()
+	for _, s := range []string{
+		"",
+		"x",
+		"xx",
+		"xxx",
+		"xxxx",
+		"xxxxx",
+		"xxxxxx",
+		"xxxxxxx",
+		"xxxxxxxx",
+		"xxxxxxxxxxxx",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchBoolEmpty(s); got != want {
+			t.Errorf("matchBoolEmpty(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
+
+func TestMatchBoolEmptyBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:x*)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"x",
+		"xx",
+		"xxx",
+		"xxxx",
+		"xxxxx",
+		"xxxxxx",
+		"xxxxxxx",
+		"xxxxxxxx",
+		"xxxxxxxxxxxx",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"4",
+		"b",
+		"x=xxxxx",
+		"xsxxxxx",
+		"xxD",
+		"xxx,xxx",
+		"xxxExx",
+		"xxx_",
+		"xxxxa",
+		"xxxxh",
+		"xxxxx!",
+		"xxxxxxxx6",
+		"xxxxxxxxxx",
+		"xxxxxxxxxxx",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"xxx",
+		"x x",
+		"xxxx",
+		"xx xx",
+		"xxxxx",
+		"xxx xxx",
+		"xxxxxx",
+		"xxxx xxxx",
+		"xxxxxxx",
+		"xxxxx xxxxx",
+		"xxxxxxxx",
+		"xxxxxx xxxxxx",
+		"xxxxxxxxx",
+		"xxxxxxx xxxxxxx",
+		"xxxxxxxxxx",
+		"xxxxxxxx xxxxxxxx",
+		"xxxxxxxxxxxxxx",
+		"xxxxxxxxxxxx xxxxxxxxxxxx",
+	} {
+		want := re.MatchString(s)
+		if got := matchBoolEmptyBytes([]byte(s)); got != want {
+			t.Errorf("matchBoolEmptyBytes(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchBoolEmptyBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:x*)")
+	re.Longest()
+	for _, s := range []string{
+		"",
+		"x",
+		"xx",
+		"xxx",
+		"xxxx",
+		"xxxxx",
+		"xxxxxx",
+		"xxxxxxx",
+		"xxxxxxxx",
+		"xxxxxxxxxxxx",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchBoolEmptyBytes([]byte(s)); got != want {
+			t.Errorf("matchBoolEmptyBytes(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
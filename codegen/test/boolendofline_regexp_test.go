@@ -0,0 +1,266 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchBoolEndOfLineAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:(?m)[a-z]+$)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"fq",
+		"gcexev",
+		"gieydn",
+		"glr",
+		"hvq",
+		"irrzpg",
+		"jnhvcl",
+		"kn",
+		"mbda",
+		"mkmpcl",
+		"moemt",
+		"mslsrc",
+		"ni",
+		"otfk",
+		"r",
+		"smalt",
+		"vjyufhfhmhtzppli",
+		"y",
+		"yw",
+		"z",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"-lr",
+		"Zieydn",
+		"gexev",
+		"irrzpg9",
+		"jkmpcl",
+		"knQ",
+		"knW",
+		"malt",
+		"moe@t",
+		"msls",
+		"n9",
+		"otfkb",
+		"pn",
+		"tfk",
+		"é",
+		"日本",
+		"\xff",
+		"xfqx",
+		"fq fq",
+		"xgcexevx",
+		"gcexev gcexev",
+		"xgieydnx",
+		"gieydn gieydn",
+		"xglrx",
+		"glr glr",
+		"xhvqx",
+		"hvq hvq",
+		"xirrzpgx",
+		"irrzpg irrzpg",
+		"xjnhvclx",
+		"jnhvcl jnhvcl",
+		"xknx",
+		"kn kn",
+		"xmbdax",
+		"mbda mbda",
+		"xmkmpclx",
+		"mkmpcl mkmpcl",
+		"xmoemtx",
+		"moemt moemt",
+		"xmslsrcx",
+		"mslsrc mslsrc",
+		"xnix",
+		"ni ni",
+		"xotfkx",
+		"otfk otfk",
+		"xrx",
+		"r r",
+		"xsmaltx",
+		"smalt smalt",
+		"xvjyufhfhmhtzpplix",
+		"vjyufhfhmhtzppli vjyufhfhmhtzppli",
+		"xyx",
+		"y y",
+		"xywx",
+		"yw yw",
+		"xzx",
+		"z z",
+	} {
+		want := re.MatchString(s)
+		if got := matchBoolEndOfLine(s); got != want {
+			t.Errorf("matchBoolEndOfLine(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchBoolEndOfLine(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:(?m)[a-z]+$)")
+	re.Longest()
+	for _, s := range []string{
+		"fq",
+		"gcexev",
+		"gieydn",
+		"glr",
+		"hvq",
+		"irrzpg",
+		"jnhvcl",
+		"kn",
+		"mbda",
+		"mkmpcl",
+		"moemt",
+		"mslsrc",
+		"ni",
+		"otfk",
+		"r",
+		"smalt",
+		"vjyufhfhmhtzppli",
+		"y",
+		"yw",
+		"z",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchBoolEndOfLine(s); got != want {
+			t.Errorf("matchBoolEndOfLine(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
+
+func TestMatchBoolEndOfLineBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:(?m)[a-z]+$)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"fq",
+		"gcexev",
+		"gieydn",
+		"glr",
+		"hvq",
+		"irrzpg",
+		"jnhvcl",
+		"kn",
+		"mbda",
+		"mkmpcl",
+		"moemt",
+		"mslsrc",
+		"ni",
+		"otfk",
+		"r",
+		"smalt",
+		"vjyufhfhmhtzppli",
+		"y",
+		"yw",
+		"z",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"-lr",
+		"Zieydn",
+		"gexev",
+		"irrzpg9",
+		"jkmpcl",
+		"knQ",
+		"knW",
+		"malt",
+		"moe@t",
+		"msls",
+		"n9",
+		"otfkb",
+		"pn",
+		"tfk",
+		"é",
+		"日本",
+		"\xff",
+		"xfqx",
+		"fq fq",
+		"xgcexevx",
+		"gcexev gcexev",
+		"xgieydnx",
+		"gieydn gieydn",
+		"xglrx",
+		"glr glr",
+		"xhvqx",
+		"hvq hvq",
+		"xirrzpgx",
+		"irrzpg irrzpg",
+		"xjnhvclx",
+		"jnhvcl jnhvcl",
+		"xknx",
+		"kn kn",
+		"xmbdax",
+		"mbda mbda",
+		"xmkmpclx",
+		"mkmpcl mkmpcl",
+		"xmoemtx",
+		"moemt moemt",
+		"xmslsrcx",
+		"mslsrc mslsrc",
+		"xnix",
+		"ni ni",
+		"xotfkx",
+		"otfk otfk",
+		"xrx",
+		"r r",
+		"xsmaltx",
+		"smalt smalt",
+		"xvjyufhfhmhtzpplix",
+		"vjyufhfhmhtzppli vjyufhfhmhtzppli",
+		"xyx",
+		"y y",
+		"xywx",
+		"yw yw",
+		"xzx",
+		"z z",
+	} {
+		want := re.MatchString(s)
+		if got := matchBoolEndOfLineBytes([]byte(s)); got != want {
+			t.Errorf("matchBoolEndOfLineBytes(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchBoolEndOfLineBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:(?m)[a-z]+$)")
+	re.Longest()
+	for _, s := range []string{
+		"fq",
+		"gcexev",
+		"gieydn",
+		"glr",
+		"hvq",
+		"irrzpg",
+		"jnhvcl",
+		"kn",
+		"mbda",
+		"mkmpcl",
+		"moemt",
+		"mslsrc",
+		"ni",
+		"otfk",
+		"r",
+		"smalt",
+		"vjyufhfhmhtzppli",
+		"y",
+		"yw",
+		"z",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchBoolEndOfLineBytes([]byte(s)); got != want {
+			t.Errorf("matchBoolEndOfLineBytes(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import "unicode/utf8"
+
+func matchBoolEndOfText(s string) bool {
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r == 97:
+		goto s2
+	}
+	return false
+s2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r == 98:
+		goto s3
+	case r == 99:
+		goto s4
+	}
+	return false
+s3:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r == 98:
+		goto s3
+	case r == 99:
+		goto s4
+	}
+	return false
+s4:
+	switch {
+	case i == len(s):
+		return true
+	}
+	return false
+}
+
+func matchBoolEndOfTextBytes(s []byte) bool {
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r == 97:
+		goto s2
+	}
+	return false
+s2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r == 98:
+		goto s3
+	case r == 99:
+		goto s4
+	}
+	return false
+s3:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r == 98:
+		goto s3
+	case r == 99:
+		goto s4
+	}
+	return false
+s4:
+	switch {
+	case i == len(s):
+		return true
+	}
+	return false
+}
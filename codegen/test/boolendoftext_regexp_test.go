@@ -0,0 +1,146 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchBoolEndOfTextAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:ab*c$)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"abbbbc",
+		"abbbc",
+		"abbc",
+		"abc",
+		"ac",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"a",
+		"ab",
+		"abb",
+		"abb!",
+		"abbbbc+",
+		"abbbbcb",
+		"abbbbc~",
+		"abbcG",
+		"abbcf",
+		"abbcs",
+		"abcp",
+		"aoc",
+		"bbbc",
+		"bc",
+		"é",
+		"日本",
+		"\xff",
+		"xabbbbcx",
+		"abbbbc abbbbc",
+		"xabbbcx",
+		"abbbc abbbc",
+		"xabbcx",
+		"abbc abbc",
+		"xabcx",
+		"abc abc",
+		"xacx",
+		"ac ac",
+	} {
+		want := re.MatchString(s)
+		if got := matchBoolEndOfText(s); got != want {
+			t.Errorf("matchBoolEndOfText(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchBoolEndOfText(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:ab*c$)")
+	re.Longest()
+	for _, s := range []string{
+		"abbbbc",
+		"abbbc",
+		"abbc",
+		"abc",
+		"ac",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchBoolEndOfText(s); got != want {
+			t.Errorf("matchBoolEndOfText(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
+
+func TestMatchBoolEndOfTextBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:ab*c$)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"abbbbc",
+		"abbbc",
+		"abbc",
+		"abc",
+		"ac",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"a",
+		"ab",
+		"abb",
+		"abb!",
+		"abbbbc+",
+		"abbbbcb",
+		"abbbbc~",
+		"abbcG",
+		"abbcf",
+		"abbcs",
+		"abcp",
+		"aoc",
+		"bbbc",
+		"bc",
+		"é",
+		"日本",
+		"\xff",
+		"xabbbbcx",
+		"abbbbc abbbbc",
+		"xabbbcx",
+		"abbbc abbbc",
+		"xabbcx",
+		"abbc abbc",
+		"xabcx",
+		"abc abc",
+		"xacx",
+		"ac ac",
+	} {
+		want := re.MatchString(s)
+		if got := matchBoolEndOfTextBytes([]byte(s)); got != want {
+			t.Errorf("matchBoolEndOfTextBytes(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchBoolEndOfTextBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:ab*c$)")
+	re.Longest()
+	for _, s := range []string{
+		"abbbbc",
+		"abbbc",
+		"abbc",
+		"abc",
+		"ac",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchBoolEndOfTextBytes([]byte(s)); got != want {
+			t.Errorf("matchBoolEndOfTextBytes(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,170 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchBoolLazyAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a*?b)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"aaaaaaaaaab",
+		"aaaaaab",
+		"aaaaab",
+		"aaaab",
+		"aaab",
+		"aab",
+		"ab",
+		"b",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"!aaaab",
+		"%b",
+		"a$aaab",
+		"aaaa",
+		"aaaaaaaaaab;",
+		"aaaaaaaaab",
+		"aaaaaab3",
+		"aaaaaabT",
+		"aaaaaahaaab",
+		"aaaaab!",
+		"aaabU",
+		"aapab",
+		"bK",
+		"zab",
+		"é",
+		"日本",
+		"\xff",
+		"xaaaaaaaaaabx",
+		"aaaaaaaaaab aaaaaaaaaab",
+		"xaaaaaabx",
+		"aaaaaab aaaaaab",
+		"xaaaaabx",
+		"aaaaab aaaaab",
+		"xaaaabx",
+		"aaaab aaaab",
+		"xaaabx",
+		"aaab aaab",
+		"xaabx",
+		"aab aab",
+		"xabx",
+		"ab ab",
+		"xbx",
+		"b b",
+	} {
+		want := re.MatchString(s)
+		if got := matchBoolLazy(s); got != want {
+			t.Errorf("matchBoolLazy(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchBoolLazy(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a*?b)")
+	re.Longest()
+	for _, s := range []string{
+		"aaaaaaaaaab",
+		"aaaaaab",
+		"aaaaab",
+		"aaaab",
+		"aaab",
+		"aab",
+		"ab",
+		"b",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchBoolLazy(s); got != want {
+			t.Errorf("matchBoolLazy(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
+
+func TestMatchBoolLazyBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a*?b)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"aaaaaaaaaab",
+		"aaaaaab",
+		"aaaaab",
+		"aaaab",
+		"aaab",
+		"aab",
+		"ab",
+		"b",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"!aaaab",
+		"%b",
+		"a$aaab",
+		"aaaa",
+		"aaaaaaaaaab;",
+		"aaaaaaaaab",
+		"aaaaaab3",
+		"aaaaaabT",
+		"aaaaaahaaab",
+		"aaaaab!",
+		"aaabU",
+		"aapab",
+		"bK",
+		"zab",
+		"é",
+		"日本",
+		"\xff",
+		"xaaaaaaaaaabx",
+		"aaaaaaaaaab aaaaaaaaaab",
+		"xaaaaaabx",
+		"aaaaaab aaaaaab",
+		"xaaaaabx",
+		"aaaaab aaaaab",
+		"xaaaabx",
+		"aaaab aaaab",
+		"xaaabx",
+		"aaab aaab",
+		"xaabx",
+		"aab aab",
+		"xabx",
+		"ab ab",
+		"xbx",
+		"b b",
+	} {
+		want := re.MatchString(s)
+		if got := matchBoolLazyBytes([]byte(s)); got != want {
+			t.Errorf("matchBoolLazyBytes(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchBoolLazyBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a*?b)")
+	re.Longest()
+	for _, s := range []string{
+		"aaaaaaaaaab",
+		"aaaaaab",
+		"aaaaab",
+		"aaaab",
+		"aaab",
+		"aab",
+		"ab",
+		"b",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchBoolLazyBytes([]byte(s)); got != want {
+			t.Errorf("matchBoolLazyBytes(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
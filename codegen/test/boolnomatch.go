@@ -0,0 +1,19 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+func matchBoolNoMatch(s string) bool {
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	return false
+}
+
+func matchBoolNoMatchBytes(s []byte) bool {
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	return false
+}
@@ -0,0 +1,76 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchBoolNoMatchAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:[^\\x00-\\x{10FFFF}])")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := re.MatchString(s)
+		if got := matchBoolNoMatch(s); got != want {
+			t.Errorf("matchBoolNoMatch(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchBoolNoMatch(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:[^\\x00-\\x{10FFFF}])")
+	re.Longest()
+	for _, s := range []string{} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchBoolNoMatch(s); got != want {
+			t.Errorf("matchBoolNoMatch(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
+
+func TestMatchBoolNoMatchBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:[^\\x00-\\x{10FFFF}])")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := re.MatchString(s)
+		if got := matchBoolNoMatchBytes([]byte(s)); got != want {
+			t.Errorf("matchBoolNoMatchBytes(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchBoolNoMatchBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:[^\\x00-\\x{10FFFF}])")
+	re.Longest()
+	for _, s := range []string{} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchBoolNoMatchBytes([]byte(s)); got != want {
+			t.Errorf("matchBoolNoMatchBytes(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,39 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import "unicode/utf8"
+
+func matchBoolPlus(s string) bool {
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r == 97:
+		return true
+	}
+	return false
+}
+
+func matchBoolPlusBytes(s []byte) bool {
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r == 97:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchBoolPlusAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a+)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaa",
+		"aaaaaaaaaa",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		";aaaaaaaaa",
+		"Da",
+		"a5aaa",
+		"aa$",
+		"aaaaR",
+		"aaaa`",
+		"aaaaaWaa",
+		"aaaaaa ",
+		"aaaaaaaG",
+		"aaaaaaaaaaS",
+		"aaaaaaaaah",
+		"aaaab",
+		"aaaa~",
+		"aaa}",
+		"é",
+		"日本",
+		"\xff",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xaaaaaax",
+		"aaaaaa aaaaaa",
+		"xaaaaaaax",
+		"aaaaaaa aaaaaaa",
+		"xaaaaaaaax",
+		"aaaaaaaa aaaaaaaa",
+		"xaaaaaaaaax",
+		"aaaaaaaaa aaaaaaaaa",
+		"xaaaaaaaaaax",
+		"aaaaaaaaaa aaaaaaaaaa",
+	} {
+		want := re.MatchString(s)
+		if got := matchBoolPlus(s); got != want {
+			t.Errorf("matchBoolPlus(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchBoolPlus(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a+)")
+	re.Longest()
+	for _, s := range []string{
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaa",
+		"aaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchBoolPlus(s); got != want {
+			t.Errorf("matchBoolPlus(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
+
+func TestMatchBoolPlusBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a+)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaa",
+		"aaaaaaaaaa",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		";aaaaaaaaa",
+		"Da",
+		"a5aaa",
+		"aa$",
+		"aaaaR",
+		"aaaa`",
+		"aaaaaWaa",
+		"aaaaaa ",
+		"aaaaaaaG",
+		"aaaaaaaaaaS",
+		"aaaaaaaaah",
+		"aaaab",
+		"aaaa~",
+		"aaa}",
+		"é",
+		"日本",
+		"\xff",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xaaaaaax",
+		"aaaaaa aaaaaa",
+		"xaaaaaaax",
+		"aaaaaaa aaaaaaa",
+		"xaaaaaaaax",
+		"aaaaaaaa aaaaaaaa",
+		"xaaaaaaaaax",
+		"aaaaaaaaa aaaaaaaaa",
+		"xaaaaaaaaaax",
+		"aaaaaaaaaa aaaaaaaaaa",
+	} {
+		want := re.MatchString(s)
+		if got := matchBoolPlusBytes([]byte(s)); got != want {
+			t.Errorf("matchBoolPlusBytes(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchBoolPlusBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a+)")
+	re.Longest()
+	for _, s := range []string{
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaa",
+		"aaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchBoolPlusBytes([]byte(s)); got != want {
+			t.Errorf("matchBoolPlusBytes(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import "unicode/utf8"
+
+func matchBoolWordBoundary(s string) bool {
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r == 97:
+		goto s2
+	}
+	return false
+s2:
+	switch {
+	case (i > 0 && matchBoolWordBoundary57f59fd4IsWordChar(s[i-1])) != (i < len(s) && matchBoolWordBoundary57f59fd4IsWordChar(s[i])):
+		return true
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r == 97:
+		goto s2
+	}
+	return false
+}
+
+func matchBoolWordBoundaryBytes(s []byte) bool {
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r == 97:
+		goto s2
+	}
+	return false
+s2:
+	switch {
+	case (i > 0 && matchBoolWordBoundary57f59fd4IsWordChar(s[i-1])) != (i < len(s) && matchBoolWordBoundary57f59fd4IsWordChar(s[i])):
+		return true
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r == 97:
+		goto s2
+	}
+	return false
+}
+
+func matchBoolWordBoundary57f59fd4IsWordChar(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_'
+}
@@ -0,0 +1,250 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchBoolWordBoundaryAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a+\\b)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaa",
+		"aaaaaaaaaa",
+		"aaaaaaaaaaa",
+		"aaaaaaaaaaaa",
+		"aaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaaaaaaaa",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"Kaa",
+		"Yaaa",
+		"aHaa",
+		"aa%",
+		"aaMa",
+		"aaa@",
+		"aaaa_aaaaaaa",
+		"aaaaaL",
+		"aaaaaaVa",
+		"aaaaaaaaaaaa-",
+		"aaaaaaaaaaaa.aaaaa",
+		"aaaaaaaaaaaaaaaaa$",
+		"aaaaaaaaaaaaaaaaaaacaaaa",
+		"aaza",
+		"é",
+		"日本",
+		"\xff",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xaaaaaax",
+		"aaaaaa aaaaaa",
+		"xaaaaaaax",
+		"aaaaaaa aaaaaaa",
+		"xaaaaaaaax",
+		"aaaaaaaa aaaaaaaa",
+		"xaaaaaaaaax",
+		"aaaaaaaaa aaaaaaaaa",
+		"xaaaaaaaaaax",
+		"aaaaaaaaaa aaaaaaaaaa",
+		"xaaaaaaaaaaax",
+		"aaaaaaaaaaa aaaaaaaaaaa",
+		"xaaaaaaaaaaaax",
+		"aaaaaaaaaaaa aaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaa aaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaaaaaaaaaa",
+	} {
+		want := re.MatchString(s)
+		if got := matchBoolWordBoundary(s); got != want {
+			t.Errorf("matchBoolWordBoundary(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchBoolWordBoundary(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a+\\b)")
+	re.Longest()
+	for _, s := range []string{
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaa",
+		"aaaaaaaaaa",
+		"aaaaaaaaaaa",
+		"aaaaaaaaaaaa",
+		"aaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchBoolWordBoundary(s); got != want {
+			t.Errorf("matchBoolWordBoundary(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
+
+func TestMatchBoolWordBoundaryBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a+\\b)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaa",
+		"aaaaaaaaaa",
+		"aaaaaaaaaaa",
+		"aaaaaaaaaaaa",
+		"aaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaaaaaaaa",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"Kaa",
+		"Yaaa",
+		"aHaa",
+		"aa%",
+		"aaMa",
+		"aaa@",
+		"aaaa_aaaaaaa",
+		"aaaaaL",
+		"aaaaaaVa",
+		"aaaaaaaaaaaa-",
+		"aaaaaaaaaaaa.aaaaa",
+		"aaaaaaaaaaaaaaaaa$",
+		"aaaaaaaaaaaaaaaaaaacaaaa",
+		"aaza",
+		"é",
+		"日本",
+		"\xff",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xaaaaaax",
+		"aaaaaa aaaaaa",
+		"xaaaaaaax",
+		"aaaaaaa aaaaaaa",
+		"xaaaaaaaax",
+		"aaaaaaaa aaaaaaaa",
+		"xaaaaaaaaax",
+		"aaaaaaaaa aaaaaaaaa",
+		"xaaaaaaaaaax",
+		"aaaaaaaaaa aaaaaaaaaa",
+		"xaaaaaaaaaaax",
+		"aaaaaaaaaaa aaaaaaaaaaa",
+		"xaaaaaaaaaaaax",
+		"aaaaaaaaaaaa aaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaa aaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaaaaaaaaaa",
+	} {
+		want := re.MatchString(s)
+		if got := matchBoolWordBoundaryBytes([]byte(s)); got != want {
+			t.Errorf("matchBoolWordBoundaryBytes(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchBoolWordBoundaryBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a+\\b)")
+	re.Longest()
+	for _, s := range []string{
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaa",
+		"aaaaaaaaaa",
+		"aaaaaaaaaaa",
+		"aaaaaaaaaaaa",
+		"aaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchBoolWordBoundaryBytes([]byte(s)); got != want {
+			t.Errorf("matchBoolWordBoundaryBytes(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchCharClassAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:[a-z])")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		"b",
+		"c",
+		"d",
+		"f",
+		"g",
+		"h",
+		"i",
+		"j",
+		"k",
+		"l",
+		"m",
+		"n",
+		"o",
+		"p",
+		"q",
+		"s",
+		"u",
+		"w",
+		"y",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		")",
+		".",
+		"E",
+		"H",
+		"[",
+		"\\",
+		"`",
+		"a(",
+		"b$",
+		"di",
+		"hm",
+		"m|",
+		"oY",
+		"p5",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCharClass(s); got != want {
+			t.Errorf("matchCharClass(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCharClass(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:[a-z])")
+	re.Longest()
+	for _, s := range []string{
+		"a",
+		"b",
+		"c",
+		"d",
+		"f",
+		"g",
+		"h",
+		"i",
+		"j",
+		"k",
+		"l",
+		"m",
+		"n",
+		"o",
+		"p",
+		"q",
+		"s",
+		"u",
+		"w",
+		"y",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCharClass(s); got != want {
+			t.Errorf("matchCharClass(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
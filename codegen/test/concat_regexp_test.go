@@ -0,0 +1,77 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchConcatAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:ab+c)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"abbbbbbbc",
+		"abbbbbbc",
+		"abbbbbc",
+		"abbbbc",
+		"abbbc",
+		"abbc",
+		"abc",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"9bbbc",
+		"a",
+		"aUbc",
+		"ab",
+		"abb",
+		"abbb",
+		"abbbabbc",
+		"abbbbbbbcb",
+		"abbbbbbkc",
+		"abbbbbco",
+		"abbbbc{",
+		"abbcU",
+		"abc:",
+		"bbbbbbbc",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchConcat(s); got != want {
+			t.Errorf("matchConcat(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchConcat(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:ab+c)")
+	re.Longest()
+	for _, s := range []string{
+		"abbbbbbbc",
+		"abbbbbbc",
+		"abbbbbc",
+		"abbbbc",
+		"abbbc",
+		"abbc",
+		"abc",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchConcat(s); got != want {
+			t.Errorf("matchConcat(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,504 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import "unicode/utf8"
+
+func matchCountBool(s string) bool {
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	cnt1 := matchCountBool87ebb2ddCounter{entered: make([]int, 13)}
+	cnt1.enter()
+s1:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 2:
+			goto s1
+		case cnt1.max() >= 2:
+			goto s2
+		}
+	}
+	return false
+s2:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 2:
+			goto s1
+		case cnt1.max() >= 2:
+			goto s2
+		}
+	}
+	return false
+}
+
+func matchCountBoolBytes(s []byte) bool {
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	cnt1 := matchCountBool87ebb2ddCounter{entered: make([]int, 13)}
+	cnt1.enter()
+s1:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 2:
+			goto s1
+		case cnt1.max() >= 2:
+			goto s2
+		}
+	}
+	return false
+s2:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 2:
+			goto s1
+		case cnt1.max() >= 2:
+			goto s2
+		}
+	}
+	return false
+}
+
+func matchCountBoolExpanded(s string) bool {
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s2
+	}
+	return false
+s2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s3
+	}
+	return false
+s3:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s5
+	}
+	return false
+s5:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s7
+	}
+	return false
+s7:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s9
+	}
+	return false
+s9:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s11
+	}
+	return false
+s11:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s13
+	}
+	return false
+s13:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s15
+	}
+	return false
+s15:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s17
+	}
+	return false
+s17:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s19
+	}
+	return false
+s19:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s21
+	}
+	return false
+s21:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s23
+	}
+	return false
+s23:
+	switch {
+	case i == len(s):
+		return true
+	}
+	return false
+}
+
+func matchCountBoolExpandedBytes(s []byte) bool {
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s2
+	}
+	return false
+s2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s3
+	}
+	return false
+s3:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s5
+	}
+	return false
+s5:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s7
+	}
+	return false
+s7:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s9
+	}
+	return false
+s9:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s11
+	}
+	return false
+s11:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s13
+	}
+	return false
+s13:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s15
+	}
+	return false
+s15:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s17
+	}
+	return false
+s17:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s19
+	}
+	return false
+s19:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s21
+	}
+	return false
+s21:
+	switch {
+	case i == len(s):
+		return true
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return false
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto s23
+	}
+	return false
+s23:
+	switch {
+	case i == len(s):
+		return true
+	}
+	return false
+}
+
+// matchCountBool87ebb2ddCounter holds the numbers of runes counted by the threads in a bounded repetition,
+// as the numbers of runes counted by the register when they entered the repetition, oldest first.
+type matchCountBool87ebb2ddCounter struct {
+	entered []int // ring buffer of the maximum number of runes + 1 numbers
+	head, n int
+	count   int
+}
+
+func (c *matchCountBool87ebb2ddCounter) increment() {
+	c.count++
+	if c.n > 0 && c.count-c.entered[c.head] >= len(c.entered) {
+		// The oldest thread has counted too many runes.
+		c.head = (c.head + 1) % len(c.entered)
+		c.n--
+	}
+}
+
+func (c *matchCountBool87ebb2ddCounter) reset() {
+	c.n = 0
+}
+
+func (c *matchCountBool87ebb2ddCounter) enter() {
+	if c.n > 0 && c.entered[(c.head+c.n-1)%len(c.entered)] == c.count {
+		return
+	}
+	c.entered[(c.head+c.n)%len(c.entered)] = c.count
+	c.n++
+}
+
+// max returns the number of runes counted by the oldest thread, or -1 if there are no threads.
+func (c *matchCountBool87ebb2ddCounter) max() int {
+	if c.n == 0 {
+		return -1
+	}
+	return c.count - c.entered[c.head]
+}
@@ -0,0 +1,524 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchCountBoolAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:[0-9]{2,12}$)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"037112965",
+		"09534116",
+		"121",
+		"187317197",
+		"239",
+		"243028445382558451135922391",
+		"30158227",
+		"32044032426340928",
+		"37",
+		"4305789491970892000",
+		"51",
+		"543202502300687267769038825554",
+		"5435992115849761373179",
+		"767658964551032199755191431332",
+		"79954164667146409470640132579",
+		"80374",
+		"83",
+		"9378157033274",
+		"94",
+		"99",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"03D112965",
+		"09534116f",
+		"187317197K",
+		"2",
+		"3244032426340928",
+		"378157033274",
+		"37a",
+		"51a",
+		"767'58964551032199755191431332",
+		"7E954164667146409470640132579",
+		"8074",
+		"9",
+		"93781570",
+		"=1",
+		"é",
+		"日本",
+		"\xff",
+		"x037112965x",
+		"037112965 037112965",
+		"x09534116x",
+		"09534116 09534116",
+		"x121x",
+		"121 121",
+		"x187317197x",
+		"187317197 187317197",
+		"x239x",
+		"239 239",
+		"x243028445382558451135922391x",
+		"243028445382558451135922391 243028445382558451135922391",
+		"x30158227x",
+		"30158227 30158227",
+		"x32044032426340928x",
+		"32044032426340928 32044032426340928",
+		"x37x",
+		"37 37",
+		"x4305789491970892000x",
+		"4305789491970892000 4305789491970892000",
+		"x51x",
+		"51 51",
+		"x543202502300687267769038825554x",
+		"543202502300687267769038825554 543202502300687267769038825554",
+		"x5435992115849761373179x",
+		"5435992115849761373179 5435992115849761373179",
+		"x767658964551032199755191431332x",
+		"767658964551032199755191431332 767658964551032199755191431332",
+		"x79954164667146409470640132579x",
+		"79954164667146409470640132579 79954164667146409470640132579",
+		"x80374x",
+		"80374 80374",
+		"x83x",
+		"83 83",
+		"x9378157033274x",
+		"9378157033274 9378157033274",
+		"x94x",
+		"94 94",
+		"x99x",
+		"99 99",
+	} {
+		want := re.MatchString(s)
+		if got := matchCountBool(s); got != want {
+			t.Errorf("matchCountBool(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountBool(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:[0-9]{2,12}$)")
+	re.Longest()
+	for _, s := range []string{
+		"037112965",
+		"09534116",
+		"121",
+		"187317197",
+		"239",
+		"243028445382558451135922391",
+		"30158227",
+		"32044032426340928",
+		"37",
+		"4305789491970892000",
+		"51",
+		"543202502300687267769038825554",
+		"5435992115849761373179",
+		"767658964551032199755191431332",
+		"79954164667146409470640132579",
+		"80374",
+		"83",
+		"9378157033274",
+		"94",
+		"99",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchCountBool(s); got != want {
+			t.Errorf("matchCountBool(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountBoolBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:[0-9]{2,12}$)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"037112965",
+		"09534116",
+		"121",
+		"187317197",
+		"239",
+		"243028445382558451135922391",
+		"30158227",
+		"32044032426340928",
+		"37",
+		"4305789491970892000",
+		"51",
+		"543202502300687267769038825554",
+		"5435992115849761373179",
+		"767658964551032199755191431332",
+		"79954164667146409470640132579",
+		"80374",
+		"83",
+		"9378157033274",
+		"94",
+		"99",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"03D112965",
+		"09534116f",
+		"187317197K",
+		"2",
+		"3244032426340928",
+		"378157033274",
+		"37a",
+		"51a",
+		"767'58964551032199755191431332",
+		"7E954164667146409470640132579",
+		"8074",
+		"9",
+		"93781570",
+		"=1",
+		"é",
+		"日本",
+		"\xff",
+		"x037112965x",
+		"037112965 037112965",
+		"x09534116x",
+		"09534116 09534116",
+		"x121x",
+		"121 121",
+		"x187317197x",
+		"187317197 187317197",
+		"x239x",
+		"239 239",
+		"x243028445382558451135922391x",
+		"243028445382558451135922391 243028445382558451135922391",
+		"x30158227x",
+		"30158227 30158227",
+		"x32044032426340928x",
+		"32044032426340928 32044032426340928",
+		"x37x",
+		"37 37",
+		"x4305789491970892000x",
+		"4305789491970892000 4305789491970892000",
+		"x51x",
+		"51 51",
+		"x543202502300687267769038825554x",
+		"543202502300687267769038825554 543202502300687267769038825554",
+		"x5435992115849761373179x",
+		"5435992115849761373179 5435992115849761373179",
+		"x767658964551032199755191431332x",
+		"767658964551032199755191431332 767658964551032199755191431332",
+		"x79954164667146409470640132579x",
+		"79954164667146409470640132579 79954164667146409470640132579",
+		"x80374x",
+		"80374 80374",
+		"x83x",
+		"83 83",
+		"x9378157033274x",
+		"9378157033274 9378157033274",
+		"x94x",
+		"94 94",
+		"x99x",
+		"99 99",
+	} {
+		want := re.MatchString(s)
+		if got := matchCountBoolBytes([]byte(s)); got != want {
+			t.Errorf("matchCountBoolBytes(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountBoolBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:[0-9]{2,12}$)")
+	re.Longest()
+	for _, s := range []string{
+		"037112965",
+		"09534116",
+		"121",
+		"187317197",
+		"239",
+		"243028445382558451135922391",
+		"30158227",
+		"32044032426340928",
+		"37",
+		"4305789491970892000",
+		"51",
+		"543202502300687267769038825554",
+		"5435992115849761373179",
+		"767658964551032199755191431332",
+		"79954164667146409470640132579",
+		"80374",
+		"83",
+		"9378157033274",
+		"94",
+		"99",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchCountBoolBytes([]byte(s)); got != want {
+			t.Errorf("matchCountBoolBytes(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountBoolExpandedAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:[0-9]{2,12}$)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"008765",
+		"03",
+		"059901",
+		"0953418",
+		"170211570353",
+		"18",
+		"219",
+		"2574",
+		"3598",
+		"36",
+		"558",
+		"6160",
+		"67",
+		"749541",
+		"7496",
+		"834095",
+		"890093",
+		"9112063",
+		"95",
+		"976",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"053418",
+		"05991",
+		"160",
+		"170211570353`",
+		"19",
+		"21",
+		"21r",
+		"359Q",
+		"6160b",
+		"7496U",
+		"834095%",
+		"911Z063",
+		"97",
+		"j953418",
+		"é",
+		"日本",
+		"\xff",
+		"x008765x",
+		"008765 008765",
+		"x03x",
+		"03 03",
+		"x059901x",
+		"059901 059901",
+		"x0953418x",
+		"0953418 0953418",
+		"x170211570353x",
+		"170211570353 170211570353",
+		"x18x",
+		"18 18",
+		"x219x",
+		"219 219",
+		"x2574x",
+		"2574 2574",
+		"x3598x",
+		"3598 3598",
+		"x36x",
+		"36 36",
+		"x558x",
+		"558 558",
+		"x6160x",
+		"6160 6160",
+		"x67x",
+		"67 67",
+		"x749541x",
+		"749541 749541",
+		"x7496x",
+		"7496 7496",
+		"x834095x",
+		"834095 834095",
+		"x890093x",
+		"890093 890093",
+		"x9112063x",
+		"9112063 9112063",
+		"x95x",
+		"95 95",
+		"x976x",
+		"976 976",
+	} {
+		want := re.MatchString(s)
+		if got := matchCountBoolExpanded(s); got != want {
+			t.Errorf("matchCountBoolExpanded(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountBoolExpanded(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:[0-9]{2,12}$)")
+	re.Longest()
+	for _, s := range []string{
+		"008765",
+		"03",
+		"059901",
+		"0953418",
+		"170211570353",
+		"18",
+		"219",
+		"2574",
+		"3598",
+		"36",
+		"558",
+		"6160",
+		"67",
+		"749541",
+		"7496",
+		"834095",
+		"890093",
+		"9112063",
+		"95",
+		"976",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchCountBoolExpanded(s); got != want {
+			t.Errorf("matchCountBoolExpanded(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountBoolExpandedBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:[0-9]{2,12}$)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"008765",
+		"03",
+		"059901",
+		"0953418",
+		"170211570353",
+		"18",
+		"219",
+		"2574",
+		"3598",
+		"36",
+		"558",
+		"6160",
+		"67",
+		"749541",
+		"7496",
+		"834095",
+		"890093",
+		"9112063",
+		"95",
+		"976",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"053418",
+		"05991",
+		"160",
+		"170211570353`",
+		"19",
+		"21",
+		"21r",
+		"359Q",
+		"6160b",
+		"7496U",
+		"834095%",
+		"911Z063",
+		"97",
+		"j953418",
+		"é",
+		"日本",
+		"\xff",
+		"x008765x",
+		"008765 008765",
+		"x03x",
+		"03 03",
+		"x059901x",
+		"059901 059901",
+		"x0953418x",
+		"0953418 0953418",
+		"x170211570353x",
+		"170211570353 170211570353",
+		"x18x",
+		"18 18",
+		"x219x",
+		"219 219",
+		"x2574x",
+		"2574 2574",
+		"x3598x",
+		"3598 3598",
+		"x36x",
+		"36 36",
+		"x558x",
+		"558 558",
+		"x6160x",
+		"6160 6160",
+		"x67x",
+		"67 67",
+		"x749541x",
+		"749541 749541",
+		"x7496x",
+		"7496 7496",
+		"x834095x",
+		"834095 834095",
+		"x890093x",
+		"890093 890093",
+		"x9112063x",
+		"9112063 9112063",
+		"x95x",
+		"95 95",
+		"x976x",
+		"976 976",
+	} {
+		want := re.MatchString(s)
+		if got := matchCountBoolExpandedBytes([]byte(s)); got != want {
+			t.Errorf("matchCountBoolExpandedBytes(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountBoolExpandedBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:[0-9]{2,12}$)")
+	re.Longest()
+	for _, s := range []string{
+		"008765",
+		"03",
+		"059901",
+		"0953418",
+		"170211570353",
+		"18",
+		"219",
+		"2574",
+		"3598",
+		"36",
+		"558",
+		"6160",
+		"67",
+		"749541",
+		"7496",
+		"834095",
+		"890093",
+		"9112063",
+		"95",
+		"976",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.MatchString(s)
+		if got := matchCountBoolExpandedBytes([]byte(s)); got != want {
+			t.Errorf("matchCountBoolExpandedBytes(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
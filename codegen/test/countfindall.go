@@ -0,0 +1,840 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import "unicode/utf8"
+
+func matchCountFindAll(s string, n int) [][2]int {
+	var matches [][2]int
+	matchCountFindAllFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchCountFindAllFunc(s string, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		start = at
+		_, _, _ = r, rlen, i
+		cnt1 := matchCountFindAll1dfeb981Counter{entered: make([]int, 11)}
+		for {
+			end = -1
+			i = start
+			cnt1.reset()
+			cnt1.enter()
+		s1:
+			r, rlen = utf8.DecodeRuneInString(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r >= 48 && r <= 57:
+				cnt1.increment()
+				switch {
+				case cnt1.n > 0 && cnt1.max() < 4:
+					goto s1
+				case cnt1.max() >= 4:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		s2:
+			r, rlen = utf8.DecodeRuneInString(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r >= 48 && r <= 57:
+				cnt1.increment()
+				switch {
+				case cnt1.n > 0 && cnt1.max() < 4:
+					goto s1
+				case cnt1.max() >= 4:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		done:
+			if end >= 0 {
+				return
+			}
+			_, rlen = utf8.DecodeRuneInString(s[start:])
+			if rlen == 0 {
+				break
+			}
+			start += rlen
+		}
+		return -1, -1
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchCountFindAllBytes(s []byte, n int) [][2]int {
+	var matches [][2]int
+	matchCountFindAllBytesFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchCountFindAllBytesFunc(s []byte, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		start = at
+		_, _, _ = r, rlen, i
+		cnt1 := matchCountFindAll1dfeb981Counter{entered: make([]int, 11)}
+		for {
+			end = -1
+			i = start
+			cnt1.reset()
+			cnt1.enter()
+		s1:
+			r, rlen = utf8.DecodeRune(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r >= 48 && r <= 57:
+				cnt1.increment()
+				switch {
+				case cnt1.n > 0 && cnt1.max() < 4:
+					goto s1
+				case cnt1.max() >= 4:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		s2:
+			r, rlen = utf8.DecodeRune(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r >= 48 && r <= 57:
+				cnt1.increment()
+				switch {
+				case cnt1.n > 0 && cnt1.max() < 4:
+					goto s1
+				case cnt1.max() >= 4:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		done:
+			if end >= 0 {
+				return
+			}
+			_, rlen = utf8.DecodeRune(s[start:])
+			if rlen == 0 {
+				break
+			}
+			start += rlen
+		}
+		return -1, -1
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRune(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchCountFindAllExpanded(s string, n int) [][2]int {
+	var matches [][2]int
+	matchCountFindAllExpandedFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchCountFindAllExpandedFunc(s string, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 47 || r >= 58:
+			goto f1
+		case r >= 48 && r <= 57:
+			goto f2
+		}
+		goto reverse
+	f2:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 47 || r >= 58:
+			goto f1
+		case r >= 48 && r <= 57:
+			goto f3
+		}
+		goto reverse
+	f3:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 47 || r >= 58:
+			goto f1
+		case r >= 48 && r <= 57:
+			goto f4
+		}
+		goto reverse
+	f4:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 47 || r >= 58:
+			goto f1
+		case r >= 48 && r <= 57:
+			end = i
+			goto f5
+		}
+		goto reverse
+	f5:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 48 && r <= 57:
+			end = i
+			goto f6
+		}
+		goto reverse
+	f6:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 48 && r <= 57:
+			end = i
+			goto f7
+		}
+		goto reverse
+	f7:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 48 && r <= 57:
+			end = i
+			goto f8
+		}
+		goto reverse
+	f8:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 48 && r <= 57:
+			end = i
+			goto f9
+		}
+		goto reverse
+	f9:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 48 && r <= 57:
+			end = i
+			goto f10
+		}
+		goto reverse
+	f10:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 48 && r <= 57:
+			end = i
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			goto r2
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			goto r3
+		}
+		return
+	r3:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			goto r4
+		}
+		return
+	r4:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			start = i
+			goto r5
+		}
+		return
+	r5:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			start = i
+			goto r6
+		}
+		return
+	r6:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			start = i
+			goto r7
+		}
+		return
+	r7:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			start = i
+			goto r8
+		}
+		return
+	r8:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			start = i
+			goto r9
+		}
+		return
+	r9:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			start = i
+			goto r10
+		}
+		return
+	r10:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			start = i
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchCountFindAllExpandedBytes(s []byte, n int) [][2]int {
+	var matches [][2]int
+	matchCountFindAllExpandedBytesFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchCountFindAllExpandedBytesFunc(s []byte, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 47 || r >= 58:
+			goto f1
+		case r >= 48 && r <= 57:
+			goto f2
+		}
+		goto reverse
+	f2:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 47 || r >= 58:
+			goto f1
+		case r >= 48 && r <= 57:
+			goto f3
+		}
+		goto reverse
+	f3:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 47 || r >= 58:
+			goto f1
+		case r >= 48 && r <= 57:
+			goto f4
+		}
+		goto reverse
+	f4:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 47 || r >= 58:
+			goto f1
+		case r >= 48 && r <= 57:
+			end = i
+			goto f5
+		}
+		goto reverse
+	f5:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 48 && r <= 57:
+			end = i
+			goto f6
+		}
+		goto reverse
+	f6:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 48 && r <= 57:
+			end = i
+			goto f7
+		}
+		goto reverse
+	f7:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 48 && r <= 57:
+			end = i
+			goto f8
+		}
+		goto reverse
+	f8:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 48 && r <= 57:
+			end = i
+			goto f9
+		}
+		goto reverse
+	f9:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 48 && r <= 57:
+			end = i
+			goto f10
+		}
+		goto reverse
+	f10:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 48 && r <= 57:
+			end = i
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			goto r2
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			goto r3
+		}
+		return
+	r3:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			goto r4
+		}
+		return
+	r4:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			start = i
+			goto r5
+		}
+		return
+	r5:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			start = i
+			goto r6
+		}
+		return
+	r6:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			start = i
+			goto r7
+		}
+		return
+	r7:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			start = i
+			goto r8
+		}
+		return
+	r8:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			start = i
+			goto r9
+		}
+		return
+	r9:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			start = i
+			goto r10
+		}
+		return
+	r10:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57:
+			start = i
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRune(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+// matchCountFindAll1dfeb981Counter holds the numbers of runes counted by the threads in a bounded repetition,
+// as the numbers of runes counted by the register when they entered the repetition, oldest first.
+type matchCountFindAll1dfeb981Counter struct {
+	entered []int // ring buffer of the maximum number of runes + 1 numbers
+	head, n int
+	count   int
+}
+
+func (c *matchCountFindAll1dfeb981Counter) increment() {
+	c.count++
+	if c.n > 0 && c.count-c.entered[c.head] >= len(c.entered) {
+		// The oldest thread has counted too many runes.
+		c.head = (c.head + 1) % len(c.entered)
+		c.n--
+	}
+}
+
+func (c *matchCountFindAll1dfeb981Counter) reset() {
+	c.n = 0
+}
+
+func (c *matchCountFindAll1dfeb981Counter) enter() {
+	if c.n > 0 && c.entered[(c.head+c.n-1)%len(c.entered)] == c.count {
+		return
+	}
+	c.entered[(c.head+c.n)%len(c.entered)] = c.count
+	c.n++
+}
+
+// max returns the number of runes counted by the oldest thread, or -1 if there are no threads.
+func (c *matchCountFindAll1dfeb981Counter) max() int {
+	if c.n == 0 {
+		return -1
+	}
+	return c.count - c.entered[c.head]
+}
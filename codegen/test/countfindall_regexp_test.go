@@ -0,0 +1,541 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatchCountFindAllAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("[0-9]{4,10}")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"03",
+		"03087292",
+		"0356526220595950419779933",
+		"1",
+		"164862432091",
+		"1736968755535",
+		"182",
+		"3",
+		"4",
+		"484",
+		"5",
+		"55768",
+		"722092",
+		"73404",
+		"7766733083387564017346880374",
+		"82538284",
+		"83999195789963",
+		"86983",
+		"9379297423962753",
+		"9759837",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"+",
+		"0356526",
+		"0t",
+		"16",
+		"16486242091",
+		"2",
+		"734",
+		"7340",
+		"7340\\",
+		"86",
+		"9379",
+		"937929742396275",
+		"97I9837",
+		"~3404",
+		"é",
+		"日本",
+		"\xff",
+		"x03x",
+		"03 03",
+		"x03087292x",
+		"03087292 03087292",
+		"x0356526220595950419779933x",
+		"0356526220595950419779933 0356526220595950419779933",
+		"x1x",
+		"1 1",
+		"x164862432091x",
+		"164862432091 164862432091",
+		"x1736968755535x",
+		"1736968755535 1736968755535",
+		"x182x",
+		"182 182",
+		"x3x",
+		"3 3",
+		"x4x",
+		"4 4",
+		"x484x",
+		"484 484",
+		"x5x",
+		"5 5",
+		"x55768x",
+		"55768 55768",
+		"x722092x",
+		"722092 722092",
+		"x73404x",
+		"73404 73404",
+		"x7766733083387564017346880374x",
+		"7766733083387564017346880374 7766733083387564017346880374",
+		"x82538284x",
+		"82538284 82538284",
+		"x83999195789963x",
+		"83999195789963 83999195789963",
+		"x86983x",
+		"86983 86983",
+		"x9379297423962753x",
+		"9379297423962753 9379297423962753",
+		"x9759837x",
+		"9759837 9759837",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchCountFindAll(s, n)); got != want {
+				t.Errorf("matchCountFindAll(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchCountFindAll(f *testing.F) {
+	re := regexp.MustCompile("[0-9]{4,10}")
+	re.Longest()
+	for _, s := range []string{
+		"03",
+		"03087292",
+		"0356526220595950419779933",
+		"1",
+		"164862432091",
+		"1736968755535",
+		"182",
+		"3",
+		"4",
+		"484",
+		"5",
+		"55768",
+		"722092",
+		"73404",
+		"7766733083387564017346880374",
+		"82538284",
+		"83999195789963",
+		"86983",
+		"9379297423962753",
+		"9759837",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchCountFindAll(s, n)); got != want {
+				t.Errorf("matchCountFindAll(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchCountFindAllBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("[0-9]{4,10}")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"03",
+		"03087292",
+		"0356526220595950419779933",
+		"1",
+		"164862432091",
+		"1736968755535",
+		"182",
+		"3",
+		"4",
+		"484",
+		"5",
+		"55768",
+		"722092",
+		"73404",
+		"7766733083387564017346880374",
+		"82538284",
+		"83999195789963",
+		"86983",
+		"9379297423962753",
+		"9759837",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"+",
+		"0356526",
+		"0t",
+		"16",
+		"16486242091",
+		"2",
+		"734",
+		"7340",
+		"7340\\",
+		"86",
+		"9379",
+		"937929742396275",
+		"97I9837",
+		"~3404",
+		"é",
+		"日本",
+		"\xff",
+		"x03x",
+		"03 03",
+		"x03087292x",
+		"03087292 03087292",
+		"x0356526220595950419779933x",
+		"0356526220595950419779933 0356526220595950419779933",
+		"x1x",
+		"1 1",
+		"x164862432091x",
+		"164862432091 164862432091",
+		"x1736968755535x",
+		"1736968755535 1736968755535",
+		"x182x",
+		"182 182",
+		"x3x",
+		"3 3",
+		"x4x",
+		"4 4",
+		"x484x",
+		"484 484",
+		"x5x",
+		"5 5",
+		"x55768x",
+		"55768 55768",
+		"x722092x",
+		"722092 722092",
+		"x73404x",
+		"73404 73404",
+		"x7766733083387564017346880374x",
+		"7766733083387564017346880374 7766733083387564017346880374",
+		"x82538284x",
+		"82538284 82538284",
+		"x83999195789963x",
+		"83999195789963 83999195789963",
+		"x86983x",
+		"86983 86983",
+		"x9379297423962753x",
+		"9379297423962753 9379297423962753",
+		"x9759837x",
+		"9759837 9759837",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchCountFindAllBytes([]byte(s), n)); got != want {
+				t.Errorf("matchCountFindAllBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchCountFindAllBytes(f *testing.F) {
+	re := regexp.MustCompile("[0-9]{4,10}")
+	re.Longest()
+	for _, s := range []string{
+		"03",
+		"03087292",
+		"0356526220595950419779933",
+		"1",
+		"164862432091",
+		"1736968755535",
+		"182",
+		"3",
+		"4",
+		"484",
+		"5",
+		"55768",
+		"722092",
+		"73404",
+		"7766733083387564017346880374",
+		"82538284",
+		"83999195789963",
+		"86983",
+		"9379297423962753",
+		"9759837",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchCountFindAllBytes([]byte(s), n)); got != want {
+				t.Errorf("matchCountFindAllBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchCountFindAllExpandedAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("[0-9]{4,10}")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"0578107",
+		"0953",
+		"09594405",
+		"1325689",
+		"1757242755",
+		"1873",
+		"3753",
+		"38837",
+		"45802",
+		"5008669",
+		"5409",
+		"588284524",
+		"593599",
+		"7146",
+		"7281",
+		"749981167",
+		"8157",
+		"90045",
+		"91120",
+		"97080",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"095",
+		"0953K",
+		"09594",
+		"1120",
+		"132R689",
+		"146",
+		"1]73",
+		"5935",
+		"593599T",
+		"593599t",
+		"749o81167",
+		"817",
+		"91",
+		"97K80",
+		"é",
+		"日本",
+		"\xff",
+		"x0578107x",
+		"0578107 0578107",
+		"x0953x",
+		"0953 0953",
+		"x09594405x",
+		"09594405 09594405",
+		"x1325689x",
+		"1325689 1325689",
+		"x1757242755x",
+		"1757242755 1757242755",
+		"x1873x",
+		"1873 1873",
+		"x3753x",
+		"3753 3753",
+		"x38837x",
+		"38837 38837",
+		"x45802x",
+		"45802 45802",
+		"x5008669x",
+		"5008669 5008669",
+		"x5409x",
+		"5409 5409",
+		"x588284524x",
+		"588284524 588284524",
+		"x593599x",
+		"593599 593599",
+		"x7146x",
+		"7146 7146",
+		"x7281x",
+		"7281 7281",
+		"x749981167x",
+		"749981167 749981167",
+		"x8157x",
+		"8157 8157",
+		"x90045x",
+		"90045 90045",
+		"x91120x",
+		"91120 91120",
+		"x97080x",
+		"97080 97080",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchCountFindAllExpanded(s, n)); got != want {
+				t.Errorf("matchCountFindAllExpanded(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchCountFindAllExpanded(f *testing.F) {
+	re := regexp.MustCompile("[0-9]{4,10}")
+
+	for _, s := range []string{
+		"0578107",
+		"0953",
+		"09594405",
+		"1325689",
+		"1757242755",
+		"1873",
+		"3753",
+		"38837",
+		"45802",
+		"5008669",
+		"5409",
+		"588284524",
+		"593599",
+		"7146",
+		"7281",
+		"749981167",
+		"8157",
+		"90045",
+		"91120",
+		"97080",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchCountFindAllExpanded(s, n)); got != want {
+				t.Errorf("matchCountFindAllExpanded(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchCountFindAllExpandedBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("[0-9]{4,10}")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"0578107",
+		"0953",
+		"09594405",
+		"1325689",
+		"1757242755",
+		"1873",
+		"3753",
+		"38837",
+		"45802",
+		"5008669",
+		"5409",
+		"588284524",
+		"593599",
+		"7146",
+		"7281",
+		"749981167",
+		"8157",
+		"90045",
+		"91120",
+		"97080",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"095",
+		"0953K",
+		"09594",
+		"1120",
+		"132R689",
+		"146",
+		"1]73",
+		"5935",
+		"593599T",
+		"593599t",
+		"749o81167",
+		"817",
+		"91",
+		"97K80",
+		"é",
+		"日本",
+		"\xff",
+		"x0578107x",
+		"0578107 0578107",
+		"x0953x",
+		"0953 0953",
+		"x09594405x",
+		"09594405 09594405",
+		"x1325689x",
+		"1325689 1325689",
+		"x1757242755x",
+		"1757242755 1757242755",
+		"x1873x",
+		"1873 1873",
+		"x3753x",
+		"3753 3753",
+		"x38837x",
+		"38837 38837",
+		"x45802x",
+		"45802 45802",
+		"x5008669x",
+		"5008669 5008669",
+		"x5409x",
+		"5409 5409",
+		"x588284524x",
+		"588284524 588284524",
+		"x593599x",
+		"593599 593599",
+		"x7146x",
+		"7146 7146",
+		"x7281x",
+		"7281 7281",
+		"x749981167x",
+		"749981167 749981167",
+		"x8157x",
+		"8157 8157",
+		"x90045x",
+		"90045 90045",
+		"x91120x",
+		"91120 91120",
+		"x97080x",
+		"97080 97080",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchCountFindAllExpandedBytes([]byte(s), n)); got != want {
+				t.Errorf("matchCountFindAllExpandedBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchCountFindAllExpandedBytes(f *testing.F) {
+	re := regexp.MustCompile("[0-9]{4,10}")
+
+	for _, s := range []string{
+		"0578107",
+		"0953",
+		"09594405",
+		"1325689",
+		"1757242755",
+		"1873",
+		"3753",
+		"38837",
+		"45802",
+		"5008669",
+		"5409",
+		"588284524",
+		"593599",
+		"7146",
+		"7281",
+		"749981167",
+		"8157",
+		"90045",
+		"91120",
+		"97080",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchCountFindAllExpandedBytes([]byte(s), n)); got != want {
+				t.Errorf("matchCountFindAllExpandedBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,700 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import "unicode/utf8"
+
+func matchCountFindAllEmpty(s string, n int) [][2]int {
+	var matches [][2]int
+	matchCountFindAllEmptyFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchCountFindAllEmptyFunc(s string, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		start = at
+		_, _, _ = r, rlen, i
+		cnt1 := matchCountFindAllEmpty6078b80aCounter{entered: make([]int, 6)}
+		for {
+			end = start
+			i = start
+			cnt1.reset()
+			cnt1.enter()
+			r, rlen = utf8.DecodeRuneInString(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 98:
+				cnt1.reset()
+				cnt1.enter()
+				end = i
+				goto s3
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		s2:
+			r, rlen = utf8.DecodeRuneInString(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		s3:
+			r, rlen = utf8.DecodeRuneInString(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		done:
+			if end >= 0 {
+				return
+			}
+			_, rlen = utf8.DecodeRuneInString(s[start:])
+			if rlen == 0 {
+				break
+			}
+			start += rlen
+		}
+		return -1, -1
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchCountFindAllEmptyBytes(s []byte, n int) [][2]int {
+	var matches [][2]int
+	matchCountFindAllEmptyBytesFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchCountFindAllEmptyBytesFunc(s []byte, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		start = at
+		_, _, _ = r, rlen, i
+		cnt1 := matchCountFindAllEmpty6078b80aCounter{entered: make([]int, 6)}
+		for {
+			end = start
+			i = start
+			cnt1.reset()
+			cnt1.enter()
+			r, rlen = utf8.DecodeRune(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 98:
+				cnt1.reset()
+				cnt1.enter()
+				end = i
+				goto s3
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		s2:
+			r, rlen = utf8.DecodeRune(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		s3:
+			r, rlen = utf8.DecodeRune(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		done:
+			if end >= 0 {
+				return
+			}
+			_, rlen = utf8.DecodeRune(s[start:])
+			if rlen == 0 {
+				break
+			}
+			start += rlen
+		}
+		return -1, -1
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRune(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchCountFindAllEmptyExpanded(s string, n int) [][2]int {
+	var matches [][2]int
+	matchCountFindAllEmptyExpandedFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchCountFindAllEmptyExpandedFunc(s string, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = i
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f2
+		case r == 98:
+			end = i
+			goto f3
+		}
+		goto reverse
+	f2:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f4
+		}
+		goto reverse
+	f3:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f2
+		}
+		goto reverse
+	f4:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f5
+		}
+		goto reverse
+	f5:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f6
+		}
+		goto reverse
+	f6:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = end
+		i = end
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r2
+		case r == 98:
+			start = i
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r4
+		case r == 98:
+			start = i
+		}
+		return
+	r4:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r5
+		case r == 98:
+			start = i
+		}
+		return
+	r5:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r6
+		case r == 98:
+			start = i
+		}
+		return
+	r6:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r7
+		case r == 98:
+			start = i
+		}
+		return
+	r7:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 98:
+			start = i
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchCountFindAllEmptyExpandedBytes(s []byte, n int) [][2]int {
+	var matches [][2]int
+	matchCountFindAllEmptyExpandedBytesFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchCountFindAllEmptyExpandedBytesFunc(s []byte, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = i
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f2
+		case r == 98:
+			end = i
+			goto f3
+		}
+		goto reverse
+	f2:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f4
+		}
+		goto reverse
+	f3:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f2
+		}
+		goto reverse
+	f4:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f5
+		}
+		goto reverse
+	f5:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f6
+		}
+		goto reverse
+	f6:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = end
+		i = end
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r2
+		case r == 98:
+			start = i
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r4
+		case r == 98:
+			start = i
+		}
+		return
+	r4:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r5
+		case r == 98:
+			start = i
+		}
+		return
+	r5:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r6
+		case r == 98:
+			start = i
+		}
+		return
+	r6:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r7
+		case r == 98:
+			start = i
+		}
+		return
+	r7:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 98:
+			start = i
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRune(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+// matchCountFindAllEmpty6078b80aCounter holds the numbers of runes counted by the threads in a bounded repetition,
+// as the numbers of runes counted by the register when they entered the repetition, oldest first.
+type matchCountFindAllEmpty6078b80aCounter struct {
+	entered []int // ring buffer of the maximum number of runes + 1 numbers
+	head, n int
+	count   int
+}
+
+func (c *matchCountFindAllEmpty6078b80aCounter) increment() {
+	c.count++
+	if c.n > 0 && c.count-c.entered[c.head] >= len(c.entered) {
+		// The oldest thread has counted too many runes.
+		c.head = (c.head + 1) % len(c.entered)
+		c.n--
+	}
+}
+
+func (c *matchCountFindAllEmpty6078b80aCounter) reset() {
+	c.n = 0
+}
+
+func (c *matchCountFindAllEmpty6078b80aCounter) enter() {
+	if c.n > 0 && c.entered[(c.head+c.n-1)%len(c.entered)] == c.count {
+		return
+	}
+	c.entered[(c.head+c.n)%len(c.entered)] = c.count
+	c.n++
+}
+
+// max returns the number of runes counted by the oldest thread, or -1 if there are no threads.
+func (c *matchCountFindAllEmpty6078b80aCounter) max() int {
+	if c.n == 0 {
+		return -1
+	}
+	return c.count - c.entered[c.head]
+}
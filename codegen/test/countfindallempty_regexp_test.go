@@ -0,0 +1,445 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatchCountFindAllEmptyAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("b?a{0,5}")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"b",
+		"ba",
+		"baa",
+		"baaa",
+		"baaaaa",
+		"baaaaaa",
+		"baaaaaaaaaaa",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"'",
+		",aa",
+		"4",
+		"_",
+		"aaDa",
+		"aaaEaaaa",
+		"aaaaaaaI",
+		"aaaf",
+		"aaah",
+		"ba6",
+		"baaK",
+		"baaa=a",
+		"baaaaa:",
+		"baaaaaa!",
+		"bas",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xaaaaaax",
+		"aaaaaa aaaaaa",
+		"xaaaaaaax",
+		"aaaaaaa aaaaaaa",
+		"xaaaaaaaax",
+		"aaaaaaaa aaaaaaaa",
+		"xbx",
+		"b b",
+		"xbax",
+		"ba ba",
+		"xbaax",
+		"baa baa",
+		"xbaaax",
+		"baaa baaa",
+		"xbaaaaax",
+		"baaaaa baaaaa",
+		"xbaaaaaax",
+		"baaaaaa baaaaaa",
+		"xbaaaaaaaaaaax",
+		"baaaaaaaaaaa baaaaaaaaaaa",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchCountFindAllEmpty(s, n)); got != want {
+				t.Errorf("matchCountFindAllEmpty(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchCountFindAllEmpty(f *testing.F) {
+	re := regexp.MustCompile("b?a{0,5}")
+	re.Longest()
+	for _, s := range []string{
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"b",
+		"ba",
+		"baa",
+		"baaa",
+		"baaaaa",
+		"baaaaaa",
+		"baaaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchCountFindAllEmpty(s, n)); got != want {
+				t.Errorf("matchCountFindAllEmpty(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchCountFindAllEmptyBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("b?a{0,5}")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"b",
+		"ba",
+		"baa",
+		"baaa",
+		"baaaaa",
+		"baaaaaa",
+		"baaaaaaaaaaa",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"'",
+		",aa",
+		"4",
+		"_",
+		"aaDa",
+		"aaaEaaaa",
+		"aaaaaaaI",
+		"aaaf",
+		"aaah",
+		"ba6",
+		"baaK",
+		"baaa=a",
+		"baaaaa:",
+		"baaaaaa!",
+		"bas",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xaaaaaax",
+		"aaaaaa aaaaaa",
+		"xaaaaaaax",
+		"aaaaaaa aaaaaaa",
+		"xaaaaaaaax",
+		"aaaaaaaa aaaaaaaa",
+		"xbx",
+		"b b",
+		"xbax",
+		"ba ba",
+		"xbaax",
+		"baa baa",
+		"xbaaax",
+		"baaa baaa",
+		"xbaaaaax",
+		"baaaaa baaaaa",
+		"xbaaaaaax",
+		"baaaaaa baaaaaa",
+		"xbaaaaaaaaaaax",
+		"baaaaaaaaaaa baaaaaaaaaaa",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchCountFindAllEmptyBytes([]byte(s), n)); got != want {
+				t.Errorf("matchCountFindAllEmptyBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchCountFindAllEmptyBytes(f *testing.F) {
+	re := regexp.MustCompile("b?a{0,5}")
+	re.Longest()
+	for _, s := range []string{
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"b",
+		"ba",
+		"baa",
+		"baaa",
+		"baaaaa",
+		"baaaaaa",
+		"baaaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchCountFindAllEmptyBytes([]byte(s), n)); got != want {
+				t.Errorf("matchCountFindAllEmptyBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchCountFindAllEmptyExpandedAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("b?a{0,5}")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"b",
+		"ba",
+		"baa",
+		"baaa",
+		"baaaa",
+		"baaaaa",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"4",
+		"Yaa",
+		"_aaaa",
+		"a$a",
+		"aaaa3",
+		"aaaaF",
+		"b4aaa",
+		"ba.",
+		"baa)",
+		"baa@aa",
+		"baaaa3",
+		"f",
+		"h",
+		"za",
+		"~",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xbx",
+		"b b",
+		"xbax",
+		"ba ba",
+		"xbaax",
+		"baa baa",
+		"xbaaax",
+		"baaa baaa",
+		"xbaaaax",
+		"baaaa baaaa",
+		"xbaaaaax",
+		"baaaaa baaaaa",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchCountFindAllEmptyExpanded(s, n)); got != want {
+				t.Errorf("matchCountFindAllEmptyExpanded(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchCountFindAllEmptyExpanded(f *testing.F) {
+	re := regexp.MustCompile("b?a{0,5}")
+
+	for _, s := range []string{
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"b",
+		"ba",
+		"baa",
+		"baaa",
+		"baaaa",
+		"baaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchCountFindAllEmptyExpanded(s, n)); got != want {
+				t.Errorf("matchCountFindAllEmptyExpanded(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchCountFindAllEmptyExpandedBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("b?a{0,5}")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"b",
+		"ba",
+		"baa",
+		"baaa",
+		"baaaa",
+		"baaaaa",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"4",
+		"Yaa",
+		"_aaaa",
+		"a$a",
+		"aaaa3",
+		"aaaaF",
+		"b4aaa",
+		"ba.",
+		"baa)",
+		"baa@aa",
+		"baaaa3",
+		"f",
+		"h",
+		"za",
+		"~",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xbx",
+		"b b",
+		"xbax",
+		"ba ba",
+		"xbaax",
+		"baa baa",
+		"xbaaax",
+		"baaa baaa",
+		"xbaaaax",
+		"baaaa baaaa",
+		"xbaaaaax",
+		"baaaaa baaaaa",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchCountFindAllEmptyExpandedBytes([]byte(s), n)); got != want {
+				t.Errorf("matchCountFindAllEmptyExpandedBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchCountFindAllEmptyExpandedBytes(f *testing.F) {
+	re := regexp.MustCompile("b?a{0,5}")
+
+	for _, s := range []string{
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"b",
+		"ba",
+		"baa",
+		"baaa",
+		"baaaa",
+		"baaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchCountFindAllEmptyExpandedBytes([]byte(s), n)); got != want {
+				t.Errorf("matchCountFindAllEmptyExpandedBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
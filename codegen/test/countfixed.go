@@ -0,0 +1,502 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import "unicode/utf8"
+
+func matchCountFixed(s string) (end int) {
+	end = -1
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	cnt1 := matchCountFixed2053f219Counter{entered: make([]int, 11)}
+	cnt1.enter()
+s1:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 10:
+			goto s1
+		case cnt1.max() >= 10:
+			goto s2
+		}
+	}
+	return
+s2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 119 || r >= 121:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 10:
+			goto s1
+		case cnt1.max() >= 10:
+			goto s2
+		}
+	case r == 120:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 10:
+			end = i
+			goto s4
+		case cnt1.max() >= 10:
+			end = i
+			goto s5
+		case cnt1.n == 0:
+			end = i
+		}
+	}
+	return
+s4:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 10:
+			goto s1
+		case cnt1.max() >= 10:
+			goto s2
+		}
+	}
+	return
+s5:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 119 || r >= 121:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 10:
+			goto s1
+		case cnt1.max() >= 10:
+			goto s2
+		}
+	case r == 120:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 10:
+			end = i
+			goto s4
+		case cnt1.max() >= 10:
+			end = i
+			goto s5
+		case cnt1.n == 0:
+			end = i
+		}
+	}
+	return
+}
+
+func matchCountFixedBytes(s []byte) (end int) {
+	end = -1
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	cnt1 := matchCountFixed2053f219Counter{entered: make([]int, 11)}
+	cnt1.enter()
+s1:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 10:
+			goto s1
+		case cnt1.max() >= 10:
+			goto s2
+		}
+	}
+	return
+s2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 119 || r >= 121:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 10:
+			goto s1
+		case cnt1.max() >= 10:
+			goto s2
+		}
+	case r == 120:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 10:
+			end = i
+			goto s4
+		case cnt1.max() >= 10:
+			end = i
+			goto s5
+		case cnt1.n == 0:
+			end = i
+		}
+	}
+	return
+s4:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 10:
+			goto s1
+		case cnt1.max() >= 10:
+			goto s2
+		}
+	}
+	return
+s5:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 119 || r >= 121:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 10:
+			goto s1
+		case cnt1.max() >= 10:
+			goto s2
+		}
+	case r == 120:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 10:
+			end = i
+			goto s4
+		case cnt1.max() >= 10:
+			end = i
+			goto s5
+		case cnt1.n == 0:
+			end = i
+		}
+	}
+	return
+}
+
+func matchCountFixedExpanded(s string) (end int) {
+	end = -1
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s2
+	}
+	return
+s2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s3
+	}
+	return
+s3:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s4
+	}
+	return
+s4:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s5
+	}
+	return
+s5:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s6
+	}
+	return
+s6:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s7
+	}
+	return
+s7:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s8
+	}
+	return
+s8:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s9
+	}
+	return
+s9:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s10
+	}
+	return
+s10:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s11
+	}
+	return
+s11:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r == 120:
+		end = i
+	}
+	return
+}
+
+func matchCountFixedExpandedBytes(s []byte) (end int) {
+	end = -1
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s2
+	}
+	return
+s2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s3
+	}
+	return
+s3:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s4
+	}
+	return
+s4:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s5
+	}
+	return
+s5:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s6
+	}
+	return
+s6:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s7
+	}
+	return
+s7:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s8
+	}
+	return
+s8:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s9
+	}
+	return
+s9:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s10
+	}
+	return
+s10:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11:
+		goto s11
+	}
+	return
+s11:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r == 120:
+		end = i
+	}
+	return
+}
+
+// matchCountFixed2053f219Counter holds the numbers of runes counted by the threads in a bounded repetition,
+// as the numbers of runes counted by the register when they entered the repetition, oldest first.
+type matchCountFixed2053f219Counter struct {
+	entered []int // ring buffer of the maximum number of runes + 1 numbers
+	head, n int
+	count   int
+}
+
+func (c *matchCountFixed2053f219Counter) increment() {
+	c.count++
+	if c.n > 0 && c.count-c.entered[c.head] >= len(c.entered) {
+		// The oldest thread has counted too many runes.
+		c.head = (c.head + 1) % len(c.entered)
+		c.n--
+	}
+}
+
+func (c *matchCountFixed2053f219Counter) reset() {
+	c.n = 0
+}
+
+func (c *matchCountFixed2053f219Counter) enter() {
+	if c.n > 0 && c.entered[(c.head+c.n-1)%len(c.entered)] == c.count {
+		return
+	}
+	c.entered[(c.head+c.n)%len(c.entered)] = c.count
+	c.n++
+}
+
+// max returns the number of runes counted by the oldest thread, or -1 if there are no threads.
+func (c *matchCountFixed2053f219Counter) max() int {
+	if c.n == 0 {
+		return -1
+	}
+	return c.count - c.entered[c.head]
+}
@@ -0,0 +1,388 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchCountFixedAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:.{10}x)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"\x03mG\x02=\"\U000bb595Yd\tx",
+		"\x05A\bNOUt*3]b{^x\x03x",
+		"\x05IH\U000c9d3fI,~xQ\x05x",
+		"\txF.J@\U0005726d;Y\ttx",
+		"*`x",
+		"-\x04\x03Vx",
+		".~N\U0010f8e3x",
+		"7@x",
+		"7M\U0007369d@\U000dc753{DA)x",
+		"8*x=;MC^x",
+		"9x",
+		"=|B@yvG4\U000b736exF3{x",
+		"@~=8Vxd\U00040b9e|\U00019017x",
+		"g{\U00081bfbx",
+		"n~x.j=x>K2x",
+		"uJ\x05M}x`x!x",
+		"~xX=hA\x04xx}x|(\x03[xOD𱛢{\U0008081a\ax",
+		"\U0002fe1ex",
+		"\U000543b1x\x05\x05x",
+		"\U0009e4d7cfd\bx",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\x03mG\x02=\"\U000bb595Yd",
+		"\x05A\bNOUt*3]bt^x\x03x",
+		"\x05IH\U000c9d3fI,~xQ\x05xQ",
+		"\txF.J@\U0005726d;\ttx",
+		"\n",
+		"*Ix",
+		".~N\U0010f8e3",
+		"72x",
+		"7@+",
+		"7@xn",
+		"8*x=gMC^x",
+		"g{\U00081bfb",
+		"uJ\x05M}~`x!x",
+		"|xX=hA\x04xx}x|(\x03[xOD𱛢{\U0008081a\ax",
+		"é",
+		"日本",
+		"\U0009e4d7cfd\b'",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountFixed(s); got != want {
+			t.Errorf("matchCountFixed(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountFixed(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:.{10}x)")
+	re.Longest()
+	for _, s := range []string{
+		"\x03mG\x02=\"\U000bb595Yd\tx",
+		"\x05A\bNOUt*3]b{^x\x03x",
+		"\x05IH\U000c9d3fI,~xQ\x05x",
+		"\txF.J@\U0005726d;Y\ttx",
+		"*`x",
+		"-\x04\x03Vx",
+		".~N\U0010f8e3x",
+		"7@x",
+		"7M\U0007369d@\U000dc753{DA)x",
+		"8*x=;MC^x",
+		"9x",
+		"=|B@yvG4\U000b736exF3{x",
+		"@~=8Vxd\U00040b9e|\U00019017x",
+		"g{\U00081bfbx",
+		"n~x.j=x>K2x",
+		"uJ\x05M}x`x!x",
+		"~xX=hA\x04xx}x|(\x03[xOD𱛢{\U0008081a\ax",
+		"\U0002fe1ex",
+		"\U000543b1x\x05\x05x",
+		"\U0009e4d7cfd\bx",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountFixed(s); got != want {
+			t.Errorf("matchCountFixed(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountFixedBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:.{10}x)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"\x03mG\x02=\"\U000bb595Yd\tx",
+		"\x05A\bNOUt*3]b{^x\x03x",
+		"\x05IH\U000c9d3fI,~xQ\x05x",
+		"\txF.J@\U0005726d;Y\ttx",
+		"*`x",
+		"-\x04\x03Vx",
+		".~N\U0010f8e3x",
+		"7@x",
+		"7M\U0007369d@\U000dc753{DA)x",
+		"8*x=;MC^x",
+		"9x",
+		"=|B@yvG4\U000b736exF3{x",
+		"@~=8Vxd\U00040b9e|\U00019017x",
+		"g{\U00081bfbx",
+		"n~x.j=x>K2x",
+		"uJ\x05M}x`x!x",
+		"~xX=hA\x04xx}x|(\x03[xOD𱛢{\U0008081a\ax",
+		"\U0002fe1ex",
+		"\U000543b1x\x05\x05x",
+		"\U0009e4d7cfd\bx",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\x03mG\x02=\"\U000bb595Yd",
+		"\x05A\bNOUt*3]bt^x\x03x",
+		"\x05IH\U000c9d3fI,~xQ\x05xQ",
+		"\txF.J@\U0005726d;\ttx",
+		"\n",
+		"*Ix",
+		".~N\U0010f8e3",
+		"72x",
+		"7@+",
+		"7@xn",
+		"8*x=gMC^x",
+		"g{\U00081bfb",
+		"uJ\x05M}~`x!x",
+		"|xX=hA\x04xx}x|(\x03[xOD𱛢{\U0008081a\ax",
+		"é",
+		"日本",
+		"\U0009e4d7cfd\b'",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountFixedBytes([]byte(s)); got != want {
+			t.Errorf("matchCountFixedBytes(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountFixedBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:.{10}x)")
+	re.Longest()
+	for _, s := range []string{
+		"\x03mG\x02=\"\U000bb595Yd\tx",
+		"\x05A\bNOUt*3]b{^x\x03x",
+		"\x05IH\U000c9d3fI,~xQ\x05x",
+		"\txF.J@\U0005726d;Y\ttx",
+		"*`x",
+		"-\x04\x03Vx",
+		".~N\U0010f8e3x",
+		"7@x",
+		"7M\U0007369d@\U000dc753{DA)x",
+		"8*x=;MC^x",
+		"9x",
+		"=|B@yvG4\U000b736exF3{x",
+		"@~=8Vxd\U00040b9e|\U00019017x",
+		"g{\U00081bfbx",
+		"n~x.j=x>K2x",
+		"uJ\x05M}x`x!x",
+		"~xX=hA\x04xx}x|(\x03[xOD𱛢{\U0008081a\ax",
+		"\U0002fe1ex",
+		"\U000543b1x\x05\x05x",
+		"\U0009e4d7cfd\bx",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountFixedBytes([]byte(s)); got != want {
+			t.Errorf("matchCountFixedBytes(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountFixedExpandedAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:.{10}x)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"\x01𱐅bI=T']J`x",
+		"\x03d\a\x00l}//[Ux",
+		"\x05YH|%\x03nR@\U00040b9ex",
+		"\x06h\x04[`U0ciSx",
+		"\a3wW\a8𫜪\x02(|x",
+		"\bzZ93mT~QXx",
+		"\t\U0009ccdf\ue93a{\x01wm=;Mx",
+		".O9\x00k\U000c56c6\U00015229\x05\x00 x",
+		"0\x00oo*a\U000a9ff7*\x03\"x",
+		"K%\\b1U\x04\U0001645aZix",
+		"MT\U0010f615nQ=hA\x04Ix",
+		"`VluJ\x05M>#bx",
+		"a\b\t&ep\x05\x01\\\U000db89cx",
+		"cJk/\x01i\x01jJyx",
+		"gs\aAXE*UL4x",
+		"l𗰤Hm#𥍘zoe x",
+		"u4NdO'rq4*x",
+		"vG4\U000b736e\x03;\U00098c3bJG)x",
+		"w<>K2,7n\v,x",
+		"zX\x04I.@TZx\U000925bax",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\x01𱐅bI",
+		"\x01𱐅bI#T']J`x",
+		"\x01𱐅bI=T']",
+		"\x01𱐅bI=T']J`x/",
+		"\a",
+		"\a3wW\a8𫜪\x02(x",
+		"\n",
+		"K%\\b1U\x04\U0001645aZ",
+		"a\b\t&ep\x05\x01\\\U000db89cx ",
+		"a\b\t&ep\x05\x01\\\U000db89cxb",
+		"cJk/\x01i\x01jJyx;",
+		"vG4A\x03;\U00098c3bJG)x",
+		"vG4\U000b736eB;\U00098c3bJG)x",
+		"w<>K2,7n\v,",
+		"zX\x04I.sTZx\U000925bax",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountFixedExpanded(s); got != want {
+			t.Errorf("matchCountFixedExpanded(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountFixedExpanded(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:.{10}x)")
+	re.Longest()
+	for _, s := range []string{
+		"\x01𱐅bI=T']J`x",
+		"\x03d\a\x00l}//[Ux",
+		"\x05YH|%\x03nR@\U00040b9ex",
+		"\x06h\x04[`U0ciSx",
+		"\a3wW\a8𫜪\x02(|x",
+		"\bzZ93mT~QXx",
+		"\t\U0009ccdf\ue93a{\x01wm=;Mx",
+		".O9\x00k\U000c56c6\U00015229\x05\x00 x",
+		"0\x00oo*a\U000a9ff7*\x03\"x",
+		"K%\\b1U\x04\U0001645aZix",
+		"MT\U0010f615nQ=hA\x04Ix",
+		"`VluJ\x05M>#bx",
+		"a\b\t&ep\x05\x01\\\U000db89cx",
+		"cJk/\x01i\x01jJyx",
+		"gs\aAXE*UL4x",
+		"l𗰤Hm#𥍘zoe x",
+		"u4NdO'rq4*x",
+		"vG4\U000b736e\x03;\U00098c3bJG)x",
+		"w<>K2,7n\v,x",
+		"zX\x04I.@TZx\U000925bax",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountFixedExpanded(s); got != want {
+			t.Errorf("matchCountFixedExpanded(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountFixedExpandedBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:.{10}x)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"\x01𱐅bI=T']J`x",
+		"\x03d\a\x00l}//[Ux",
+		"\x05YH|%\x03nR@\U00040b9ex",
+		"\x06h\x04[`U0ciSx",
+		"\a3wW\a8𫜪\x02(|x",
+		"\bzZ93mT~QXx",
+		"\t\U0009ccdf\ue93a{\x01wm=;Mx",
+		".O9\x00k\U000c56c6\U00015229\x05\x00 x",
+		"0\x00oo*a\U000a9ff7*\x03\"x",
+		"K%\\b1U\x04\U0001645aZix",
+		"MT\U0010f615nQ=hA\x04Ix",
+		"`VluJ\x05M>#bx",
+		"a\b\t&ep\x05\x01\\\U000db89cx",
+		"cJk/\x01i\x01jJyx",
+		"gs\aAXE*UL4x",
+		"l𗰤Hm#𥍘zoe x",
+		"u4NdO'rq4*x",
+		"vG4\U000b736e\x03;\U00098c3bJG)x",
+		"w<>K2,7n\v,x",
+		"zX\x04I.@TZx\U000925bax",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\x01𱐅bI",
+		"\x01𱐅bI#T']J`x",
+		"\x01𱐅bI=T']",
+		"\x01𱐅bI=T']J`x/",
+		"\a",
+		"\a3wW\a8𫜪\x02(x",
+		"\n",
+		"K%\\b1U\x04\U0001645aZ",
+		"a\b\t&ep\x05\x01\\\U000db89cx ",
+		"a\b\t&ep\x05\x01\\\U000db89cxb",
+		"cJk/\x01i\x01jJyx;",
+		"vG4A\x03;\U00098c3bJG)x",
+		"vG4\U000b736eB;\U00098c3bJG)x",
+		"w<>K2,7n\v,",
+		"zX\x04I.sTZx\U000925bax",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountFixedExpandedBytes([]byte(s)); got != want {
+			t.Errorf("matchCountFixedExpandedBytes(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountFixedExpandedBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:.{10}x)")
+	re.Longest()
+	for _, s := range []string{
+		"\x01𱐅bI=T']J`x",
+		"\x03d\a\x00l}//[Ux",
+		"\x05YH|%\x03nR@\U00040b9ex",
+		"\x06h\x04[`U0ciSx",
+		"\a3wW\a8𫜪\x02(|x",
+		"\bzZ93mT~QXx",
+		"\t\U0009ccdf\ue93a{\x01wm=;Mx",
+		".O9\x00k\U000c56c6\U00015229\x05\x00 x",
+		"0\x00oo*a\U000a9ff7*\x03\"x",
+		"K%\\b1U\x04\U0001645aZix",
+		"MT\U0010f615nQ=hA\x04Ix",
+		"`VluJ\x05M>#bx",
+		"a\b\t&ep\x05\x01\\\U000db89cx",
+		"cJk/\x01i\x01jJyx",
+		"gs\aAXE*UL4x",
+		"l𗰤Hm#𥍘zoe x",
+		"u4NdO'rq4*x",
+		"vG4\U000b736e\x03;\U00098c3bJG)x",
+		"w<>K2,7n\v,x",
+		"zX\x04I.@TZx\U000925bax",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountFixedExpandedBytes([]byte(s)); got != want {
+			t.Errorf("matchCountFixedExpandedBytes(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
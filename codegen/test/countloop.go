@@ -0,0 +1,388 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import "unicode/utf8"
+
+func matchCountLoop(s string) (end int) {
+	end = -1
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	cnt1 := matchCountLoopb90d840bCounter{entered: make([]int, 9)}
+	cnt1.enter()
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 8:
+			goto s2
+		case cnt1.max() >= 8:
+			cnt1.enter()
+			end = i
+			goto s3
+		}
+	}
+	return
+s2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 8:
+			goto s2
+		case cnt1.max() >= 8:
+			cnt1.enter()
+			end = i
+			goto s3
+		}
+	}
+	return
+s3:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 8:
+			goto s2
+		case cnt1.max() >= 8:
+			cnt1.enter()
+			end = i
+			goto s3
+		}
+	}
+	return
+}
+
+func matchCountLoopBytes(s []byte) (end int) {
+	end = -1
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	cnt1 := matchCountLoopb90d840bCounter{entered: make([]int, 9)}
+	cnt1.enter()
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 8:
+			goto s2
+		case cnt1.max() >= 8:
+			cnt1.enter()
+			end = i
+			goto s3
+		}
+	}
+	return
+s2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 8:
+			goto s2
+		case cnt1.max() >= 8:
+			cnt1.enter()
+			end = i
+			goto s3
+		}
+	}
+	return
+s3:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 8:
+			goto s2
+		case cnt1.max() >= 8:
+			cnt1.enter()
+			end = i
+			goto s3
+		}
+	}
+	return
+}
+
+func matchCountLoopExpanded(s string) (end int) {
+	end = -1
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		goto s2
+	}
+	return
+s2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		goto s3
+	}
+	return
+s3:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		goto s4
+	}
+	return
+s4:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		goto s5
+	}
+	return
+s5:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		goto s6
+	}
+	return
+s6:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		goto s7
+	}
+	return
+s7:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		goto s8
+	}
+	return
+s8:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		end = i
+		goto s9
+	}
+	return
+s9:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		goto s2
+	}
+	return
+}
+
+func matchCountLoopExpandedBytes(s []byte) (end int) {
+	end = -1
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		goto s2
+	}
+	return
+s2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		goto s3
+	}
+	return
+s3:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		goto s4
+	}
+	return
+s4:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		goto s5
+	}
+	return
+s5:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		goto s6
+	}
+	return
+s6:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		goto s7
+	}
+	return
+s7:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		goto s8
+	}
+	return
+s8:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		end = i
+		goto s9
+	}
+	return
+s9:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57 || r >= 97 && r <= 102:
+		goto s2
+	}
+	return
+}
+
+// matchCountLoopb90d840bCounter holds the numbers of runes counted by the threads in a bounded repetition,
+// as the numbers of runes counted by the register when they entered the repetition, oldest first.
+type matchCountLoopb90d840bCounter struct {
+	entered []int // ring buffer of the maximum number of runes + 1 numbers
+	head, n int
+	count   int
+}
+
+func (c *matchCountLoopb90d840bCounter) increment() {
+	c.count++
+	if c.n > 0 && c.count-c.entered[c.head] >= len(c.entered) {
+		// The oldest thread has counted too many runes.
+		c.head = (c.head + 1) % len(c.entered)
+		c.n--
+	}
+}
+
+func (c *matchCountLoopb90d840bCounter) reset() {
+	c.n = 0
+}
+
+func (c *matchCountLoopb90d840bCounter) enter() {
+	if c.n > 0 && c.entered[(c.head+c.n-1)%len(c.entered)] == c.count {
+		return
+	}
+	c.entered[(c.head+c.n)%len(c.entered)] = c.count
+	c.n++
+}
+
+// max returns the number of runes counted by the oldest thread, or -1 if there are no threads.
+func (c *matchCountLoopb90d840bCounter) max() int {
+	if c.n == 0 {
+		return -1
+	}
+	return c.count - c.entered[c.head]
+}
@@ -0,0 +1,388 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchCountLoopAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:(?:[0-9a-f]{8})+)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"173ef687f55f5",
+		"5",
+		"776cb3dcabba75ecadf346e8edbc",
+		"7c2ab2",
+		"83999b95789f63",
+		"975fcb7",
+		"a25d8ea4",
+		"a3",
+		"ac98d",
+		"b",
+		"b3792d7ae396cff3",
+		"ba2",
+		"c",
+		"c35e5c62c0dfb9504d9779fdb",
+		"c8a",
+		"d",
+		"d34ec",
+		"ddf6c",
+		"f",
+		"f6e8caafa0dd",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"173ef'87f55f5",
+		"173ef687f55f5}",
+		"776cb3dcabba5ecadf346e8edbc",
+		"83999b95789f63K",
+		"83999b957g9f63",
+		"975fcb7m",
+		"W",
+		"a2",
+		"a98d",
+		"c35e5c62c0dfb9504d9779fdbQ",
+		"d34e",
+		"ddf6c(",
+		"fn",
+		"|",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountLoop(s); got != want {
+			t.Errorf("matchCountLoop(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountLoop(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:(?:[0-9a-f]{8})+)")
+	re.Longest()
+	for _, s := range []string{
+		"173ef687f55f5",
+		"5",
+		"776cb3dcabba75ecadf346e8edbc",
+		"7c2ab2",
+		"83999b95789f63",
+		"975fcb7",
+		"a25d8ea4",
+		"a3",
+		"ac98d",
+		"b",
+		"b3792d7ae396cff3",
+		"ba2",
+		"c",
+		"c35e5c62c0dfb9504d9779fdb",
+		"c8a",
+		"d",
+		"d34ec",
+		"ddf6c",
+		"f",
+		"f6e8caafa0dd",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountLoop(s); got != want {
+			t.Errorf("matchCountLoop(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountLoopBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:(?:[0-9a-f]{8})+)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"173ef687f55f5",
+		"5",
+		"776cb3dcabba75ecadf346e8edbc",
+		"7c2ab2",
+		"83999b95789f63",
+		"975fcb7",
+		"a25d8ea4",
+		"a3",
+		"ac98d",
+		"b",
+		"b3792d7ae396cff3",
+		"ba2",
+		"c",
+		"c35e5c62c0dfb9504d9779fdb",
+		"c8a",
+		"d",
+		"d34ec",
+		"ddf6c",
+		"f",
+		"f6e8caafa0dd",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"173ef'87f55f5",
+		"173ef687f55f5}",
+		"776cb3dcabba5ecadf346e8edbc",
+		"83999b95789f63K",
+		"83999b957g9f63",
+		"975fcb7m",
+		"W",
+		"a2",
+		"a98d",
+		"c35e5c62c0dfb9504d9779fdbQ",
+		"d34e",
+		"ddf6c(",
+		"fn",
+		"|",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountLoopBytes([]byte(s)); got != want {
+			t.Errorf("matchCountLoopBytes(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountLoopBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:(?:[0-9a-f]{8})+)")
+	re.Longest()
+	for _, s := range []string{
+		"173ef687f55f5",
+		"5",
+		"776cb3dcabba75ecadf346e8edbc",
+		"7c2ab2",
+		"83999b95789f63",
+		"975fcb7",
+		"a25d8ea4",
+		"a3",
+		"ac98d",
+		"b",
+		"b3792d7ae396cff3",
+		"ba2",
+		"c",
+		"c35e5c62c0dfb9504d9779fdb",
+		"c8a",
+		"d",
+		"d34ec",
+		"ddf6c",
+		"f",
+		"f6e8caafa0dd",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountLoopBytes([]byte(s)); got != want {
+			t.Errorf("matchCountLoopBytes(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountLoopExpandedAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:(?:[0-9a-f]{8})+)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"065b1db416bccc68",
+		"28779fbafd7a7a37",
+		"49b0755e5a0d2c015c98dc01",
+		"644cc703",
+		"815984786fa00cdeceefc1fb",
+		"98ba26a8",
+		"9f75fbbddb5cb36f",
+		"a0dfbfc9dfb9fb8b",
+		"abacbc7b",
+		"afd5ec95b8df9ad6",
+		"aff690fccb86fc502aa17b86",
+		"bb53c2ec29ebbe4aff18db68",
+		"c06378d14759bdba2c2443af6ff4d38e",
+		"c5789e91",
+		"cada4d11",
+		"d200ecb05b0dcace",
+		"dbfe7f5f",
+		"de7435a2",
+		"fc73d7ef35a0bfe4e2ffcd80d823b28b",
+		"fd97c7c3",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"065b1db416bccc686",
+		"49b0755e5a0d2c015c98dc01G",
+		"6",
+		"644cc70!",
+		"815984786fa00cdeceefc1f",
+		"98ba26as",
+		"9f",
+		"abacbc7\\",
+		"afd5ec95b8df9ad6b",
+		"afd5ec95b8df9ad6~",
+		"aff690fccb86fc502aa17b86i",
+		"c0378d14759bdba2c2443af6ff4d38e",
+		"c06378d14759bdba2c2443a6ff4d38e",
+		"fd97c7o3",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountLoopExpanded(s); got != want {
+			t.Errorf("matchCountLoopExpanded(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountLoopExpanded(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:(?:[0-9a-f]{8})+)")
+	re.Longest()
+	for _, s := range []string{
+		"065b1db416bccc68",
+		"28779fbafd7a7a37",
+		"49b0755e5a0d2c015c98dc01",
+		"644cc703",
+		"815984786fa00cdeceefc1fb",
+		"98ba26a8",
+		"9f75fbbddb5cb36f",
+		"a0dfbfc9dfb9fb8b",
+		"abacbc7b",
+		"afd5ec95b8df9ad6",
+		"aff690fccb86fc502aa17b86",
+		"bb53c2ec29ebbe4aff18db68",
+		"c06378d14759bdba2c2443af6ff4d38e",
+		"c5789e91",
+		"cada4d11",
+		"d200ecb05b0dcace",
+		"dbfe7f5f",
+		"de7435a2",
+		"fc73d7ef35a0bfe4e2ffcd80d823b28b",
+		"fd97c7c3",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountLoopExpanded(s); got != want {
+			t.Errorf("matchCountLoopExpanded(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountLoopExpandedBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:(?:[0-9a-f]{8})+)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"065b1db416bccc68",
+		"28779fbafd7a7a37",
+		"49b0755e5a0d2c015c98dc01",
+		"644cc703",
+		"815984786fa00cdeceefc1fb",
+		"98ba26a8",
+		"9f75fbbddb5cb36f",
+		"a0dfbfc9dfb9fb8b",
+		"abacbc7b",
+		"afd5ec95b8df9ad6",
+		"aff690fccb86fc502aa17b86",
+		"bb53c2ec29ebbe4aff18db68",
+		"c06378d14759bdba2c2443af6ff4d38e",
+		"c5789e91",
+		"cada4d11",
+		"d200ecb05b0dcace",
+		"dbfe7f5f",
+		"de7435a2",
+		"fc73d7ef35a0bfe4e2ffcd80d823b28b",
+		"fd97c7c3",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"065b1db416bccc686",
+		"49b0755e5a0d2c015c98dc01G",
+		"6",
+		"644cc70!",
+		"815984786fa00cdeceefc1f",
+		"98ba26as",
+		"9f",
+		"abacbc7\\",
+		"afd5ec95b8df9ad6b",
+		"afd5ec95b8df9ad6~",
+		"aff690fccb86fc502aa17b86i",
+		"c0378d14759bdba2c2443af6ff4d38e",
+		"c06378d14759bdba2c2443a6ff4d38e",
+		"fd97c7o3",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountLoopExpandedBytes([]byte(s)); got != want {
+			t.Errorf("matchCountLoopExpandedBytes(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountLoopExpandedBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:(?:[0-9a-f]{8})+)")
+	re.Longest()
+	for _, s := range []string{
+		"065b1db416bccc68",
+		"28779fbafd7a7a37",
+		"49b0755e5a0d2c015c98dc01",
+		"644cc703",
+		"815984786fa00cdeceefc1fb",
+		"98ba26a8",
+		"9f75fbbddb5cb36f",
+		"a0dfbfc9dfb9fb8b",
+		"abacbc7b",
+		"afd5ec95b8df9ad6",
+		"aff690fccb86fc502aa17b86",
+		"bb53c2ec29ebbe4aff18db68",
+		"c06378d14759bdba2c2443af6ff4d38e",
+		"c5789e91",
+		"cada4d11",
+		"d200ecb05b0dcace",
+		"dbfe7f5f",
+		"de7435a2",
+		"fc73d7ef35a0bfe4e2ffcd80d823b28b",
+		"fd97c7c3",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountLoopExpandedBytes([]byte(s)); got != want {
+			t.Errorf("matchCountLoopExpandedBytes(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,626 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import "unicode/utf8"
+
+func matchCountRange(s string) (end int) {
+	end = -1
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	cnt1 := matchCountRange673aa2f2Counter{entered: make([]int, 21)}
+	cnt1.enter()
+s1:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 1:
+			goto s1
+		case cnt1.max() >= 1:
+			end = i
+			goto s2
+		}
+	}
+	return
+s2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 1:
+			goto s1
+		case cnt1.max() >= 1:
+			end = i
+			goto s2
+		}
+	}
+	return
+}
+
+func matchCountRangeBytes(s []byte) (end int) {
+	end = -1
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	cnt1 := matchCountRange673aa2f2Counter{entered: make([]int, 21)}
+	cnt1.enter()
+s1:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 1:
+			goto s1
+		case cnt1.max() >= 1:
+			end = i
+			goto s2
+		}
+	}
+	return
+s2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 1:
+			goto s1
+		case cnt1.max() >= 1:
+			end = i
+			goto s2
+		}
+	}
+	return
+}
+
+func matchCountRangeExpanded(s string) (end int) {
+	end = -1
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s2
+	}
+	return
+s2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s3
+	}
+	return
+s3:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s4
+	}
+	return
+s4:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s5
+	}
+	return
+s5:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s6
+	}
+	return
+s6:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s7
+	}
+	return
+s7:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s8
+	}
+	return
+s8:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s9
+	}
+	return
+s9:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s10
+	}
+	return
+s10:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s11
+	}
+	return
+s11:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s12
+	}
+	return
+s12:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s13
+	}
+	return
+s13:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s14
+	}
+	return
+s14:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s15
+	}
+	return
+s15:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s16
+	}
+	return
+s16:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s17
+	}
+	return
+s17:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s18
+	}
+	return
+s18:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s19
+	}
+	return
+s19:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s20
+	}
+	return
+s20:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+	}
+	return
+}
+
+func matchCountRangeExpandedBytes(s []byte) (end int) {
+	end = -1
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s2
+	}
+	return
+s2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s3
+	}
+	return
+s3:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s4
+	}
+	return
+s4:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s5
+	}
+	return
+s5:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s6
+	}
+	return
+s6:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s7
+	}
+	return
+s7:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s8
+	}
+	return
+s8:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s9
+	}
+	return
+s9:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s10
+	}
+	return
+s10:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s11
+	}
+	return
+s11:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s12
+	}
+	return
+s12:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s13
+	}
+	return
+s13:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s14
+	}
+	return
+s14:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s15
+	}
+	return
+s15:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s16
+	}
+	return
+s16:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s17
+	}
+	return
+s17:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s18
+	}
+	return
+s18:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s19
+	}
+	return
+s19:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+		goto s20
+	}
+	return
+s20:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		end = i
+	}
+	return
+}
+
+// matchCountRange673aa2f2Counter holds the numbers of runes counted by the threads in a bounded repetition,
+// as the numbers of runes counted by the register when they entered the repetition, oldest first.
+type matchCountRange673aa2f2Counter struct {
+	entered []int // ring buffer of the maximum number of runes + 1 numbers
+	head, n int
+	count   int
+}
+
+func (c *matchCountRange673aa2f2Counter) increment() {
+	c.count++
+	if c.n > 0 && c.count-c.entered[c.head] >= len(c.entered) {
+		// The oldest thread has counted too many runes.
+		c.head = (c.head + 1) % len(c.entered)
+		c.n--
+	}
+}
+
+func (c *matchCountRange673aa2f2Counter) reset() {
+	c.n = 0
+}
+
+func (c *matchCountRange673aa2f2Counter) enter() {
+	if c.n > 0 && c.entered[(c.head+c.n-1)%len(c.entered)] == c.count {
+		return
+	}
+	c.entered[(c.head+c.n)%len(c.entered)] = c.count
+	c.n++
+}
+
+// max returns the number of runes counted by the oldest thread, or -1 if there are no threads.
+func (c *matchCountRange673aa2f2Counter) max() int {
+	if c.n == 0 {
+		return -1
+	}
+	return c.count - c.entered[c.head]
+}
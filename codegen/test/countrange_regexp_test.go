@@ -0,0 +1,388 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchCountRangeAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:[a-z]{1,20})")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"cmpad",
+		"djbdwhh",
+		"h",
+		"n",
+		"nllszqmdvrbrx",
+		"numaiygxsarl",
+		"oothoucm",
+		"peuijq",
+		"r",
+		"rdrmg",
+		"rnqkntnwstdctjcaetbbeywqizng",
+		"sb",
+		"sca",
+		"svfgdamcygjzpltusrlztnhlb",
+		"v",
+		"vzqgw",
+		"w",
+		"wzptldfdbmpvqd",
+		"x",
+		"zgo",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"6",
+		"V",
+		"ca",
+		"fgo",
+		"hF",
+		"nllszqmd'rbrx",
+		"nllszqmdvrb",
+		"oothoucmL",
+		"p!uijq",
+		"rrmg",
+		"scaa",
+		"svfgdamcygjzpltcsrlztnhlb",
+		"svfgdamcygjzpltusrlztnhlbh",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountRange(s); got != want {
+			t.Errorf("matchCountRange(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountRange(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:[a-z]{1,20})")
+	re.Longest()
+	for _, s := range []string{
+		"cmpad",
+		"djbdwhh",
+		"h",
+		"n",
+		"nllszqmdvrbrx",
+		"numaiygxsarl",
+		"oothoucm",
+		"peuijq",
+		"r",
+		"rdrmg",
+		"rnqkntnwstdctjcaetbbeywqizng",
+		"sb",
+		"sca",
+		"svfgdamcygjzpltusrlztnhlb",
+		"v",
+		"vzqgw",
+		"w",
+		"wzptldfdbmpvqd",
+		"x",
+		"zgo",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountRange(s); got != want {
+			t.Errorf("matchCountRange(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountRangeBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:[a-z]{1,20})")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"cmpad",
+		"djbdwhh",
+		"h",
+		"n",
+		"nllszqmdvrbrx",
+		"numaiygxsarl",
+		"oothoucm",
+		"peuijq",
+		"r",
+		"rdrmg",
+		"rnqkntnwstdctjcaetbbeywqizng",
+		"sb",
+		"sca",
+		"svfgdamcygjzpltusrlztnhlb",
+		"v",
+		"vzqgw",
+		"w",
+		"wzptldfdbmpvqd",
+		"x",
+		"zgo",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"6",
+		"V",
+		"ca",
+		"fgo",
+		"hF",
+		"nllszqmd'rbrx",
+		"nllszqmdvrb",
+		"oothoucmL",
+		"p!uijq",
+		"rrmg",
+		"scaa",
+		"svfgdamcygjzpltcsrlztnhlb",
+		"svfgdamcygjzpltusrlztnhlbh",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountRangeBytes([]byte(s)); got != want {
+			t.Errorf("matchCountRangeBytes(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountRangeBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:[a-z]{1,20})")
+	re.Longest()
+	for _, s := range []string{
+		"cmpad",
+		"djbdwhh",
+		"h",
+		"n",
+		"nllszqmdvrbrx",
+		"numaiygxsarl",
+		"oothoucm",
+		"peuijq",
+		"r",
+		"rdrmg",
+		"rnqkntnwstdctjcaetbbeywqizng",
+		"sb",
+		"sca",
+		"svfgdamcygjzpltusrlztnhlb",
+		"v",
+		"vzqgw",
+		"w",
+		"wzptldfdbmpvqd",
+		"x",
+		"zgo",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountRangeBytes([]byte(s)); got != want {
+			t.Errorf("matchCountRangeBytes(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountRangeExpandedAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:[a-z]{1,20})")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"alcs",
+		"b",
+		"bymul",
+		"d",
+		"dhdm",
+		"eqez",
+		"gdknlm",
+		"h",
+		"ja",
+		"m",
+		"mld",
+		"nc",
+		"nu",
+		"owf",
+		"pc",
+		"quihu",
+		"s",
+		"tls",
+		"u",
+		"wq",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"a7cs",
+		"al`s",
+		"als",
+		"dhdm[",
+		"dhdmm",
+		"di",
+		"eqez*",
+		"gdkn\\m",
+		"hdm",
+		"nE",
+		"t",
+		"tls:",
+		"ts",
+		"w",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountRangeExpanded(s); got != want {
+			t.Errorf("matchCountRangeExpanded(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountRangeExpanded(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:[a-z]{1,20})")
+	re.Longest()
+	for _, s := range []string{
+		"alcs",
+		"b",
+		"bymul",
+		"d",
+		"dhdm",
+		"eqez",
+		"gdknlm",
+		"h",
+		"ja",
+		"m",
+		"mld",
+		"nc",
+		"nu",
+		"owf",
+		"pc",
+		"quihu",
+		"s",
+		"tls",
+		"u",
+		"wq",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountRangeExpanded(s); got != want {
+			t.Errorf("matchCountRangeExpanded(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountRangeExpandedBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:[a-z]{1,20})")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"alcs",
+		"b",
+		"bymul",
+		"d",
+		"dhdm",
+		"eqez",
+		"gdknlm",
+		"h",
+		"ja",
+		"m",
+		"mld",
+		"nc",
+		"nu",
+		"owf",
+		"pc",
+		"quihu",
+		"s",
+		"tls",
+		"u",
+		"wq",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"a7cs",
+		"al`s",
+		"als",
+		"dhdm[",
+		"dhdmm",
+		"di",
+		"eqez*",
+		"gdkn\\m",
+		"hdm",
+		"nE",
+		"t",
+		"tls:",
+		"ts",
+		"w",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountRangeExpandedBytes([]byte(s)); got != want {
+			t.Errorf("matchCountRangeExpandedBytes(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountRangeExpandedBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:[a-z]{1,20})")
+	re.Longest()
+	for _, s := range []string{
+		"alcs",
+		"b",
+		"bymul",
+		"d",
+		"dhdm",
+		"eqez",
+		"gdknlm",
+		"h",
+		"ja",
+		"m",
+		"mld",
+		"nc",
+		"nu",
+		"owf",
+		"pc",
+		"quihu",
+		"s",
+		"tls",
+		"u",
+		"wq",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountRangeExpandedBytes([]byte(s)); got != want {
+			t.Errorf("matchCountRangeExpandedBytes(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,651 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+func matchCountReplaceAllEmpty(s string) string {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		start = at
+		_, _, _ = r, rlen, i
+		cnt1 := matchCountReplaceAllEmpty55010a1bCounter{entered: make([]int, 6)}
+		for {
+			end = start
+			i = start
+			cnt1.reset()
+			cnt1.enter()
+			r, rlen = utf8.DecodeRuneInString(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 98:
+				cnt1.reset()
+				cnt1.enter()
+				end = i
+				goto s3
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		s2:
+			r, rlen = utf8.DecodeRuneInString(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		s3:
+			r, rlen = utf8.DecodeRuneInString(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		done:
+			if end >= 0 {
+				return
+			}
+			_, rlen = utf8.DecodeRuneInString(s[start:])
+			if rlen == 0 {
+				break
+			}
+			start += rlen
+		}
+		return -1, -1
+	}
+	var b strings.Builder
+	last := 0
+	for pos := 0; pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		b.WriteString(s[last:start])
+		// An empty match right after the previous match is not replaced.
+		if end > last || start == 0 {
+			b.WriteString("<")
+			b.WriteString(s[start:end])
+			b.WriteString(">")
+		}
+		last = end
+		// As in regexp.ReplaceAllString, but the position moves forward even at the end of s.
+		if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 && pos+width > end {
+			pos += width
+		} else if pos+1 > end {
+			pos++
+		} else {
+			pos = end
+		}
+	}
+	if last == 0 && b.Len() == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+func matchCountReplaceAllEmptyBytes(s []byte) []byte {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		start = at
+		_, _, _ = r, rlen, i
+		cnt1 := matchCountReplaceAllEmpty55010a1bCounter{entered: make([]int, 6)}
+		for {
+			end = start
+			i = start
+			cnt1.reset()
+			cnt1.enter()
+			r, rlen = utf8.DecodeRune(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 98:
+				cnt1.reset()
+				cnt1.enter()
+				end = i
+				goto s3
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		s2:
+			r, rlen = utf8.DecodeRune(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		s3:
+			r, rlen = utf8.DecodeRune(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		done:
+			if end >= 0 {
+				return
+			}
+			_, rlen = utf8.DecodeRune(s[start:])
+			if rlen == 0 {
+				break
+			}
+			start += rlen
+		}
+		return -1, -1
+	}
+	var b []byte
+	last := 0
+	for pos := 0; pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		b = append(b, s[last:start]...)
+		// An empty match right after the previous match is not replaced.
+		if end > last || start == 0 {
+			b = append(b, "<"...)
+			b = append(b, s[start:end]...)
+			b = append(b, ">"...)
+		}
+		last = end
+		// As in regexp.ReplaceAllString, but the position moves forward even at the end of s.
+		if _, width := utf8.DecodeRune(s[pos:]); width > 0 && pos+width > end {
+			pos += width
+		} else if pos+1 > end {
+			pos++
+		} else {
+			pos = end
+		}
+	}
+	return append(b, s[last:]...)
+}
+
+func matchCountReplaceAllEmptyExpanded(s string) string {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = i
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f2
+		case r == 98:
+			end = i
+			goto f3
+		}
+		goto reverse
+	f2:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f4
+		}
+		goto reverse
+	f3:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f2
+		}
+		goto reverse
+	f4:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f5
+		}
+		goto reverse
+	f5:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f6
+		}
+		goto reverse
+	f6:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = end
+		i = end
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r2
+		case r == 98:
+			start = i
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r4
+		case r == 98:
+			start = i
+		}
+		return
+	r4:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r5
+		case r == 98:
+			start = i
+		}
+		return
+	r5:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r6
+		case r == 98:
+			start = i
+		}
+		return
+	r6:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r7
+		case r == 98:
+			start = i
+		}
+		return
+	r7:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 98:
+			start = i
+		}
+		return
+	}
+	var b strings.Builder
+	last := 0
+	for pos := 0; pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		b.WriteString(s[last:start])
+		// An empty match right after the previous match is not replaced.
+		if end > last || start == 0 {
+			b.WriteString("<")
+			b.WriteString(s[start:end])
+			b.WriteString(">")
+		}
+		last = end
+		// As in regexp.ReplaceAllString, but the position moves forward even at the end of s.
+		if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 && pos+width > end {
+			pos += width
+		} else if pos+1 > end {
+			pos++
+		} else {
+			pos = end
+		}
+	}
+	if last == 0 && b.Len() == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+func matchCountReplaceAllEmptyExpandedBytes(s []byte) []byte {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = i
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f2
+		case r == 98:
+			end = i
+			goto f3
+		}
+		goto reverse
+	f2:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f4
+		}
+		goto reverse
+	f3:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f2
+		}
+		goto reverse
+	f4:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f5
+		}
+		goto reverse
+	f5:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f6
+		}
+		goto reverse
+	f6:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = end
+		i = end
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r2
+		case r == 98:
+			start = i
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r4
+		case r == 98:
+			start = i
+		}
+		return
+	r4:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r5
+		case r == 98:
+			start = i
+		}
+		return
+	r5:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r6
+		case r == 98:
+			start = i
+		}
+		return
+	r6:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r7
+		case r == 98:
+			start = i
+		}
+		return
+	r7:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 98:
+			start = i
+		}
+		return
+	}
+	var b []byte
+	last := 0
+	for pos := 0; pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		b = append(b, s[last:start]...)
+		// An empty match right after the previous match is not replaced.
+		if end > last || start == 0 {
+			b = append(b, "<"...)
+			b = append(b, s[start:end]...)
+			b = append(b, ">"...)
+		}
+		last = end
+		// As in regexp.ReplaceAllString, but the position moves forward even at the end of s.
+		if _, width := utf8.DecodeRune(s[pos:]); width > 0 && pos+width > end {
+			pos += width
+		} else if pos+1 > end {
+			pos++
+		} else {
+			pos = end
+		}
+	}
+	return append(b, s[last:]...)
+}
+
+// matchCountReplaceAllEmpty55010a1bCounter holds the numbers of runes counted by the threads in a bounded repetition,
+// as the numbers of runes counted by the register when they entered the repetition, oldest first.
+type matchCountReplaceAllEmpty55010a1bCounter struct {
+	entered []int // ring buffer of the maximum number of runes + 1 numbers
+	head, n int
+	count   int
+}
+
+func (c *matchCountReplaceAllEmpty55010a1bCounter) increment() {
+	c.count++
+	if c.n > 0 && c.count-c.entered[c.head] >= len(c.entered) {
+		// The oldest thread has counted too many runes.
+		c.head = (c.head + 1) % len(c.entered)
+		c.n--
+	}
+}
+
+func (c *matchCountReplaceAllEmpty55010a1bCounter) reset() {
+	c.n = 0
+}
+
+func (c *matchCountReplaceAllEmpty55010a1bCounter) enter() {
+	if c.n > 0 && c.entered[(c.head+c.n-1)%len(c.entered)] == c.count {
+		return
+	}
+	c.entered[(c.head+c.n)%len(c.entered)] = c.count
+	c.n++
+}
+
+// max returns the number of runes counted by the oldest thread, or -1 if there are no threads.
+func (c *matchCountReplaceAllEmpty55010a1bCounter) max() int {
+	if c.n == 0 {
+		return -1
+	}
+	return c.count - c.entered[c.head]
+}
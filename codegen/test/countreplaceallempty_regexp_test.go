@@ -0,0 +1,428 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchCountReplaceAllEmptyAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("b?a{0,5}")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"b",
+		"ba",
+		"baa",
+		"baaa",
+		"baaaaa",
+		"baaaaaa",
+		"baaaaaaaaaaa",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"'",
+		",aa",
+		"4",
+		"_",
+		"aaDa",
+		"aaaEaaaa",
+		"aaaaaaaI",
+		"aaaf",
+		"aaah",
+		"ba6",
+		"baaK",
+		"baaa=a",
+		"baaaaa:",
+		"baaaaaa!",
+		"bas",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xaaaaaax",
+		"aaaaaa aaaaaa",
+		"xaaaaaaax",
+		"aaaaaaa aaaaaaa",
+		"xaaaaaaaax",
+		"aaaaaaaa aaaaaaaa",
+		"xbx",
+		"b b",
+		"xbax",
+		"ba ba",
+		"xbaax",
+		"baa baa",
+		"xbaaax",
+		"baaa baaa",
+		"xbaaaaax",
+		"baaaaa baaaaa",
+		"xbaaaaaax",
+		"baaaaaa baaaaaa",
+		"xbaaaaaaaaaaax",
+		"baaaaaaaaaaa baaaaaaaaaaa",
+	} {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchCountReplaceAllEmpty(s)); got != want {
+			t.Errorf("matchCountReplaceAllEmpty(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountReplaceAllEmpty(f *testing.F) {
+	re := regexp.MustCompile("b?a{0,5}")
+	re.Longest()
+	for _, s := range []string{
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"b",
+		"ba",
+		"baa",
+		"baaa",
+		"baaaaa",
+		"baaaaaa",
+		"baaaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchCountReplaceAllEmpty(s)); got != want {
+			t.Errorf("matchCountReplaceAllEmpty(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountReplaceAllEmptyBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("b?a{0,5}")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"b",
+		"ba",
+		"baa",
+		"baaa",
+		"baaaaa",
+		"baaaaaa",
+		"baaaaaaaaaaa",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"'",
+		",aa",
+		"4",
+		"_",
+		"aaDa",
+		"aaaEaaaa",
+		"aaaaaaaI",
+		"aaaf",
+		"aaah",
+		"ba6",
+		"baaK",
+		"baaa=a",
+		"baaaaa:",
+		"baaaaaa!",
+		"bas",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xaaaaaax",
+		"aaaaaa aaaaaa",
+		"xaaaaaaax",
+		"aaaaaaa aaaaaaa",
+		"xaaaaaaaax",
+		"aaaaaaaa aaaaaaaa",
+		"xbx",
+		"b b",
+		"xbax",
+		"ba ba",
+		"xbaax",
+		"baa baa",
+		"xbaaax",
+		"baaa baaa",
+		"xbaaaaax",
+		"baaaaa baaaaa",
+		"xbaaaaaax",
+		"baaaaaa baaaaaa",
+		"xbaaaaaaaaaaax",
+		"baaaaaaaaaaa baaaaaaaaaaa",
+	} {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchCountReplaceAllEmptyBytes([]byte(s))); got != want {
+			t.Errorf("matchCountReplaceAllEmptyBytes(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountReplaceAllEmptyBytes(f *testing.F) {
+	re := regexp.MustCompile("b?a{0,5}")
+	re.Longest()
+	for _, s := range []string{
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"b",
+		"ba",
+		"baa",
+		"baaa",
+		"baaaaa",
+		"baaaaaa",
+		"baaaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchCountReplaceAllEmptyBytes([]byte(s))); got != want {
+			t.Errorf("matchCountReplaceAllEmptyBytes(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountReplaceAllEmptyExpandedAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("b?a{0,5}")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"b",
+		"ba",
+		"baa",
+		"baaa",
+		"baaaa",
+		"baaaaa",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"4",
+		"Yaa",
+		"_aaaa",
+		"a$a",
+		"aaaa3",
+		"aaaaF",
+		"b4aaa",
+		"ba.",
+		"baa)",
+		"baa@aa",
+		"baaaa3",
+		"f",
+		"h",
+		"za",
+		"~",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xbx",
+		"b b",
+		"xbax",
+		"ba ba",
+		"xbaax",
+		"baa baa",
+		"xbaaax",
+		"baaa baaa",
+		"xbaaaax",
+		"baaaa baaaa",
+		"xbaaaaax",
+		"baaaaa baaaaa",
+	} {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchCountReplaceAllEmptyExpanded(s)); got != want {
+			t.Errorf("matchCountReplaceAllEmptyExpanded(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountReplaceAllEmptyExpanded(f *testing.F) {
+	re := regexp.MustCompile("b?a{0,5}")
+
+	for _, s := range []string{
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"b",
+		"ba",
+		"baa",
+		"baaa",
+		"baaaa",
+		"baaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchCountReplaceAllEmptyExpanded(s)); got != want {
+			t.Errorf("matchCountReplaceAllEmptyExpanded(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountReplaceAllEmptyExpandedBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("b?a{0,5}")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"b",
+		"ba",
+		"baa",
+		"baaa",
+		"baaaa",
+		"baaaaa",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"4",
+		"Yaa",
+		"_aaaa",
+		"a$a",
+		"aaaa3",
+		"aaaaF",
+		"b4aaa",
+		"ba.",
+		"baa)",
+		"baa@aa",
+		"baaaa3",
+		"f",
+		"h",
+		"za",
+		"~",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xbx",
+		"b b",
+		"xbax",
+		"ba ba",
+		"xbaax",
+		"baa baa",
+		"xbaaax",
+		"baaa baaa",
+		"xbaaaax",
+		"baaaa baaaa",
+		"xbaaaaax",
+		"baaaaa baaaaa",
+	} {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchCountReplaceAllEmptyExpandedBytes([]byte(s))); got != want {
+			t.Errorf("matchCountReplaceAllEmptyExpandedBytes(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountReplaceAllEmptyExpandedBytes(f *testing.F) {
+	re := regexp.MustCompile("b?a{0,5}")
+
+	for _, s := range []string{
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"b",
+		"ba",
+		"baa",
+		"baaa",
+		"baaaa",
+		"baaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchCountReplaceAllEmptyExpandedBytes([]byte(s))); got != want {
+			t.Errorf("matchCountReplaceAllEmptyExpandedBytes(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
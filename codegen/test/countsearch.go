@@ -0,0 +1,962 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+func matchCountSearch(s string) (start, end int) {
+	if !strings.Contains(s, "@x") {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	cnt1 := matchCountSearch1265cd49Counter{entered: make([]int, 13)}
+	for {
+		end = -1
+		i = start
+		cnt1.reset()
+		cnt1.enter()
+	s1:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto done
+		}
+		i += rlen
+		switch {
+		case r >= 97 && r <= 122:
+			cnt1.increment()
+			switch {
+			case cnt1.n > 0 && cnt1.max() < 3:
+				goto s1
+			case cnt1.max() >= 3:
+				goto s2
+			}
+		}
+		goto done
+	s2:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto done
+		}
+		i += rlen
+		switch {
+		case r == 64:
+			cnt1.reset()
+			goto s3
+		case r >= 97 && r <= 122:
+			cnt1.increment()
+			switch {
+			case cnt1.n > 0 && cnt1.max() < 3:
+				goto s1
+			case cnt1.max() >= 3:
+				goto s2
+			}
+		}
+		goto done
+	s3:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto done
+		}
+		i += rlen
+		switch {
+		case r == 120:
+			end = i
+		}
+		goto done
+	done:
+		if end >= 0 {
+			return
+		}
+		_, rlen = utf8.DecodeRuneInString(s[start:])
+		if rlen == 0 {
+			break
+		}
+		start += rlen
+	}
+	return -1, -1
+}
+
+func matchCountSearchBytes(s []byte) (start, end int) {
+	if !bytes.Contains(s, []byte("@x")) {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	cnt1 := matchCountSearch1265cd49Counter{entered: make([]int, 13)}
+	for {
+		end = -1
+		i = start
+		cnt1.reset()
+		cnt1.enter()
+	s1:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto done
+		}
+		i += rlen
+		switch {
+		case r >= 97 && r <= 122:
+			cnt1.increment()
+			switch {
+			case cnt1.n > 0 && cnt1.max() < 3:
+				goto s1
+			case cnt1.max() >= 3:
+				goto s2
+			}
+		}
+		goto done
+	s2:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto done
+		}
+		i += rlen
+		switch {
+		case r == 64:
+			cnt1.reset()
+			goto s3
+		case r >= 97 && r <= 122:
+			cnt1.increment()
+			switch {
+			case cnt1.n > 0 && cnt1.max() < 3:
+				goto s1
+			case cnt1.max() >= 3:
+				goto s2
+			}
+		}
+		goto done
+	s3:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto done
+		}
+		i += rlen
+		switch {
+		case r == 120:
+			end = i
+		}
+		goto done
+	done:
+		if end >= 0 {
+			return
+		}
+		_, rlen = utf8.DecodeRune(s[start:])
+		if rlen == 0 {
+			break
+		}
+		start += rlen
+	}
+	return -1, -1
+}
+
+func matchCountSearchExpanded(s string) (start, end int) {
+	if !strings.Contains(s, "@x") {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 123:
+		goto f1
+	case r >= 97 && r <= 122:
+		goto f2
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 123:
+		goto f1
+	case r >= 97 && r <= 122:
+		goto f3
+	}
+	goto reverse
+f3:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 123:
+		goto f1
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f4:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f6
+	}
+	goto reverse
+f5:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 123:
+		goto f1
+	case r >= 97 && r <= 119 || r >= 121 && r <= 122:
+		goto f2
+	case r == 120:
+		end = i
+	}
+	goto reverse
+f6:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f8
+	}
+	goto reverse
+f8:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f9
+	}
+	goto reverse
+f9:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f10
+	}
+	goto reverse
+f10:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f11
+	}
+	goto reverse
+f11:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f12
+	}
+	goto reverse
+f12:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f13
+	}
+	goto reverse
+f13:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f14
+	}
+	goto reverse
+f14:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f15
+	}
+	goto reverse
+f15:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f15
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 120:
+		goto r2
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 64:
+		goto r3
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto r4
+	}
+	return
+r4:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto r5
+	}
+	return
+r5:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r6
+	}
+	return
+r6:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r7
+	}
+	return
+r7:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r8
+	}
+	return
+r8:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r9
+	}
+	return
+r9:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r10
+	}
+	return
+r10:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r11
+	}
+	return
+r11:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r12
+	}
+	return
+r12:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r13
+	}
+	return
+r13:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r14
+	}
+	return
+r14:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+	}
+	return
+}
+
+func matchCountSearchExpandedBytes(s []byte) (start, end int) {
+	if !bytes.Contains(s, []byte("@x")) {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 123:
+		goto f1
+	case r >= 97 && r <= 122:
+		goto f2
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 123:
+		goto f1
+	case r >= 97 && r <= 122:
+		goto f3
+	}
+	goto reverse
+f3:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 123:
+		goto f1
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f4:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f6
+	}
+	goto reverse
+f5:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 123:
+		goto f1
+	case r >= 97 && r <= 119 || r >= 121 && r <= 122:
+		goto f2
+	case r == 120:
+		end = i
+	}
+	goto reverse
+f6:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f8
+	}
+	goto reverse
+f8:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f9
+	}
+	goto reverse
+f9:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f10
+	}
+	goto reverse
+f10:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f11
+	}
+	goto reverse
+f11:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f12
+	}
+	goto reverse
+f12:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f13
+	}
+	goto reverse
+f13:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f14
+	}
+	goto reverse
+f14:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f15
+	}
+	goto reverse
+f15:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f15
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 120:
+		goto r2
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 64:
+		goto r3
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto r4
+	}
+	return
+r4:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto r5
+	}
+	return
+r5:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r6
+	}
+	return
+r6:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r7
+	}
+	return
+r7:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r8
+	}
+	return
+r8:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r9
+	}
+	return
+r9:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r10
+	}
+	return
+r10:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r11
+	}
+	return
+r11:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r12
+	}
+	return
+r12:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r13
+	}
+	return
+r13:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r14
+	}
+	return
+r14:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+	}
+	return
+}
+
+// matchCountSearch1265cd49Counter holds the numbers of runes counted by the threads in a bounded repetition,
+// as the numbers of runes counted by the register when they entered the repetition, oldest first.
+type matchCountSearch1265cd49Counter struct {
+	entered []int // ring buffer of the maximum number of runes + 1 numbers
+	head, n int
+	count   int
+}
+
+func (c *matchCountSearch1265cd49Counter) increment() {
+	c.count++
+	if c.n > 0 && c.count-c.entered[c.head] >= len(c.entered) {
+		// The oldest thread has counted too many runes.
+		c.head = (c.head + 1) % len(c.entered)
+		c.n--
+	}
+}
+
+func (c *matchCountSearch1265cd49Counter) reset() {
+	c.n = 0
+}
+
+func (c *matchCountSearch1265cd49Counter) enter() {
+	if c.n > 0 && c.entered[(c.head+c.n-1)%len(c.entered)] == c.count {
+		return
+	}
+	c.entered[(c.head+c.n)%len(c.entered)] = c.count
+	c.n++
+}
+
+// max returns the number of runes counted by the oldest thread, or -1 if there are no threads.
+func (c *matchCountSearch1265cd49Counter) max() int {
+	if c.n == 0 {
+		return -1
+	}
+	return c.count - c.entered[c.head]
+}
@@ -0,0 +1,548 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchCountSearchAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("[a-z]{3,12}@x")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"amcbqi@x",
+		"arl@x",
+		"bdwhruahwkrbwwdfdtwtdutfxnkz@x",
+		"cqmdz@x",
+		"eswdsrxs@x",
+		"g@x",
+		"ipbhk@x",
+		"j@x",
+		"kx@x",
+		"ma@x",
+		"nitptf@x",
+		"qk@x",
+		"rbkoonb@x",
+		"rrzp@x",
+		"ruieyd@x",
+		"tbt@x",
+		"v@x",
+		"vgvbeunj@x",
+		"x@x",
+		"xpaa@x",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"cuieyd@x",
+		"eswds",
+		"gx",
+		"j@xK",
+		"kx@xI",
+		"qX@x",
+		"r",
+		"ruiNyd@x",
+		"tbt@f",
+		"tbtgx",
+		"vgvbeunj@xa",
+		"xpa@x",
+		"xpaaCx",
+		"xpha@x",
+		"é",
+		"日本",
+		"\xff",
+		"xamcbqi@xx",
+		"amcbqi@x amcbqi@x",
+		"xarl@xx",
+		"arl@x arl@x",
+		"xbdwhruahwkrbwwdfdtwtdutfxnkz@xx",
+		"bdwhruahwkrbwwdfdtwtdutfxnkz@x bdwhruahwkrbwwdfdtwtdutfxnkz@x",
+		"xcqmdz@xx",
+		"cqmdz@x cqmdz@x",
+		"xeswdsrxs@xx",
+		"eswdsrxs@x eswdsrxs@x",
+		"xg@xx",
+		"g@x g@x",
+		"xipbhk@xx",
+		"ipbhk@x ipbhk@x",
+		"xj@xx",
+		"j@x j@x",
+		"xkx@xx",
+		"kx@x kx@x",
+		"xma@xx",
+		"ma@x ma@x",
+		"xnitptf@xx",
+		"nitptf@x nitptf@x",
+		"xqk@xx",
+		"qk@x qk@x",
+		"xrbkoonb@xx",
+		"rbkoonb@x rbkoonb@x",
+		"xrrzp@xx",
+		"rrzp@x rrzp@x",
+		"xruieyd@xx",
+		"ruieyd@x ruieyd@x",
+		"xtbt@xx",
+		"tbt@x tbt@x",
+		"xv@xx",
+		"v@x v@x",
+		"xvgvbeunj@xx",
+		"vgvbeunj@x vgvbeunj@x",
+		"xx@xx",
+		"x@x x@x",
+		"xxpaa@xx",
+		"xpaa@x xpaa@x",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchCountSearch(s); start != want[0] || end != want[1] {
+			t.Errorf("matchCountSearch(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchCountSearch(f *testing.F) {
+	re := regexp.MustCompile("[a-z]{3,12}@x")
+	re.Longest()
+	for _, s := range []string{
+		"amcbqi@x",
+		"arl@x",
+		"bdwhruahwkrbwwdfdtwtdutfxnkz@x",
+		"cqmdz@x",
+		"eswdsrxs@x",
+		"g@x",
+		"ipbhk@x",
+		"j@x",
+		"kx@x",
+		"ma@x",
+		"nitptf@x",
+		"qk@x",
+		"rbkoonb@x",
+		"rrzp@x",
+		"ruieyd@x",
+		"tbt@x",
+		"v@x",
+		"vgvbeunj@x",
+		"x@x",
+		"xpaa@x",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchCountSearch(s); start != want[0] || end != want[1] {
+			t.Errorf("matchCountSearch(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchCountSearchBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("[a-z]{3,12}@x")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"amcbqi@x",
+		"arl@x",
+		"bdwhruahwkrbwwdfdtwtdutfxnkz@x",
+		"cqmdz@x",
+		"eswdsrxs@x",
+		"g@x",
+		"ipbhk@x",
+		"j@x",
+		"kx@x",
+		"ma@x",
+		"nitptf@x",
+		"qk@x",
+		"rbkoonb@x",
+		"rrzp@x",
+		"ruieyd@x",
+		"tbt@x",
+		"v@x",
+		"vgvbeunj@x",
+		"x@x",
+		"xpaa@x",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"cuieyd@x",
+		"eswds",
+		"gx",
+		"j@xK",
+		"kx@xI",
+		"qX@x",
+		"r",
+		"ruiNyd@x",
+		"tbt@f",
+		"tbtgx",
+		"vgvbeunj@xa",
+		"xpa@x",
+		"xpaaCx",
+		"xpha@x",
+		"é",
+		"日本",
+		"\xff",
+		"xamcbqi@xx",
+		"amcbqi@x amcbqi@x",
+		"xarl@xx",
+		"arl@x arl@x",
+		"xbdwhruahwkrbwwdfdtwtdutfxnkz@xx",
+		"bdwhruahwkrbwwdfdtwtdutfxnkz@x bdwhruahwkrbwwdfdtwtdutfxnkz@x",
+		"xcqmdz@xx",
+		"cqmdz@x cqmdz@x",
+		"xeswdsrxs@xx",
+		"eswdsrxs@x eswdsrxs@x",
+		"xg@xx",
+		"g@x g@x",
+		"xipbhk@xx",
+		"ipbhk@x ipbhk@x",
+		"xj@xx",
+		"j@x j@x",
+		"xkx@xx",
+		"kx@x kx@x",
+		"xma@xx",
+		"ma@x ma@x",
+		"xnitptf@xx",
+		"nitptf@x nitptf@x",
+		"xqk@xx",
+		"qk@x qk@x",
+		"xrbkoonb@xx",
+		"rbkoonb@x rbkoonb@x",
+		"xrrzp@xx",
+		"rrzp@x rrzp@x",
+		"xruieyd@xx",
+		"ruieyd@x ruieyd@x",
+		"xtbt@xx",
+		"tbt@x tbt@x",
+		"xv@xx",
+		"v@x v@x",
+		"xvgvbeunj@xx",
+		"vgvbeunj@x vgvbeunj@x",
+		"xx@xx",
+		"x@x x@x",
+		"xxpaa@xx",
+		"xpaa@x xpaa@x",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchCountSearchBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchCountSearchBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchCountSearchBytes(f *testing.F) {
+	re := regexp.MustCompile("[a-z]{3,12}@x")
+	re.Longest()
+	for _, s := range []string{
+		"amcbqi@x",
+		"arl@x",
+		"bdwhruahwkrbwwdfdtwtdutfxnkz@x",
+		"cqmdz@x",
+		"eswdsrxs@x",
+		"g@x",
+		"ipbhk@x",
+		"j@x",
+		"kx@x",
+		"ma@x",
+		"nitptf@x",
+		"qk@x",
+		"rbkoonb@x",
+		"rrzp@x",
+		"ruieyd@x",
+		"tbt@x",
+		"v@x",
+		"vgvbeunj@x",
+		"x@x",
+		"xpaa@x",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchCountSearchBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchCountSearchBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchCountSearchExpandedAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("[a-z]{3,12}@x")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"bkoon@x",
+		"btd@x",
+		"ctk@x",
+		"gca@x",
+		"lnrgcex@x",
+		"lyedy@x",
+		"nit@x",
+		"nruieydn@x",
+		"nyjl@x",
+		"qrvjyuf@x",
+		"rdu@x",
+		"rlc@x",
+		"rua@x",
+		"sbc@x",
+		"sslxp@x",
+		"swmiu@x",
+		"ukr@x",
+		"xsj@x",
+		"zgs@x",
+		"zpp@x",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"ctk@xT",
+		"ctk@xt",
+		"gRa@x",
+		"gca@2",
+		"nruieydn@o",
+		"nyjlx",
+		"qr",
+		"rdu@",
+		"sBc@x",
+		"sKc@x",
+		"sslxpKx",
+		"xsj@xK",
+		"z",
+		"zgK@x",
+		"é",
+		"日本",
+		"\xff",
+		"xbkoon@xx",
+		"bkoon@x bkoon@x",
+		"xbtd@xx",
+		"btd@x btd@x",
+		"xctk@xx",
+		"ctk@x ctk@x",
+		"xgca@xx",
+		"gca@x gca@x",
+		"xlnrgcex@xx",
+		"lnrgcex@x lnrgcex@x",
+		"xlyedy@xx",
+		"lyedy@x lyedy@x",
+		"xnit@xx",
+		"nit@x nit@x",
+		"xnruieydn@xx",
+		"nruieydn@x nruieydn@x",
+		"xnyjl@xx",
+		"nyjl@x nyjl@x",
+		"xqrvjyuf@xx",
+		"qrvjyuf@x qrvjyuf@x",
+		"xrdu@xx",
+		"rdu@x rdu@x",
+		"xrlc@xx",
+		"rlc@x rlc@x",
+		"xrua@xx",
+		"rua@x rua@x",
+		"xsbc@xx",
+		"sbc@x sbc@x",
+		"xsslxp@xx",
+		"sslxp@x sslxp@x",
+		"xswmiu@xx",
+		"swmiu@x swmiu@x",
+		"xukr@xx",
+		"ukr@x ukr@x",
+		"xxsj@xx",
+		"xsj@x xsj@x",
+		"xzgs@xx",
+		"zgs@x zgs@x",
+		"xzpp@xx",
+		"zpp@x zpp@x",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchCountSearchExpanded(s); start != want[0] || end != want[1] {
+			t.Errorf("matchCountSearchExpanded(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchCountSearchExpanded(f *testing.F) {
+	re := regexp.MustCompile("[a-z]{3,12}@x")
+
+	for _, s := range []string{
+		"bkoon@x",
+		"btd@x",
+		"ctk@x",
+		"gca@x",
+		"lnrgcex@x",
+		"lyedy@x",
+		"nit@x",
+		"nruieydn@x",
+		"nyjl@x",
+		"qrvjyuf@x",
+		"rdu@x",
+		"rlc@x",
+		"rua@x",
+		"sbc@x",
+		"sslxp@x",
+		"swmiu@x",
+		"ukr@x",
+		"xsj@x",
+		"zgs@x",
+		"zpp@x",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchCountSearchExpanded(s); start != want[0] || end != want[1] {
+			t.Errorf("matchCountSearchExpanded(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchCountSearchExpandedBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("[a-z]{3,12}@x")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"bkoon@x",
+		"btd@x",
+		"ctk@x",
+		"gca@x",
+		"lnrgcex@x",
+		"lyedy@x",
+		"nit@x",
+		"nruieydn@x",
+		"nyjl@x",
+		"qrvjyuf@x",
+		"rdu@x",
+		"rlc@x",
+		"rua@x",
+		"sbc@x",
+		"sslxp@x",
+		"swmiu@x",
+		"ukr@x",
+		"xsj@x",
+		"zgs@x",
+		"zpp@x",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"ctk@xT",
+		"ctk@xt",
+		"gRa@x",
+		"gca@2",
+		"nruieydn@o",
+		"nyjlx",
+		"qr",
+		"rdu@",
+		"sBc@x",
+		"sKc@x",
+		"sslxpKx",
+		"xsj@xK",
+		"z",
+		"zgK@x",
+		"é",
+		"日本",
+		"\xff",
+		"xbkoon@xx",
+		"bkoon@x bkoon@x",
+		"xbtd@xx",
+		"btd@x btd@x",
+		"xctk@xx",
+		"ctk@x ctk@x",
+		"xgca@xx",
+		"gca@x gca@x",
+		"xlnrgcex@xx",
+		"lnrgcex@x lnrgcex@x",
+		"xlyedy@xx",
+		"lyedy@x lyedy@x",
+		"xnit@xx",
+		"nit@x nit@x",
+		"xnruieydn@xx",
+		"nruieydn@x nruieydn@x",
+		"xnyjl@xx",
+		"nyjl@x nyjl@x",
+		"xqrvjyuf@xx",
+		"qrvjyuf@x qrvjyuf@x",
+		"xrdu@xx",
+		"rdu@x rdu@x",
+		"xrlc@xx",
+		"rlc@x rlc@x",
+		"xrua@xx",
+		"rua@x rua@x",
+		"xsbc@xx",
+		"sbc@x sbc@x",
+		"xsslxp@xx",
+		"sslxp@x sslxp@x",
+		"xswmiu@xx",
+		"swmiu@x swmiu@x",
+		"xukr@xx",
+		"ukr@x ukr@x",
+		"xxsj@xx",
+		"xsj@x xsj@x",
+		"xzgs@xx",
+		"zgs@x zgs@x",
+		"xzpp@xx",
+		"zpp@x zpp@x",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchCountSearchExpandedBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchCountSearchExpandedBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchCountSearchExpandedBytes(f *testing.F) {
+	re := regexp.MustCompile("[a-z]{3,12}@x")
+
+	for _, s := range []string{
+		"bkoon@x",
+		"btd@x",
+		"ctk@x",
+		"gca@x",
+		"lnrgcex@x",
+		"lyedy@x",
+		"nit@x",
+		"nruieydn@x",
+		"nyjl@x",
+		"qrvjyuf@x",
+		"rdu@x",
+		"rlc@x",
+		"rua@x",
+		"sbc@x",
+		"sslxp@x",
+		"swmiu@x",
+		"ukr@x",
+		"xsj@x",
+		"zgs@x",
+		"zpp@x",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchCountSearchExpandedBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchCountSearchExpandedBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
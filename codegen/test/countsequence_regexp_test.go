@@ -0,0 +1,312 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchCountSequenceAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a{6}b{6})")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"aaaaaaaaab",
+		"aaaaaaaabb",
+		"aaaaaaaabbb",
+		"aaaaaaab",
+		"aaaaab",
+		"aaaaabbbbbbbb",
+		"aaaab",
+		"aaaabbbbbbbbbbbbbbb",
+		"aaab",
+		"aaabbb",
+		"aabb",
+		"aabbbbbb",
+		"aabbbbbbbbbbbb",
+		"aabbbbbbbbbbbbbb",
+		"abb",
+		"abbbb",
+		"abbbbb",
+		"abbbbbbb",
+		"abbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbb",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"a",
+		"a$aaabbbbbbbb",
+		"aYbbb",
+		"aa",
+		"aa4b",
+		"aaaaaaabb",
+		"aaaaaaabbb",
+		"aaaaab3bbbbbb",
+		"aaaabbbb4bbbbbbbbbb",
+		"aaabbb3",
+		"aabbbbbbbbbbb",
+		"aazb",
+		"aa~abbbbbbbbbbbbbbb",
+		"a~b",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountSequence(s); got != want {
+			t.Errorf("matchCountSequence(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountSequence(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a{6}b{6})")
+	re.Longest()
+	for _, s := range []string{
+		"aaaaaaaaab",
+		"aaaaaaaabb",
+		"aaaaaaaabbb",
+		"aaaaaaab",
+		"aaaaab",
+		"aaaaabbbbbbbb",
+		"aaaab",
+		"aaaabbbbbbbbbbbbbbb",
+		"aaab",
+		"aaabbb",
+		"aabb",
+		"aabbbbbb",
+		"aabbbbbbbbbbbb",
+		"aabbbbbbbbbbbbbb",
+		"abb",
+		"abbbb",
+		"abbbbb",
+		"abbbbbbb",
+		"abbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbb",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountSequence(s); got != want {
+			t.Errorf("matchCountSequence(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountSequenceBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a{6}b{6})")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"aaaaaaaaab",
+		"aaaaaaaabb",
+		"aaaaaaaabbb",
+		"aaaaaaab",
+		"aaaaab",
+		"aaaaabbbbbbbb",
+		"aaaab",
+		"aaaabbbbbbbbbbbbbbb",
+		"aaab",
+		"aaabbb",
+		"aabb",
+		"aabbbbbb",
+		"aabbbbbbbbbbbb",
+		"aabbbbbbbbbbbbbb",
+		"abb",
+		"abbbb",
+		"abbbbb",
+		"abbbbbbb",
+		"abbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbb",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"a",
+		"a$aaabbbbbbbb",
+		"aYbbb",
+		"aa",
+		"aa4b",
+		"aaaaaaabb",
+		"aaaaaaabbb",
+		"aaaaab3bbbbbb",
+		"aaaabbbb4bbbbbbbbbb",
+		"aaabbb3",
+		"aabbbbbbbbbbb",
+		"aazb",
+		"aa~abbbbbbbbbbbbbbb",
+		"a~b",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountSequenceBytes([]byte(s)); got != want {
+			t.Errorf("matchCountSequenceBytes(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountSequenceBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a{6}b{6})")
+	re.Longest()
+	for _, s := range []string{
+		"aaaaaaaaab",
+		"aaaaaaaabb",
+		"aaaaaaaabbb",
+		"aaaaaaab",
+		"aaaaab",
+		"aaaaabbbbbbbb",
+		"aaaab",
+		"aaaabbbbbbbbbbbbbbb",
+		"aaab",
+		"aaabbb",
+		"aabb",
+		"aabbbbbb",
+		"aabbbbbbbbbbbb",
+		"aabbbbbbbbbbbbbb",
+		"abb",
+		"abbbb",
+		"abbbbb",
+		"abbbbbbb",
+		"abbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbb",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountSequenceBytes([]byte(s)); got != want {
+			t.Errorf("matchCountSequenceBytes(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountSequenceExpandedAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a{6}b{6})")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"aaaaaabbbbbb",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"a",
+		"aaa",
+		"aaaa",
+		"aaaaaabbbb",
+		"aaaaaabbbb%b",
+		"aaaaaabbbbHb",
+		"aaaaaabbbbb",
+		"aaaaaabbbbbb*",
+		"aaaaaabbbbbbC",
+		"aaaaaabbbbbbD",
+		"aaaaaabbbbbbx",
+		"aaaaaabbbbbb{",
+		"aaaaaabbbbbb|",
+		"aaaaaabbbpbb",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountSequenceExpanded(s); got != want {
+			t.Errorf("matchCountSequenceExpanded(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountSequenceExpanded(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a{6}b{6})")
+	re.Longest()
+	for _, s := range []string{
+		"aaaaaabbbbbb",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountSequenceExpanded(s); got != want {
+			t.Errorf("matchCountSequenceExpanded(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountSequenceExpandedBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a{6}b{6})")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"aaaaaabbbbbb",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"a",
+		"aaa",
+		"aaaa",
+		"aaaaaabbbb",
+		"aaaaaabbbb%b",
+		"aaaaaabbbbHb",
+		"aaaaaabbbbb",
+		"aaaaaabbbbbb*",
+		"aaaaaabbbbbbC",
+		"aaaaaabbbbbbD",
+		"aaaaaabbbbbbx",
+		"aaaaaabbbbbb{",
+		"aaaaaabbbbbb|",
+		"aaaaaabbbpbb",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountSequenceExpandedBytes([]byte(s)); got != want {
+			t.Errorf("matchCountSequenceExpandedBytes(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountSequenceExpandedBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a{6}b{6})")
+	re.Longest()
+	for _, s := range []string{
+		"aaaaaabbbbbb",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountSequenceExpandedBytes([]byte(s)); got != want {
+			t.Errorf("matchCountSequenceExpandedBytes(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,732 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import "unicode/utf8"
+
+func matchCountSplitEmpty(s string, n int) []string {
+	if n == 0 {
+		return nil
+	}
+	if len(s) == 0 {
+		return []string{s}
+	}
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		start = at
+		_, _, _ = r, rlen, i
+		cnt1 := matchCountSplitEmpty5853db90Counter{entered: make([]int, 6)}
+		for {
+			end = start
+			i = start
+			cnt1.reset()
+			cnt1.enter()
+			r, rlen = utf8.DecodeRuneInString(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 98:
+				cnt1.reset()
+				cnt1.enter()
+				end = i
+				goto s3
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		s2:
+			r, rlen = utf8.DecodeRuneInString(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		s3:
+			r, rlen = utf8.DecodeRuneInString(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		done:
+			if end >= 0 {
+				return
+			}
+			_, rlen = utf8.DecodeRuneInString(s[start:])
+			if rlen == 0 {
+				break
+			}
+			start += rlen
+		}
+		return -1, -1
+	}
+	parts := []string{}
+	beg, last := 0, 0
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if n > 0 && len(parts) == n-1 {
+				break
+			}
+			last = start
+			if end != 0 {
+				parts = append(parts, s[beg:start])
+			}
+			beg = end
+			k++
+		}
+	}
+	if last != len(s) {
+		parts = append(parts, s[beg:])
+	}
+	return parts
+}
+
+func matchCountSplitEmptyBytes(s []byte, n int) [][]byte {
+	if n == 0 {
+		return nil
+	}
+	if len(s) == 0 {
+		return [][]byte{s}
+	}
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		start = at
+		_, _, _ = r, rlen, i
+		cnt1 := matchCountSplitEmpty5853db90Counter{entered: make([]int, 6)}
+		for {
+			end = start
+			i = start
+			cnt1.reset()
+			cnt1.enter()
+			r, rlen = utf8.DecodeRune(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 98:
+				cnt1.reset()
+				cnt1.enter()
+				end = i
+				goto s3
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		s2:
+			r, rlen = utf8.DecodeRune(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		s3:
+			r, rlen = utf8.DecodeRune(s[i:])
+			if rlen == 0 {
+				goto done
+			}
+			i += rlen
+			switch {
+			case r == 97:
+				cnt1.increment()
+				switch {
+				case cnt1.max() >= 0:
+					end = i
+					goto s2
+				}
+			}
+			goto done
+		done:
+			if end >= 0 {
+				return
+			}
+			_, rlen = utf8.DecodeRune(s[start:])
+			if rlen == 0 {
+				break
+			}
+			start += rlen
+		}
+		return -1, -1
+	}
+	parts := [][]byte{}
+	beg, last := 0, 0
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRune(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if n > 0 && len(parts) == n-1 {
+				break
+			}
+			last = start
+			if end != 0 {
+				parts = append(parts, s[beg:start])
+			}
+			beg = end
+			k++
+		}
+	}
+	if last != len(s) {
+		parts = append(parts, s[beg:])
+	}
+	return parts
+}
+
+func matchCountSplitEmptyExpanded(s string, n int) []string {
+	if n == 0 {
+		return nil
+	}
+	if len(s) == 0 {
+		return []string{s}
+	}
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = i
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f2
+		case r == 98:
+			end = i
+			goto f3
+		}
+		goto reverse
+	f2:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f4
+		}
+		goto reverse
+	f3:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f2
+		}
+		goto reverse
+	f4:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f5
+		}
+		goto reverse
+	f5:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f6
+		}
+		goto reverse
+	f6:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = end
+		i = end
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r2
+		case r == 98:
+			start = i
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r4
+		case r == 98:
+			start = i
+		}
+		return
+	r4:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r5
+		case r == 98:
+			start = i
+		}
+		return
+	r5:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r6
+		case r == 98:
+			start = i
+		}
+		return
+	r6:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r7
+		case r == 98:
+			start = i
+		}
+		return
+	r7:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 98:
+			start = i
+		}
+		return
+	}
+	parts := []string{}
+	beg, last := 0, 0
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if n > 0 && len(parts) == n-1 {
+				break
+			}
+			last = start
+			if end != 0 {
+				parts = append(parts, s[beg:start])
+			}
+			beg = end
+			k++
+		}
+	}
+	if last != len(s) {
+		parts = append(parts, s[beg:])
+	}
+	return parts
+}
+
+func matchCountSplitEmptyExpandedBytes(s []byte, n int) [][]byte {
+	if n == 0 {
+		return nil
+	}
+	if len(s) == 0 {
+		return [][]byte{s}
+	}
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = i
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f2
+		case r == 98:
+			end = i
+			goto f3
+		}
+		goto reverse
+	f2:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f4
+		}
+		goto reverse
+	f3:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f2
+		}
+		goto reverse
+	f4:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f5
+		}
+		goto reverse
+	f5:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f6
+		}
+		goto reverse
+	f6:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = end
+		i = end
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r2
+		case r == 98:
+			start = i
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r4
+		case r == 98:
+			start = i
+		}
+		return
+	r4:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r5
+		case r == 98:
+			start = i
+		}
+		return
+	r5:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r6
+		case r == 98:
+			start = i
+		}
+		return
+	r6:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r7
+		case r == 98:
+			start = i
+		}
+		return
+	r7:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 98:
+			start = i
+		}
+		return
+	}
+	parts := [][]byte{}
+	beg, last := 0, 0
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRune(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if n > 0 && len(parts) == n-1 {
+				break
+			}
+			last = start
+			if end != 0 {
+				parts = append(parts, s[beg:start])
+			}
+			beg = end
+			k++
+		}
+	}
+	if last != len(s) {
+		parts = append(parts, s[beg:])
+	}
+	return parts
+}
+
+// matchCountSplitEmpty5853db90Counter holds the numbers of runes counted by the threads in a bounded repetition,
+// as the numbers of runes counted by the register when they entered the repetition, oldest first.
+type matchCountSplitEmpty5853db90Counter struct {
+	entered []int // ring buffer of the maximum number of runes + 1 numbers
+	head, n int
+	count   int
+}
+
+func (c *matchCountSplitEmpty5853db90Counter) increment() {
+	c.count++
+	if c.n > 0 && c.count-c.entered[c.head] >= len(c.entered) {
+		// The oldest thread has counted too many runes.
+		c.head = (c.head + 1) % len(c.entered)
+		c.n--
+	}
+}
+
+func (c *matchCountSplitEmpty5853db90Counter) reset() {
+	c.n = 0
+}
+
+func (c *matchCountSplitEmpty5853db90Counter) enter() {
+	if c.n > 0 && c.entered[(c.head+c.n-1)%len(c.entered)] == c.count {
+		return
+	}
+	c.entered[(c.head+c.n)%len(c.entered)] = c.count
+	c.n++
+}
+
+// max returns the number of runes counted by the oldest thread, or -1 if there are no threads.
+func (c *matchCountSplitEmpty5853db90Counter) max() int {
+	if c.n == 0 {
+		return -1
+	}
+	return c.count - c.entered[c.head]
+}
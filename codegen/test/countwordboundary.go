@@ -0,0 +1,704 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import "unicode/utf8"
+
+func matchCountWordBoundary(s string) (end int) {
+	end = -1
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	cnt1 := matchCountWordBoundary0e6248efCounter{entered: make([]int, 11)}
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		cnt1.enter()
+		goto s2
+	}
+	return
+s2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 3:
+			goto s3
+		case cnt1.max() >= 3:
+			goto s4
+		}
+	}
+	return
+s3:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 3:
+			goto s3
+		case cnt1.max() >= 3:
+			goto s4
+		}
+	}
+	return
+s4:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+		goto s5
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 3:
+			goto s3
+		case cnt1.max() >= 3:
+			goto s4
+		}
+	}
+	return
+s5:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 3:
+			goto s3
+		case cnt1.max() >= 3:
+			goto s4
+		}
+	}
+	return
+}
+
+func matchCountWordBoundaryBytes(s []byte) (end int) {
+	end = -1
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	cnt1 := matchCountWordBoundary0e6248efCounter{entered: make([]int, 11)}
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		cnt1.enter()
+		goto s2
+	}
+	return
+s2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 3:
+			goto s3
+		case cnt1.max() >= 3:
+			goto s4
+		}
+	}
+	return
+s3:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 3:
+			goto s3
+		case cnt1.max() >= 3:
+			goto s4
+		}
+	}
+	return
+s4:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+		goto s5
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 3:
+			goto s3
+		case cnt1.max() >= 3:
+			goto s4
+		}
+	}
+	return
+s5:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		cnt1.increment()
+		switch {
+		case cnt1.n > 0 && cnt1.max() < 3:
+			goto s3
+		case cnt1.max() >= 3:
+			goto s4
+		}
+	}
+	return
+}
+
+func matchCountWordBoundaryExpanded(s string) (end int) {
+	end = -1
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		goto s2
+	}
+	return
+s2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s3
+	}
+	return
+s3:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s4
+	}
+	return
+s4:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s5
+	}
+	return
+s5:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+		goto s6
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s7
+	}
+	return
+s6:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s7
+	}
+	return
+s7:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+		goto s8
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s9
+	}
+	return
+s8:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s9
+	}
+	return
+s9:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+		goto s10
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s11
+	}
+	return
+s10:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s11
+	}
+	return
+s11:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+		goto s12
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s13
+	}
+	return
+s12:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s13
+	}
+	return
+s13:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+		goto s14
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s15
+	}
+	return
+s14:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s15
+	}
+	return
+s15:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+		goto s16
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s17
+	}
+	return
+s16:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s17
+	}
+	return
+s17:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+		goto s18
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s19
+	}
+	return
+s18:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s19
+	}
+	return
+s19:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+	}
+	return
+}
+
+func matchCountWordBoundaryExpandedBytes(s []byte) (end int) {
+	end = -1
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		goto s2
+	}
+	return
+s2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s3
+	}
+	return
+s3:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s4
+	}
+	return
+s4:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s5
+	}
+	return
+s5:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+		goto s6
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s7
+	}
+	return
+s6:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s7
+	}
+	return
+s7:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+		goto s8
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s9
+	}
+	return
+s8:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s9
+	}
+	return
+s9:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+		goto s10
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s11
+	}
+	return
+s10:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s11
+	}
+	return
+s11:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+		goto s12
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s13
+	}
+	return
+s12:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s13
+	}
+	return
+s13:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+		goto s14
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s15
+	}
+	return
+s14:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s15
+	}
+	return
+s15:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+		goto s16
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s17
+	}
+	return
+s16:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s17
+	}
+	return
+s17:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+		goto s18
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s19
+	}
+	return
+s18:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		return
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto s19
+	}
+	return
+s19:
+	switch {
+	case (i > 0 && matchCountWordBoundary0e6248efIsWordChar(s[i-1])) != (i < len(s) && matchCountWordBoundary0e6248efIsWordChar(s[i])):
+		end = i
+	}
+	return
+}
+
+func matchCountWordBoundary0e6248efIsWordChar(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_'
+}
+
+// matchCountWordBoundary0e6248efCounter holds the numbers of runes counted by the threads in a bounded repetition,
+// as the numbers of runes counted by the register when they entered the repetition, oldest first.
+type matchCountWordBoundary0e6248efCounter struct {
+	entered []int // ring buffer of the maximum number of runes + 1 numbers
+	head, n int
+	count   int
+}
+
+func (c *matchCountWordBoundary0e6248efCounter) increment() {
+	c.count++
+	if c.n > 0 && c.count-c.entered[c.head] >= len(c.entered) {
+		// The oldest thread has counted too many runes.
+		c.head = (c.head + 1) % len(c.entered)
+		c.n--
+	}
+}
+
+func (c *matchCountWordBoundary0e6248efCounter) reset() {
+	c.n = 0
+}
+
+func (c *matchCountWordBoundary0e6248efCounter) enter() {
+	if c.n > 0 && c.entered[(c.head+c.n-1)%len(c.entered)] == c.count {
+		return
+	}
+	c.entered[(c.head+c.n)%len(c.entered)] = c.count
+	c.n++
+}
+
+// max returns the number of runes counted by the oldest thread, or -1 if there are no threads.
+func (c *matchCountWordBoundary0e6248efCounter) max() int {
+	if c.n == 0 {
+		return -1
+	}
+	return c.count - c.entered[c.head]
+}
@@ -0,0 +1,388 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchCountWordBoundaryAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:\\b[a-z]{3,10}\\b)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"by",
+		"exycxekcetmgfvynghwuonvji",
+		"gmqdnerdtd",
+		"gngnwsjokhmcaetvmbvf",
+		"htslxjkva",
+		"i",
+		"kcjkucmuxluclhmwvvjrxbeunj",
+		"kguxgbnznacxzhoyqcsyhzsvllku",
+		"n",
+		"nywnssma",
+		"prvgpobbbnrbxtbkfzxbm",
+		"pyjwlygxsarzzuahevuhruahwkrbw",
+		"rvnrvafvbwgxwqnygn",
+		"rxgopdlsrartowhuefcaov",
+		"vamcbqizpldtqplaxzrbkoonb",
+		"vxou",
+		"yedydvqd",
+		"zfilojtv",
+		"zlpbhkqlqgwwcteprvhzbohresbj",
+		"zng",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"exycoekcetmgfvynghwuonvji",
+		"exycxekcetmgfvynghwuonvjip",
+		"gm",
+		"htslxjkva+",
+		"htslxjkvab",
+		"htslxjkva~",
+		"nywssma",
+		"y",
+		"yedydvq!",
+		"yedydvqds",
+		"zfiloj",
+		"zfilojt",
+		"zfilojtvf",
+		"zngG",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountWordBoundary(s); got != want {
+			t.Errorf("matchCountWordBoundary(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountWordBoundary(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:\\b[a-z]{3,10}\\b)")
+	re.Longest()
+	for _, s := range []string{
+		"by",
+		"exycxekcetmgfvynghwuonvji",
+		"gmqdnerdtd",
+		"gngnwsjokhmcaetvmbvf",
+		"htslxjkva",
+		"i",
+		"kcjkucmuxluclhmwvvjrxbeunj",
+		"kguxgbnznacxzhoyqcsyhzsvllku",
+		"n",
+		"nywnssma",
+		"prvgpobbbnrbxtbkfzxbm",
+		"pyjwlygxsarzzuahevuhruahwkrbw",
+		"rvnrvafvbwgxwqnygn",
+		"rxgopdlsrartowhuefcaov",
+		"vamcbqizpldtqplaxzrbkoonb",
+		"vxou",
+		"yedydvqd",
+		"zfilojtv",
+		"zlpbhkqlqgwwcteprvhzbohresbj",
+		"zng",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountWordBoundary(s); got != want {
+			t.Errorf("matchCountWordBoundary(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountWordBoundaryBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:\\b[a-z]{3,10}\\b)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"by",
+		"exycxekcetmgfvynghwuonvji",
+		"gmqdnerdtd",
+		"gngnwsjokhmcaetvmbvf",
+		"htslxjkva",
+		"i",
+		"kcjkucmuxluclhmwvvjrxbeunj",
+		"kguxgbnznacxzhoyqcsyhzsvllku",
+		"n",
+		"nywnssma",
+		"prvgpobbbnrbxtbkfzxbm",
+		"pyjwlygxsarzzuahevuhruahwkrbw",
+		"rvnrvafvbwgxwqnygn",
+		"rxgopdlsrartowhuefcaov",
+		"vamcbqizpldtqplaxzrbkoonb",
+		"vxou",
+		"yedydvqd",
+		"zfilojtv",
+		"zlpbhkqlqgwwcteprvhzbohresbj",
+		"zng",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"exycoekcetmgfvynghwuonvji",
+		"exycxekcetmgfvynghwuonvjip",
+		"gm",
+		"htslxjkva+",
+		"htslxjkvab",
+		"htslxjkva~",
+		"nywssma",
+		"y",
+		"yedydvq!",
+		"yedydvqds",
+		"zfiloj",
+		"zfilojt",
+		"zfilojtvf",
+		"zngG",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountWordBoundaryBytes([]byte(s)); got != want {
+			t.Errorf("matchCountWordBoundaryBytes(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountWordBoundaryBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:\\b[a-z]{3,10}\\b)")
+	re.Longest()
+	for _, s := range []string{
+		"by",
+		"exycxekcetmgfvynghwuonvji",
+		"gmqdnerdtd",
+		"gngnwsjokhmcaetvmbvf",
+		"htslxjkva",
+		"i",
+		"kcjkucmuxluclhmwvvjrxbeunj",
+		"kguxgbnznacxzhoyqcsyhzsvllku",
+		"n",
+		"nywnssma",
+		"prvgpobbbnrbxtbkfzxbm",
+		"pyjwlygxsarzzuahevuhruahwkrbw",
+		"rvnrvafvbwgxwqnygn",
+		"rxgopdlsrartowhuefcaov",
+		"vamcbqizpldtqplaxzrbkoonb",
+		"vxou",
+		"yedydvqd",
+		"zfilojtv",
+		"zlpbhkqlqgwwcteprvhzbohresbj",
+		"zng",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountWordBoundaryBytes([]byte(s)); got != want {
+			t.Errorf("matchCountWordBoundaryBytes(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountWordBoundaryExpandedAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:\\b[a-z]{3,10}\\b)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"axrahburdu",
+		"beygn",
+		"cbqjswdt",
+		"cctk",
+		"emt",
+		"htjppli",
+		"hvq",
+		"nruieydn",
+		"pumapy",
+		"smm",
+		"srxz",
+		"stn",
+		"tja",
+		"tkuqvgcir",
+		"tusbq",
+		"ucmp",
+		"yedydudg",
+		"ywn",
+		"zngswrxx",
+		"zrbkoony",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"axrahburdu2",
+		"bygn",
+		"em",
+		"htjppi",
+		"htjppli)",
+		"hvqT",
+		"pukapy",
+		"srxz|",
+		"tkqvgcir",
+		"uc",
+		"uczp",
+		"ye",
+		"yedydudgW",
+		"zngs",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountWordBoundaryExpanded(s); got != want {
+			t.Errorf("matchCountWordBoundaryExpanded(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountWordBoundaryExpanded(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:\\b[a-z]{3,10}\\b)")
+	re.Longest()
+	for _, s := range []string{
+		"axrahburdu",
+		"beygn",
+		"cbqjswdt",
+		"cctk",
+		"emt",
+		"htjppli",
+		"hvq",
+		"nruieydn",
+		"pumapy",
+		"smm",
+		"srxz",
+		"stn",
+		"tja",
+		"tkuqvgcir",
+		"tusbq",
+		"ucmp",
+		"yedydudg",
+		"ywn",
+		"zngswrxx",
+		"zrbkoony",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountWordBoundaryExpanded(s); got != want {
+			t.Errorf("matchCountWordBoundaryExpanded(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
+
+func TestMatchCountWordBoundaryExpandedBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:\\b[a-z]{3,10}\\b)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"axrahburdu",
+		"beygn",
+		"cbqjswdt",
+		"cctk",
+		"emt",
+		"htjppli",
+		"hvq",
+		"nruieydn",
+		"pumapy",
+		"smm",
+		"srxz",
+		"stn",
+		"tja",
+		"tkuqvgcir",
+		"tusbq",
+		"ucmp",
+		"yedydudg",
+		"ywn",
+		"zngswrxx",
+		"zrbkoony",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"axrahburdu2",
+		"bygn",
+		"em",
+		"htjppi",
+		"htjppli)",
+		"hvqT",
+		"pukapy",
+		"srxz|",
+		"tkqvgcir",
+		"uc",
+		"uczp",
+		"ye",
+		"yedydudgW",
+		"zngs",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountWordBoundaryExpandedBytes([]byte(s)); got != want {
+			t.Errorf("matchCountWordBoundaryExpandedBytes(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchCountWordBoundaryExpandedBytes(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:\\b[a-z]{3,10}\\b)")
+	re.Longest()
+	for _, s := range []string{
+		"axrahburdu",
+		"beygn",
+		"cbqjswdt",
+		"cctk",
+		"emt",
+		"htjppli",
+		"hvq",
+		"nruieydn",
+		"pumapy",
+		"smm",
+		"srxz",
+		"stn",
+		"tja",
+		"tkuqvgcir",
+		"tusbq",
+		"ucmp",
+		"yedydudg",
+		"ywn",
+		"zngswrxx",
+		"zrbkoony",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchCountWordBoundaryExpandedBytes([]byte(s)); got != want {
+			t.Errorf("matchCountWordBoundaryExpandedBytes(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
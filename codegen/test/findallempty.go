@@ -0,0 +1,189 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import "unicode/utf8"
+
+func matchFindAllEmpty(s string, n int) [][2]int {
+	var matches [][2]int
+	matchFindAllEmptyFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchFindAllEmptyFunc(s string, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = i
+	f1:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f1
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = end
+		i = end
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r2
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r2
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchFindAllEmptyBytes(s []byte, n int) [][2]int {
+	var matches [][2]int
+	matchFindAllEmptyBytesFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchFindAllEmptyBytesFunc(s []byte, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = i
+	f1:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r == 97:
+			end = i
+			goto f1
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = end
+		i = end
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r2
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+			goto r2
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRune(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatchFindAllFirstAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a|ab")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"2",
+		"I",
+		"K",
+		"R",
+		"]",
+		"a)",
+		"a2",
+		"aK",
+		"aT",
+		"aj",
+		"at",
+		"k",
+		"o",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+		"xax",
+		"a a",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllFirst(s, n)); got != want {
+				t.Errorf("matchFindAllFirst(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchFindAllFirst(f *testing.F) {
+	re := regexp.MustCompile("a|ab")
+
+	for _, s := range []string{
+		"a",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllFirst(s, n)); got != want {
+				t.Errorf("matchFindAllFirst(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchFindAllFirstBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a|ab")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"2",
+		"I",
+		"K",
+		"R",
+		"]",
+		"a)",
+		"a2",
+		"aK",
+		"aT",
+		"aj",
+		"at",
+		"k",
+		"o",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+		"xax",
+		"a a",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllFirstBytes([]byte(s), n)); got != want {
+				t.Errorf("matchFindAllFirstBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchFindAllFirstBytes(f *testing.F) {
+	re := regexp.MustCompile("a|ab")
+
+	for _, s := range []string{
+		"a",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllFirstBytes([]byte(s), n)); got != want {
+				t.Errorf("matchFindAllFirstBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,225 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+func matchFindAllLazy(s string, n int) [][2]int {
+	var matches [][2]int
+	matchFindAllLazyFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchFindAllLazyFunc(s string, n int, yield func(start, end int) bool) {
+	if strings.IndexByte(s, 'y') < 0 {
+		return
+	}
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 119 || r == 120 || r >= 122:
+			goto f1
+		case r == 121:
+			end = i
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 121:
+			start = i
+			goto r2
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 120:
+			start = i
+			goto r3
+		}
+		return
+	r3:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 120:
+			start = i
+			goto r3
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchFindAllLazyBytes(s []byte, n int) [][2]int {
+	var matches [][2]int
+	matchFindAllLazyBytesFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchFindAllLazyBytesFunc(s []byte, n int, yield func(start, end int) bool) {
+	if bytes.IndexByte(s, 'y') < 0 {
+		return
+	}
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 119 || r == 120 || r >= 122:
+			goto f1
+		case r == 121:
+			end = i
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 121:
+			start = i
+			goto r2
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 120:
+			start = i
+			goto r3
+		}
+		return
+	r3:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 120:
+			start = i
+			goto r3
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRune(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatchFindAllLazyAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("x*?y")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"xxxxxxxxxxy",
+		"xxxxxxy",
+		"xxxxxy",
+		"xxxxy",
+		"xxxy",
+		"xxy",
+		"xy",
+		"y",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"!xxxxy",
+		"%y",
+		"x$xxxy",
+		"xxpxy",
+		"xxxx",
+		"xxxxxxhxxxy",
+		"xxxxxxxxxxy;",
+		"xxxxxxxxxy",
+		"xxxxxxy3",
+		"xxxxxxyT",
+		"xxxxxy!",
+		"xxxyU",
+		"yK",
+		"zxy",
+		"é",
+		"日本",
+		"\xff",
+		"xxxxxxxxxxxyx",
+		"xxxxxxxxxxy xxxxxxxxxxy",
+		"xxxxxxxyx",
+		"xxxxxxy xxxxxxy",
+		"xxxxxxyx",
+		"xxxxxy xxxxxy",
+		"xxxxxyx",
+		"xxxxy xxxxy",
+		"xxxxyx",
+		"xxxy xxxy",
+		"xxxyx",
+		"xxy xxy",
+		"xxyx",
+		"xy xy",
+		"xyx",
+		"y y",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllLazy(s, n)); got != want {
+				t.Errorf("matchFindAllLazy(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchFindAllLazy(f *testing.F) {
+	re := regexp.MustCompile("x*?y")
+
+	for _, s := range []string{
+		"xxxxxxxxxxy",
+		"xxxxxxy",
+		"xxxxxy",
+		"xxxxy",
+		"xxxy",
+		"xxy",
+		"xy",
+		"y",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllLazy(s, n)); got != want {
+				t.Errorf("matchFindAllLazy(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchFindAllLazyBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("x*?y")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"xxxxxxxxxxy",
+		"xxxxxxy",
+		"xxxxxy",
+		"xxxxy",
+		"xxxy",
+		"xxy",
+		"xy",
+		"y",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"!xxxxy",
+		"%y",
+		"x$xxxy",
+		"xxpxy",
+		"xxxx",
+		"xxxxxxhxxxy",
+		"xxxxxxxxxxy;",
+		"xxxxxxxxxy",
+		"xxxxxxy3",
+		"xxxxxxyT",
+		"xxxxxy!",
+		"xxxyU",
+		"yK",
+		"zxy",
+		"é",
+		"日本",
+		"\xff",
+		"xxxxxxxxxxxyx",
+		"xxxxxxxxxxy xxxxxxxxxxy",
+		"xxxxxxxyx",
+		"xxxxxxy xxxxxxy",
+		"xxxxxxyx",
+		"xxxxxy xxxxxy",
+		"xxxxxyx",
+		"xxxxy xxxxy",
+		"xxxxyx",
+		"xxxy xxxy",
+		"xxxyx",
+		"xxy xxy",
+		"xxyx",
+		"xy xy",
+		"xyx",
+		"y y",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllLazyBytes([]byte(s), n)); got != want {
+				t.Errorf("matchFindAllLazyBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchFindAllLazyBytes(f *testing.F) {
+	re := regexp.MustCompile("x*?y")
+
+	for _, s := range []string{
+		"xxxxxxxxxxy",
+		"xxxxxxy",
+		"xxxxxy",
+		"xxxxy",
+		"xxxy",
+		"xxy",
+		"xy",
+		"y",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllLazyBytes([]byte(s), n)); got != want {
+				t.Errorf("matchFindAllLazyBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,123 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatchFindAllLazyEmptyAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("(.)??")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		".",
+		"2",
+		"3",
+		"4",
+		"6",
+		"C",
+		"E",
+		"G",
+		"K",
+		"L",
+		"R",
+		"]",
+		"g",
+		"p",
+		"{",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllLazyEmpty(s, n)); got != want {
+				t.Errorf("matchFindAllLazyEmpty(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchFindAllLazyEmpty(f *testing.F) {
+	re := regexp.MustCompile("(.)??")
+
+	for _, s := range []string{
+		"",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllLazyEmpty(s, n)); got != want {
+				t.Errorf("matchFindAllLazyEmpty(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchFindAllLazyEmptyBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("(.)??")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		".",
+		"2",
+		"3",
+		"4",
+		"6",
+		"C",
+		"E",
+		"G",
+		"K",
+		"L",
+		"R",
+		"]",
+		"g",
+		"p",
+		"{",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllLazyEmptyBytes([]byte(s), n)); got != want {
+				t.Errorf("matchFindAllLazyEmptyBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchFindAllLazyEmptyBytes(f *testing.F) {
+	re := regexp.MustCompile("(.)??")
+
+	for _, s := range []string{
+		"",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllLazyEmptyBytes([]byte(s), n)); got != want {
+				t.Errorf("matchFindAllLazyEmptyBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,465 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import "unicode/utf8"
+
+func matchFindAllLines(s string, n int) [][2]int {
+	var matches [][2]int
+	matchFindAllLinesFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchFindAllLinesFunc(s string, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		switch {
+		case i == 0 || s[i-1] == '\n':
+			goto f2
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 1114111:
+			goto f1
+		}
+		goto reverse
+	f2:
+		switch {
+		case i == len(s) || s[i] == '\n':
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto f4
+		case r == 10:
+			goto f1
+		}
+		goto reverse
+	f3:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto f5
+		}
+		goto reverse
+	f4:
+		switch {
+		case i == len(s) || s[i] == '\n':
+			end = i
+			goto f3
+		case i == 0 || s[i-1] == '\n':
+			goto f6
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto f4
+		case r == 10:
+			goto f1
+		}
+		goto reverse
+	f5:
+		switch {
+		case i == len(s) || s[i] == '\n':
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto f5
+		}
+		goto reverse
+	f6:
+		switch {
+		case i == len(s) || s[i] == '\n':
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto f4
+		case r == 10:
+			goto f1
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		switch {
+		case i == len(s) || s[i] == '\n':
+			goto r2
+		}
+		return
+	r2:
+		switch {
+		case i == 0 || s[i-1] == '\n':
+			start = i
+			goto r3
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r4
+		}
+		return
+	r3:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r5
+		}
+		return
+	r4:
+		switch {
+		case i == 0 || s[i-1] == '\n':
+			start = i
+			goto r6
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r4
+		}
+		return
+	r5:
+		switch {
+		case i == 0 || s[i-1] == '\n':
+			start = i
+			goto r6
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r5
+		}
+		return
+	r6:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r5
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchFindAllLinesBytes(s []byte, n int) [][2]int {
+	var matches [][2]int
+	matchFindAllLinesBytesFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchFindAllLinesBytesFunc(s []byte, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		switch {
+		case i == 0 || s[i-1] == '\n':
+			goto f2
+		}
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 1114111:
+			goto f1
+		}
+		goto reverse
+	f2:
+		switch {
+		case i == len(s) || s[i] == '\n':
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto f4
+		case r == 10:
+			goto f1
+		}
+		goto reverse
+	f3:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto f5
+		}
+		goto reverse
+	f4:
+		switch {
+		case i == len(s) || s[i] == '\n':
+			end = i
+			goto f3
+		case i == 0 || s[i-1] == '\n':
+			goto f6
+		}
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto f4
+		case r == 10:
+			goto f1
+		}
+		goto reverse
+	f5:
+		switch {
+		case i == len(s) || s[i] == '\n':
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto f5
+		}
+		goto reverse
+	f6:
+		switch {
+		case i == len(s) || s[i] == '\n':
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto f4
+		case r == 10:
+			goto f1
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		switch {
+		case i == len(s) || s[i] == '\n':
+			goto r2
+		}
+		return
+	r2:
+		switch {
+		case i == 0 || s[i-1] == '\n':
+			start = i
+			goto r3
+		}
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r4
+		}
+		return
+	r3:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r5
+		}
+		return
+	r4:
+		switch {
+		case i == 0 || s[i-1] == '\n':
+			start = i
+			goto r6
+		}
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r4
+		}
+		return
+	r5:
+		switch {
+		case i == 0 || s[i-1] == '\n':
+			start = i
+			goto r6
+		}
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r5
+		}
+		return
+	r6:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r5
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRune(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
@@ -0,0 +1,275 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatchFindAllLinesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("(?m)^.*$")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"\a",
+		"\"[tU",
+		"({~e",
+		"5*",
+		"67\t*0",
+		"9\x03[VQX",
+		"<R`T\U0010f615G=a\x04I?\"\U0010e620",
+		"C^\U0007f489k",
+		"GH=z",
+		"N",
+		"^\x04f",
+		"aDx",
+		"k\U000c56c62",
+		"m\x06",
+		"m,",
+		"n",
+		"o* J",
+		"t\U000c008djq",
+		"\U0010907a",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"({~ey",
+		"*",
+		"5*&",
+		"5*b",
+		"67\t*0U",
+		"67\t0",
+		"9\x03[VQr",
+		"[",
+		"aQx",
+		"k\U000c56c6",
+		"m",
+		"o* ",
+		"o* j",
+		"t",
+		"x^\U0007f489k",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"x\ax",
+		"\a \a",
+		"x\"[tUx",
+		"\"[tU \"[tU",
+		"x({~ex",
+		"({~e ({~e",
+		"x5*x",
+		"5* 5*",
+		"x67\t*0x",
+		"67\t*0 67\t*0",
+		"x9\x03[VQXx",
+		"9\x03[VQX 9\x03[VQX",
+		"x<R`T\U0010f615G=a\x04I?\"\U0010e620x",
+		"<R`T\U0010f615G=a\x04I?\"\U0010e620 <R`T\U0010f615G=a\x04I?\"\U0010e620",
+		"xC^\U0007f489kx",
+		"C^\U0007f489k C^\U0007f489k",
+		"xGH=zx",
+		"GH=z GH=z",
+		"xNx",
+		"N N",
+		"x^\x04fx",
+		"^\x04f ^\x04f",
+		"xaDxx",
+		"aDx aDx",
+		"xk\U000c56c62x",
+		"k\U000c56c62 k\U000c56c62",
+		"xm\x06x",
+		"m\x06 m\x06",
+		"xm,x",
+		"m, m,",
+		"xnx",
+		"n n",
+		"xo* Jx",
+		"o* J o* J",
+		"xt\U000c008djqx",
+		"t\U000c008djq t\U000c008djq",
+		"x\U0010907ax",
+		"\U0010907a \U0010907a",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllLines(s, n)); got != want {
+				t.Errorf("matchFindAllLines(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchFindAllLines(f *testing.F) {
+	re := regexp.MustCompile("(?m)^.*$")
+
+	for _, s := range []string{
+		"",
+		"\a",
+		"\"[tU",
+		"({~e",
+		"5*",
+		"67\t*0",
+		"9\x03[VQX",
+		"<R`T\U0010f615G=a\x04I?\"\U0010e620",
+		"C^\U0007f489k",
+		"GH=z",
+		"N",
+		"^\x04f",
+		"aDx",
+		"k\U000c56c62",
+		"m\x06",
+		"m,",
+		"n",
+		"o* J",
+		"t\U000c008djq",
+		"\U0010907a",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllLines(s, n)); got != want {
+				t.Errorf("matchFindAllLines(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchFindAllLinesBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("(?m)^.*$")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"\a",
+		"\"[tU",
+		"({~e",
+		"5*",
+		"67\t*0",
+		"9\x03[VQX",
+		"<R`T\U0010f615G=a\x04I?\"\U0010e620",
+		"C^\U0007f489k",
+		"GH=z",
+		"N",
+		"^\x04f",
+		"aDx",
+		"k\U000c56c62",
+		"m\x06",
+		"m,",
+		"n",
+		"o* J",
+		"t\U000c008djq",
+		"\U0010907a",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"({~ey",
+		"*",
+		"5*&",
+		"5*b",
+		"67\t*0U",
+		"67\t0",
+		"9\x03[VQr",
+		"[",
+		"aQx",
+		"k\U000c56c6",
+		"m",
+		"o* ",
+		"o* j",
+		"t",
+		"x^\U0007f489k",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"x\ax",
+		"\a \a",
+		"x\"[tUx",
+		"\"[tU \"[tU",
+		"x({~ex",
+		"({~e ({~e",
+		"x5*x",
+		"5* 5*",
+		"x67\t*0x",
+		"67\t*0 67\t*0",
+		"x9\x03[VQXx",
+		"9\x03[VQX 9\x03[VQX",
+		"x<R`T\U0010f615G=a\x04I?\"\U0010e620x",
+		"<R`T\U0010f615G=a\x04I?\"\U0010e620 <R`T\U0010f615G=a\x04I?\"\U0010e620",
+		"xC^\U0007f489kx",
+		"C^\U0007f489k C^\U0007f489k",
+		"xGH=zx",
+		"GH=z GH=z",
+		"xNx",
+		"N N",
+		"x^\x04fx",
+		"^\x04f ^\x04f",
+		"xaDxx",
+		"aDx aDx",
+		"xk\U000c56c62x",
+		"k\U000c56c62 k\U000c56c62",
+		"xm\x06x",
+		"m\x06 m\x06",
+		"xm,x",
+		"m, m,",
+		"xnx",
+		"n n",
+		"xo* Jx",
+		"o* J o* J",
+		"xt\U000c008djqx",
+		"t\U000c008djq t\U000c008djq",
+		"x\U0010907ax",
+		"\U0010907a \U0010907a",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllLinesBytes([]byte(s), n)); got != want {
+				t.Errorf("matchFindAllLinesBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchFindAllLinesBytes(f *testing.F) {
+	re := regexp.MustCompile("(?m)^.*$")
+
+	for _, s := range []string{
+		"",
+		"\a",
+		"\"[tU",
+		"({~e",
+		"5*",
+		"67\t*0",
+		"9\x03[VQX",
+		"<R`T\U0010f615G=a\x04I?\"\U0010e620",
+		"C^\U0007f489k",
+		"GH=z",
+		"N",
+		"^\x04f",
+		"aDx",
+		"k\U000c56c62",
+		"m\x06",
+		"m,",
+		"n",
+		"o* J",
+		"t\U000c008djq",
+		"\U0010907a",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllLinesBytes([]byte(s), n)); got != want {
+				t.Errorf("matchFindAllLinesBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
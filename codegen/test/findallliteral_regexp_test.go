@@ -0,0 +1,123 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatchFindAllLiteralAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("ab")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"ab",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"#b",
+		"Ab",
+		"Bb",
+		"a",
+		"a@",
+		"a_",
+		"ab ",
+		"ab)",
+		"ab/",
+		"ab;",
+		"abF",
+		"abb",
+		"as",
+		"b",
+		"é",
+		"日本",
+		"\xff",
+		"xabx",
+		"ab ab",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllLiteral(s, n)); got != want {
+				t.Errorf("matchFindAllLiteral(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchFindAllLiteral(f *testing.F) {
+	re := regexp.MustCompile("ab")
+
+	for _, s := range []string{
+		"ab",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllLiteral(s, n)); got != want {
+				t.Errorf("matchFindAllLiteral(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchFindAllLiteralBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("ab")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"ab",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"#b",
+		"Ab",
+		"Bb",
+		"a",
+		"a@",
+		"a_",
+		"ab ",
+		"ab)",
+		"ab/",
+		"ab;",
+		"abF",
+		"abb",
+		"as",
+		"b",
+		"é",
+		"日本",
+		"\xff",
+		"xabx",
+		"ab ab",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllLiteralBytes([]byte(s), n)); got != want {
+				t.Errorf("matchFindAllLiteralBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchFindAllLiteralBytes(f *testing.F) {
+	re := regexp.MustCompile("ab")
+
+	for _, s := range []string{
+		"ab",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllLiteralBytes([]byte(s), n)); got != want {
+				t.Errorf("matchFindAllLiteralBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,275 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatchFindAllWordsAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("[a-z]+")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"alcs",
+		"b",
+		"bymul",
+		"d",
+		"dhdm",
+		"eqez",
+		"gdknlm",
+		"h",
+		"ja",
+		"m",
+		"mld",
+		"nc",
+		"nu",
+		"owf",
+		"pc",
+		"quihu",
+		"s",
+		"tls",
+		"u",
+		"wq",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"a7cs",
+		"al`s",
+		"als",
+		"dhdm[",
+		"dhdmm",
+		"di",
+		"eqez*",
+		"gdkn\\m",
+		"hdm",
+		"nE",
+		"t",
+		"tls:",
+		"ts",
+		"w",
+		"é",
+		"日本",
+		"\xff",
+		"xalcsx",
+		"alcs alcs",
+		"xbx",
+		"b b",
+		"xbymulx",
+		"bymul bymul",
+		"xdx",
+		"d d",
+		"xdhdmx",
+		"dhdm dhdm",
+		"xeqezx",
+		"eqez eqez",
+		"xgdknlmx",
+		"gdknlm gdknlm",
+		"xhx",
+		"h h",
+		"xjax",
+		"ja ja",
+		"xmx",
+		"m m",
+		"xmldx",
+		"mld mld",
+		"xncx",
+		"nc nc",
+		"xnux",
+		"nu nu",
+		"xowfx",
+		"owf owf",
+		"xpcx",
+		"pc pc",
+		"xquihux",
+		"quihu quihu",
+		"xsx",
+		"s s",
+		"xtlsx",
+		"tls tls",
+		"xux",
+		"u u",
+		"xwqx",
+		"wq wq",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllWords(s, n)); got != want {
+				t.Errorf("matchFindAllWords(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchFindAllWords(f *testing.F) {
+	re := regexp.MustCompile("[a-z]+")
+
+	for _, s := range []string{
+		"alcs",
+		"b",
+		"bymul",
+		"d",
+		"dhdm",
+		"eqez",
+		"gdknlm",
+		"h",
+		"ja",
+		"m",
+		"mld",
+		"nc",
+		"nu",
+		"owf",
+		"pc",
+		"quihu",
+		"s",
+		"tls",
+		"u",
+		"wq",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllWords(s, n)); got != want {
+				t.Errorf("matchFindAllWords(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchFindAllWordsBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("[a-z]+")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"alcs",
+		"b",
+		"bymul",
+		"d",
+		"dhdm",
+		"eqez",
+		"gdknlm",
+		"h",
+		"ja",
+		"m",
+		"mld",
+		"nc",
+		"nu",
+		"owf",
+		"pc",
+		"quihu",
+		"s",
+		"tls",
+		"u",
+		"wq",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"a7cs",
+		"al`s",
+		"als",
+		"dhdm[",
+		"dhdmm",
+		"di",
+		"eqez*",
+		"gdkn\\m",
+		"hdm",
+		"nE",
+		"t",
+		"tls:",
+		"ts",
+		"w",
+		"é",
+		"日本",
+		"\xff",
+		"xalcsx",
+		"alcs alcs",
+		"xbx",
+		"b b",
+		"xbymulx",
+		"bymul bymul",
+		"xdx",
+		"d d",
+		"xdhdmx",
+		"dhdm dhdm",
+		"xeqezx",
+		"eqez eqez",
+		"xgdknlmx",
+		"gdknlm gdknlm",
+		"xhx",
+		"h h",
+		"xjax",
+		"ja ja",
+		"xmx",
+		"m m",
+		"xmldx",
+		"mld mld",
+		"xncx",
+		"nc nc",
+		"xnux",
+		"nu nu",
+		"xowfx",
+		"owf owf",
+		"xpcx",
+		"pc pc",
+		"xquihux",
+		"quihu quihu",
+		"xsx",
+		"s s",
+		"xtlsx",
+		"tls tls",
+		"xux",
+		"u u",
+		"xwqx",
+		"wq wq",
+	} {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllWordsBytes([]byte(s), n)); got != want {
+				t.Errorf("matchFindAllWordsBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchFindAllWordsBytes(f *testing.F) {
+	re := regexp.MustCompile("[a-z]+")
+
+	for _, s := range []string{
+		"alcs",
+		"b",
+		"bymul",
+		"d",
+		"dhdm",
+		"eqez",
+		"gdknlm",
+		"h",
+		"ja",
+		"m",
+		"mld",
+		"nc",
+		"nu",
+		"owf",
+		"pc",
+		"quihu",
+		"s",
+		"tls",
+		"u",
+		"wq",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2} {
+			want := fmt.Sprint(re.FindAllStringIndex(s, n))
+			if got := fmt.Sprint(matchFindAllWordsBytes([]byte(s), n)); got != want {
+				t.Errorf("matchFindAllWordsBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,71 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchIgnoreCase1AgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:(?i)aZ)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"AZ",
+		"Az",
+		"aZ",
+		"az",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"#Z",
+		"A",
+		"A@",
+		"AZ)",
+		"Az ",
+		"Azb",
+		"Bz",
+		"Z",
+		"a",
+		"aZ/",
+		"aZF",
+		"a_",
+		"as",
+		"az;",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchIgnoreCase1(s); got != want {
+			t.Errorf("matchIgnoreCase1(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchIgnoreCase1(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:(?i)aZ)")
+	re.Longest()
+	for _, s := range []string{
+		"AZ",
+		"Az",
+		"aZ",
+		"az",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchIgnoreCase1(s); got != want {
+			t.Errorf("matchIgnoreCase1(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchIgnoreCase2AgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:(?i)[a-z])")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"A",
+		"F",
+		"H",
+		"J",
+		"O",
+		"S",
+		"U",
+		"b",
+		"c",
+		"d",
+		"h",
+		"i",
+		"l",
+		"n",
+		"p",
+		"q",
+		"s",
+		"w",
+		"ſ",
+		"K",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		">",
+		"FT",
+		"Fk",
+		"G",
+		"JA",
+		"OY",
+		"a",
+		"m",
+		"n>",
+		"ny",
+		"sP",
+		"z",
+		"}",
+		"é",
+		"ſp",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchIgnoreCase2(s); got != want {
+			t.Errorf("matchIgnoreCase2(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchIgnoreCase2(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:(?i)[a-z])")
+	re.Longest()
+	for _, s := range []string{
+		"A",
+		"F",
+		"H",
+		"J",
+		"O",
+		"S",
+		"U",
+		"b",
+		"c",
+		"d",
+		"h",
+		"i",
+		"l",
+		"n",
+		"p",
+		"q",
+		"s",
+		"w",
+		"ſ",
+		"K",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchIgnoreCase2(s); got != want {
+			t.Errorf("matchIgnoreCase2(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
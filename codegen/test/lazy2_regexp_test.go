@@ -0,0 +1,67 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchLazy2AgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a??b)")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"ab",
+		"b",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"'",
+		"a",
+		"a4",
+		"aW",
+		"abK",
+		"abQ",
+		"abf",
+		"abm",
+		"abn",
+		"ag",
+		"b(",
+		"bA",
+		"b}",
+		"|",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchLazy2(s); got != want {
+			t.Errorf("matchLazy2(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchLazy2(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a??b)")
+
+	for _, s := range []string{
+		"ab",
+		"b",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchLazy2(s); got != want {
+			t.Errorf("matchLazy2(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchLazy3AgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a*?)")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		".",
+		"2",
+		"3",
+		"4",
+		"6",
+		"C",
+		"E",
+		"G",
+		"K",
+		"L",
+		"R",
+		"]",
+		"g",
+		"p",
+		"{",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchLazy3(s); got != want {
+			t.Errorf("matchLazy3(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchLazy3(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a*?)")
+
+	for _, s := range []string{
+		"",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchLazy3(s); got != want {
+			t.Errorf("matchLazy3(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchLazy4AgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a*?b)")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"aaaaaaaaaab",
+		"aaaaaab",
+		"aaaaab",
+		"aaaab",
+		"aaab",
+		"aab",
+		"ab",
+		"b",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"!aaaab",
+		"%b",
+		"a$aaab",
+		"aaaa",
+		"aaaaaaaaaab;",
+		"aaaaaaaaab",
+		"aaaaaab3",
+		"aaaaaabT",
+		"aaaaaahaaab",
+		"aaaaab!",
+		"aaabU",
+		"aapab",
+		"bK",
+		"zab",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchLazy4(s); got != want {
+			t.Errorf("matchLazy4(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchLazy4(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a*?b)")
+
+	for _, s := range []string{
+		"aaaaaaaaaab",
+		"aaaaaab",
+		"aaaaab",
+		"aaaab",
+		"aaab",
+		"aab",
+		"ab",
+		"b",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchLazy4(s); got != want {
+			t.Errorf("matchLazy4(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchLazy5AgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a+?)")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"2",
+		"I",
+		"K",
+		"R",
+		"]",
+		"a)",
+		"a2",
+		"aK",
+		"aT",
+		"aj",
+		"at",
+		"k",
+		"o",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchLazy5(s); got != want {
+			t.Errorf("matchLazy5(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchLazy5(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a+?)")
+
+	for _, s := range []string{
+		"a",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchLazy5(s); got != want {
+			t.Errorf("matchLazy5(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
@@ -9,58 +9,28 @@ func matchLazy6(s string) (end int) {
 	var r rune
 	var rlen int
 	i := 0
-	lazy := false
-	type jmp struct{ s, i int }
-	var lazyArr [1]jmp
-	lazyStack := lazyArr[:0]
 	_, _, _ = r, rlen, i
 	r, rlen = utf8.DecodeRuneInString(s[i:])
 	if rlen == 0 {
-		goto bt
+		return
 	}
 	i += rlen
 	switch {
 	case r == 97:
 		goto s2
 	}
-	goto bt
+	return
 s2:
-	if lazy {
-		lazy = false
-		goto s3
-	}
-	lazyStack = append(lazyStack, jmp{s: 2, i: i})
-	r, rlen = utf8.DecodeRuneInString(s[i:])
-	if rlen == 0 {
-		goto bt
-	}
-	i += rlen
-	switch {
-	case r == 98:
-		end = i
-	}
-	goto bt
-s3:
 	r, rlen = utf8.DecodeRuneInString(s[i:])
 	if rlen == 0 {
-		goto bt
+		return
 	}
 	i += rlen
 	switch {
 	case r == 97:
 		goto s2
-	}
-bt:
-	if end >= 0 || len(lazyStack) == 0 {
-		return
-	}
-	var to jmp
-	to, lazyStack = lazyStack[len(lazyStack)-1], lazyStack[:len(lazyStack)-1]
-	lazy = true
-	i = to.i
-	switch to.s {
-	case 2:
-		goto s2
+	case r == 98:
+		end = i
 	}
 	return
 }
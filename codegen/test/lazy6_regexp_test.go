@@ -0,0 +1,77 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchLazy6AgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a+?b)")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"aaaaaaaaaab",
+		"aaaaaab",
+		"aaaaab",
+		"aaaab",
+		"aaab",
+		"aab",
+		"ab",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"Daaab",
+		"a",
+		"a;aaaab",
+		"aa",
+		"aaaaa",
+		"aaaaaa5",
+		"aaaaaaaaaab~",
+		"aaaaaab$",
+		"aaaabG",
+		"aaabS",
+		"aaabh",
+		"aab ",
+		"ab`",
+		"b",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchLazy6(s); got != want {
+			t.Errorf("matchLazy6(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchLazy6(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a+?b)")
+
+	for _, s := range []string{
+		"aaaaaaaaaab",
+		"aaaaaab",
+		"aaaaab",
+		"aaaab",
+		"aaab",
+		"aab",
+		"ab",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchLazy6(s); got != want {
+			t.Errorf("matchLazy6(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
@@ -9,69 +9,39 @@ func matchLazy7(s string) (end int) {
 	var r rune
 	var rlen int
 	i := 0
-	lazy := false
-	type jmp struct{ s, i int }
-	var lazyArr [1]jmp
-	lazyStack := lazyArr[:0]
 	_, _, _ = r, rlen, i
 	r, rlen = utf8.DecodeRuneInString(s[i:])
 	if rlen == 0 {
-		goto bt
+		return
 	}
 	i += rlen
 	switch {
 	case r == 97:
 		goto s2
 	}
-	goto bt
+	return
 s2:
-	if lazy {
-		lazy = false
-		goto s3
-	}
-	lazyStack = append(lazyStack, jmp{s: 2, i: i})
 	r, rlen = utf8.DecodeRuneInString(s[i:])
 	if rlen == 0 {
-		goto bt
+		return
 	}
 	i += rlen
 	switch {
+	case r == 98:
+		goto s3
 	case r == 99:
 		end = i
 	}
-	goto bt
+	return
 s3:
 	r, rlen = utf8.DecodeRuneInString(s[i:])
 	if rlen == 0 {
-		goto bt
-	}
-	i += rlen
-	switch {
-	case r == 98:
-		goto s4
-	}
-	goto bt
-s4:
-	r, rlen = utf8.DecodeRuneInString(s[i:])
-	if rlen == 0 {
-		goto bt
+		return
 	}
 	i += rlen
 	switch {
 	case r == 99:
 		end = i
 	}
-bt:
-	if end >= 0 || len(lazyStack) == 0 {
-		return
-	}
-	var to jmp
-	to, lazyStack = lazyStack[len(lazyStack)-1], lazyStack[:len(lazyStack)-1]
-	lazy = true
-	i = to.i
-	switch to.s {
-	case 2:
-		goto s2
-	}
 	return
 }
@@ -0,0 +1,67 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchLazy7AgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:ab??c)")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"abc",
+		"ac",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"'c",
+		":c",
+		"a",
+		"a!",
+		"a!c",
+		"a4c",
+		"ab",
+		"ab/",
+		"acV",
+		"ach",
+		"acv",
+		"bc",
+		"c",
+		"ubc",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchLazy7(s); got != want {
+			t.Errorf("matchLazy7(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchLazy7(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:ab??c)")
+
+	for _, s := range []string{
+		"abc",
+		"ac",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchLazy7(s); got != want {
+			t.Errorf("matchLazy7(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
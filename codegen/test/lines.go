@@ -0,0 +1,1358 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import "unicode/utf8"
+
+func matchLinesCR(s string, n int) [][2]int {
+	var matches [][2]int
+	matchLinesCRFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchLinesCRFunc(s string, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		switch {
+		case i == 0 || s[i-1] == '\r':
+			goto f2
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 1114111:
+			goto f1
+		}
+		goto reverse
+	f2:
+		switch {
+		case i == len(s) || s[i] == '\r':
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 12 || r >= 14:
+			goto f4
+		case r == 13:
+			goto f1
+		}
+		goto reverse
+	f3:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 12 || r >= 14:
+			goto f5
+		}
+		goto reverse
+	f4:
+		switch {
+		case i == len(s) || s[i] == '\r':
+			end = i
+			goto f3
+		case i == 0 || s[i-1] == '\r':
+			goto f6
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 12 || r >= 14:
+			goto f4
+		case r == 13:
+			goto f1
+		}
+		goto reverse
+	f5:
+		switch {
+		case i == len(s) || s[i] == '\r':
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 12 || r >= 14:
+			goto f5
+		}
+		goto reverse
+	f6:
+		switch {
+		case i == len(s) || s[i] == '\r':
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 12 || r >= 14:
+			goto f4
+		case r == 13:
+			goto f1
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		switch {
+		case i == len(s) || s[i] == '\r':
+			goto r2
+		}
+		return
+	r2:
+		switch {
+		case i == 0 || s[i-1] == '\r':
+			start = i
+			goto r3
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 12 || r >= 14:
+			goto r4
+		}
+		return
+	r3:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 12 || r >= 14:
+			goto r5
+		}
+		return
+	r4:
+		switch {
+		case i == 0 || s[i-1] == '\r':
+			start = i
+			goto r6
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 12 || r >= 14:
+			goto r4
+		}
+		return
+	r5:
+		switch {
+		case i == 0 || s[i-1] == '\r':
+			start = i
+			goto r6
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 12 || r >= 14:
+			goto r5
+		}
+		return
+	r6:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 12 || r >= 14:
+			goto r5
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchLinesCRLF(s string, n int) [][2]int {
+	var matches [][2]int
+	matchLinesCRLFFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchLinesCRLFFunc(s string, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		switch {
+		case i == 0 || i >= 2 && s[i-2] == '\r' && s[i-1] == '\n':
+			goto f2
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 1114111:
+			goto f1
+		}
+		goto reverse
+	f2:
+		switch {
+		case i == len(s) || s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n':
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto f4
+		case r == 10 || r == 13:
+			goto f1
+		}
+		goto reverse
+	f3:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto f5
+		}
+		goto reverse
+	f4:
+		switch {
+		case i == len(s) || s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n':
+			end = i
+			goto f3
+		case i == 0 || i >= 2 && s[i-2] == '\r' && s[i-1] == '\n':
+			goto f6
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto f4
+		case r == 10 || r == 13:
+			goto f1
+		}
+		goto reverse
+	f5:
+		switch {
+		case i == len(s) || s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n':
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto f5
+		}
+		goto reverse
+	f6:
+		switch {
+		case i == len(s) || s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n':
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto f4
+		case r == 10 || r == 13:
+			goto f1
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		switch {
+		case i == len(s) || s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n':
+			goto r2
+		}
+		return
+	r2:
+		switch {
+		case i == 0 || i >= 2 && s[i-2] == '\r' && s[i-1] == '\n':
+			start = i
+			goto r3
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto r4
+		}
+		return
+	r3:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto r5
+		}
+		return
+	r4:
+		switch {
+		case i == 0 || i >= 2 && s[i-2] == '\r' && s[i-1] == '\n':
+			start = i
+			goto r6
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto r4
+		}
+		return
+	r5:
+		switch {
+		case i == 0 || i >= 2 && s[i-2] == '\r' && s[i-1] == '\n':
+			start = i
+			goto r6
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto r5
+		}
+		return
+	r6:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto r5
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchLinesLFCRLF(s string, n int) [][2]int {
+	var matches [][2]int
+	matchLinesLFCRLFFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchLinesLFCRLFFunc(s string, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		switch {
+		case i == 0 || s[i-1] == '\n':
+			goto f2
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 1114111:
+			goto f1
+		}
+		goto reverse
+	f2:
+		switch {
+		case i == len(s) || s[i] == '\n' && (i == 0 || s[i-1] != '\r') || s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n':
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto f4
+		case r == 10 || r == 13:
+			goto f1
+		}
+		goto reverse
+	f3:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto f5
+		}
+		goto reverse
+	f4:
+		switch {
+		case i == len(s) || s[i] == '\n' && (i == 0 || s[i-1] != '\r') || s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n':
+			end = i
+			goto f3
+		case i == 0 || s[i-1] == '\n':
+			goto f6
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto f4
+		case r == 10 || r == 13:
+			goto f1
+		}
+		goto reverse
+	f5:
+		switch {
+		case i == len(s) || s[i] == '\n' && (i == 0 || s[i-1] != '\r') || s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n':
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto f5
+		}
+		goto reverse
+	f6:
+		switch {
+		case i == len(s) || s[i] == '\n' && (i == 0 || s[i-1] != '\r') || s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n':
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto f4
+		case r == 10 || r == 13:
+			goto f1
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		switch {
+		case i == len(s) || s[i] == '\n' && (i == 0 || s[i-1] != '\r') || s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n':
+			goto r2
+		}
+		return
+	r2:
+		switch {
+		case i == 0 || s[i-1] == '\n':
+			start = i
+			goto r3
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto r4
+		}
+		return
+	r3:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto r5
+		}
+		return
+	r4:
+		switch {
+		case i == 0 || s[i-1] == '\n':
+			start = i
+			goto r6
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto r4
+		}
+		return
+	r5:
+		switch {
+		case i == 0 || s[i-1] == '\n':
+			start = i
+			goto r6
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto r5
+		}
+		return
+	r6:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto r5
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchLinesUnicode(s string, n int) [][2]int {
+	var matches [][2]int
+	matchLinesUnicodeFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchLinesUnicodeFunc(s string, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		switch {
+		case i == 0 || i >= 2 && s[i-2] == 0xc2 && s[i-1] == 0x85 || i >= 3 && s[i-3] == 0xe2 && s[i-2] == 0x80 && (s[i-1] == 0xa8 || s[i-1] == 0xa9):
+			goto f2
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 1114111:
+			goto f1
+		}
+		goto reverse
+	f2:
+		switch {
+		case i == len(s) || i+1 < len(s) && s[i] == 0xc2 && s[i+1] == 0x85 || i+2 < len(s) && s[i] == 0xe2 && s[i+1] == 0x80 && (s[i+2] == 0xa8 || s[i+2] == 0xa9):
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto f4
+		case r == 133 || r >= 8232 && r <= 8233:
+			goto f1
+		}
+		goto reverse
+	f3:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto f5
+		}
+		goto reverse
+	f4:
+		switch {
+		case i == len(s) || i+1 < len(s) && s[i] == 0xc2 && s[i+1] == 0x85 || i+2 < len(s) && s[i] == 0xe2 && s[i+1] == 0x80 && (s[i+2] == 0xa8 || s[i+2] == 0xa9):
+			end = i
+			goto f3
+		case i == 0 || i >= 2 && s[i-2] == 0xc2 && s[i-1] == 0x85 || i >= 3 && s[i-3] == 0xe2 && s[i-2] == 0x80 && (s[i-1] == 0xa8 || s[i-1] == 0xa9):
+			goto f6
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto f4
+		case r == 133 || r >= 8232 && r <= 8233:
+			goto f1
+		}
+		goto reverse
+	f5:
+		switch {
+		case i == len(s) || i+1 < len(s) && s[i] == 0xc2 && s[i+1] == 0x85 || i+2 < len(s) && s[i] == 0xe2 && s[i+1] == 0x80 && (s[i+2] == 0xa8 || s[i+2] == 0xa9):
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto f5
+		}
+		goto reverse
+	f6:
+		switch {
+		case i == len(s) || i+1 < len(s) && s[i] == 0xc2 && s[i+1] == 0x85 || i+2 < len(s) && s[i] == 0xe2 && s[i+1] == 0x80 && (s[i+2] == 0xa8 || s[i+2] == 0xa9):
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto f4
+		case r == 133 || r >= 8232 && r <= 8233:
+			goto f1
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		switch {
+		case i == len(s) || i+1 < len(s) && s[i] == 0xc2 && s[i+1] == 0x85 || i+2 < len(s) && s[i] == 0xe2 && s[i+1] == 0x80 && (s[i+2] == 0xa8 || s[i+2] == 0xa9):
+			goto r2
+		}
+		return
+	r2:
+		switch {
+		case i == 0 || i >= 2 && s[i-2] == 0xc2 && s[i-1] == 0x85 || i >= 3 && s[i-3] == 0xe2 && s[i-2] == 0x80 && (s[i-1] == 0xa8 || s[i-1] == 0xa9):
+			start = i
+			goto r3
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto r4
+		}
+		return
+	r3:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto r5
+		}
+		return
+	r4:
+		switch {
+		case i == 0 || i >= 2 && s[i-2] == 0xc2 && s[i-1] == 0x85 || i >= 3 && s[i-3] == 0xe2 && s[i-2] == 0x80 && (s[i-1] == 0xa8 || s[i-1] == 0xa9):
+			start = i
+			goto r6
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto r4
+		}
+		return
+	r5:
+		switch {
+		case i == 0 || i >= 2 && s[i-2] == 0xc2 && s[i-1] == 0x85 || i >= 3 && s[i-3] == 0xe2 && s[i-2] == 0x80 && (s[i-1] == 0xa8 || s[i-1] == 0xa9):
+			start = i
+			goto r6
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto r5
+		}
+		return
+	r6:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto r5
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchLinesAll(s string, n int) [][2]int {
+	var matches [][2]int
+	matchLinesAllFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchLinesAllFunc(s string, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		switch {
+		case i == 0 || s[i-1] == '\n' || s[i-1] == '\r' && (i == len(s) || s[i] != '\n') || i >= 2 && s[i-2] == 0xc2 && s[i-1] == 0x85 || i >= 3 && s[i-3] == 0xe2 && s[i-2] == 0x80 && (s[i-1] == 0xa8 || s[i-1] == 0xa9):
+			goto f2
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 1114111:
+			goto f1
+		}
+		goto reverse
+	f2:
+		switch {
+		case i == len(s) || s[i] == '\n' && (i == 0 || s[i-1] != '\r') || s[i] == '\r' || i+1 < len(s) && s[i] == 0xc2 && s[i+1] == 0x85 || i+2 < len(s) && s[i] == 0xe2 && s[i+1] == 0x80 && (s[i+2] == 0xa8 || s[i+2] == 0xa9):
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14 && r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto f4
+		case r == 10 || r == 13 || r == 133 || r >= 8232 && r <= 8233:
+			goto f1
+		}
+		goto reverse
+	f3:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14 && r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto f5
+		}
+		goto reverse
+	f4:
+		switch {
+		case i == len(s) || s[i] == '\n' && (i == 0 || s[i-1] != '\r') || s[i] == '\r' || i+1 < len(s) && s[i] == 0xc2 && s[i+1] == 0x85 || i+2 < len(s) && s[i] == 0xe2 && s[i+1] == 0x80 && (s[i+2] == 0xa8 || s[i+2] == 0xa9):
+			end = i
+			goto f3
+		case i == 0 || s[i-1] == '\n' || s[i-1] == '\r' && (i == len(s) || s[i] != '\n') || i >= 2 && s[i-2] == 0xc2 && s[i-1] == 0x85 || i >= 3 && s[i-3] == 0xe2 && s[i-2] == 0x80 && (s[i-1] == 0xa8 || s[i-1] == 0xa9):
+			goto f6
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14 && r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto f4
+		case r == 10 || r == 13 || r == 133 || r >= 8232 && r <= 8233:
+			goto f1
+		}
+		goto reverse
+	f5:
+		switch {
+		case i == len(s) || s[i] == '\n' && (i == 0 || s[i-1] != '\r') || s[i] == '\r' || i+1 < len(s) && s[i] == 0xc2 && s[i+1] == 0x85 || i+2 < len(s) && s[i] == 0xe2 && s[i+1] == 0x80 && (s[i+2] == 0xa8 || s[i+2] == 0xa9):
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14 && r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto f5
+		}
+		goto reverse
+	f6:
+		switch {
+		case i == len(s) || s[i] == '\n' && (i == 0 || s[i-1] != '\r') || s[i] == '\r' || i+1 < len(s) && s[i] == 0xc2 && s[i+1] == 0x85 || i+2 < len(s) && s[i] == 0xe2 && s[i+1] == 0x80 && (s[i+2] == 0xa8 || s[i+2] == 0xa9):
+			end = i
+			goto f3
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14 && r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto f4
+		case r == 10 || r == 13 || r == 133 || r >= 8232 && r <= 8233:
+			goto f1
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		switch {
+		case i == len(s) || s[i] == '\n' && (i == 0 || s[i-1] != '\r') || s[i] == '\r' || i+1 < len(s) && s[i] == 0xc2 && s[i+1] == 0x85 || i+2 < len(s) && s[i] == 0xe2 && s[i+1] == 0x80 && (s[i+2] == 0xa8 || s[i+2] == 0xa9):
+			goto r2
+		}
+		return
+	r2:
+		switch {
+		case i == 0 || s[i-1] == '\n' || s[i-1] == '\r' && (i == len(s) || s[i] != '\n') || i >= 2 && s[i-2] == 0xc2 && s[i-1] == 0x85 || i >= 3 && s[i-3] == 0xe2 && s[i-2] == 0x80 && (s[i-1] == 0xa8 || s[i-1] == 0xa9):
+			start = i
+			goto r3
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14 && r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto r4
+		}
+		return
+	r3:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14 && r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto r5
+		}
+		return
+	r4:
+		switch {
+		case i == 0 || s[i-1] == '\n' || s[i-1] == '\r' && (i == len(s) || s[i] != '\n') || i >= 2 && s[i-2] == 0xc2 && s[i-1] == 0x85 || i >= 3 && s[i-3] == 0xe2 && s[i-2] == 0x80 && (s[i-1] == 0xa8 || s[i-1] == 0xa9):
+			start = i
+			goto r6
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14 && r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto r4
+		}
+		return
+	r5:
+		switch {
+		case i == 0 || s[i-1] == '\n' || s[i-1] == '\r' && (i == len(s) || s[i] != '\n') || i >= 2 && s[i-2] == 0xc2 && s[i-1] == 0x85 || i >= 3 && s[i-3] == 0xe2 && s[i-2] == 0x80 && (s[i-1] == 0xa8 || s[i-1] == 0xa9):
+			start = i
+			goto r6
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14 && r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto r5
+		}
+		return
+	r6:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14 && r <= 132 || r >= 134 && r <= 8231 || r >= 8234:
+			goto r5
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchLinesLazyCRLF(s string, n int) [][2]int {
+	var matches [][2]int
+	matchLinesLazyCRLFFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchLinesLazyCRLFFunc(s string, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		switch {
+		case i == 0 || i >= 2 && s[i-2] == '\r' && s[i-1] == '\n':
+			goto f2
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 1114111:
+			goto f1
+		}
+		goto reverse
+	f2:
+		switch {
+		case i == len(s) || s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n':
+			end = i
+			goto reverse
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto f4
+		case r == 10 || r == 13:
+			goto f1
+		}
+		goto reverse
+	f4:
+		switch {
+		case i == len(s) || s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n':
+			end = i
+			goto reverse
+		case i == 0 || i >= 2 && s[i-2] == '\r' && s[i-1] == '\n':
+			goto f5
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto f4
+		case r == 10 || r == 13:
+			goto f1
+		}
+		goto reverse
+	f5:
+		switch {
+		case i == len(s) || s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n':
+			end = i
+			goto reverse
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto f4
+		case r == 10 || r == 13:
+			goto f1
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		switch {
+		case i == len(s) || s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n':
+			goto r2
+		}
+		return
+	r2:
+		switch {
+		case i == 0 || i >= 2 && s[i-2] == '\r' && s[i-1] == '\n':
+			start = i
+			goto r3
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto r4
+		}
+		return
+	r3:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto r5
+		}
+		return
+	r4:
+		switch {
+		case i == 0 || i >= 2 && s[i-2] == '\r' && s[i-1] == '\n':
+			start = i
+			goto r6
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto r4
+		}
+		return
+	r5:
+		switch {
+		case i == 0 || i >= 2 && s[i-2] == '\r' && s[i-1] == '\n':
+			start = i
+			goto r6
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto r5
+		}
+		return
+	r6:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11 && r <= 12 || r >= 14:
+			goto r5
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
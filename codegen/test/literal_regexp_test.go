@@ -0,0 +1,65 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchLiteralAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:abcdef)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"abcdef",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"-bcdef",
+		"aacdef",
+		"abcdaf",
+		"abcde",
+		"abcdef&",
+		"abcdef3",
+		"abcdefE",
+		"abcdefw",
+		"abcdefy",
+		"abcden",
+		"abcdf",
+		"abcef",
+		"ab}def",
+		"aqcdef",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchLiteral(s); got != want {
+			t.Errorf("matchLiteral(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchLiteral(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:abcdef)")
+	re.Longest()
+	for _, s := range []string{
+		"abcdef",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchLiteral(s); got != want {
+			t.Errorf("matchLiteral(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
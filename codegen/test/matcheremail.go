@@ -0,0 +1,483 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+func matcherEmailFindString(s string) (start, end int) {
+	if !strings.Contains(s, ".com") || strings.IndexByte(s, '@') < 0 {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 123:
+		goto f1
+	case r >= 97 && r <= 122:
+		goto f2
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f3
+	case r >= 97 && r <= 122:
+		goto f2
+	}
+	goto reverse
+f3:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 123:
+		goto f1
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f4:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46:
+		goto f5
+	case r == 64:
+		goto f3
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f5:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 123:
+		goto f1
+	case r >= 97 && r <= 98 || r >= 100 && r <= 122:
+		goto f2
+	case r == 99:
+		goto f6
+	}
+	goto reverse
+f6:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f3
+	case r >= 97 && r <= 110 || r >= 112 && r <= 122:
+		goto f2
+	case r == 111:
+		goto f7
+	}
+	goto reverse
+f7:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f3
+	case r >= 97 && r <= 108 || r >= 110 && r <= 122:
+		goto f2
+	case r == 109:
+		end = i
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 109:
+		goto r2
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 111:
+		goto r3
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 99:
+		goto r4
+	}
+	return
+r4:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 46:
+		goto r5
+	}
+	return
+r5:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto r6
+	}
+	return
+r6:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 64:
+		goto r7
+	case r >= 97 && r <= 122:
+		goto r6
+	}
+	return
+r7:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r8
+	}
+	return
+r8:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r8
+	}
+	return
+}
+
+func matcherEmailFind(s []byte) (start, end int) {
+	if !bytes.Contains(s, []byte(".com")) || bytes.IndexByte(s, '@') < 0 {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 123:
+		goto f1
+	case r >= 97 && r <= 122:
+		goto f2
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f3
+	case r >= 97 && r <= 122:
+		goto f2
+	}
+	goto reverse
+f3:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 123:
+		goto f1
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f4:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46:
+		goto f5
+	case r == 64:
+		goto f3
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f5:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 123:
+		goto f1
+	case r >= 97 && r <= 98 || r >= 100 && r <= 122:
+		goto f2
+	case r == 99:
+		goto f6
+	}
+	goto reverse
+f6:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f3
+	case r >= 97 && r <= 110 || r >= 112 && r <= 122:
+		goto f2
+	case r == 111:
+		goto f7
+	}
+	goto reverse
+f7:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 64:
+		goto f3
+	case r >= 97 && r <= 108 || r >= 110 && r <= 122:
+		goto f2
+	case r == 109:
+		end = i
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 109:
+		goto r2
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 111:
+		goto r3
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 99:
+		goto r4
+	}
+	return
+r4:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 46:
+		goto r5
+	}
+	return
+r5:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto r6
+	}
+	return
+r6:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 64:
+		goto r7
+	case r >= 97 && r <= 122:
+		goto r6
+	}
+	return
+r7:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r8
+	}
+	return
+r8:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		start = i
+		goto r8
+	}
+	return
+}
+
+// MatcherEmail matches the regular expression "[a-z]+@[a-z]+\\.com". It has the matching methods of *regexp.Regexp.
+type MatcherEmail struct{}
+
+// MatchString reports whether s contains a match.
+func (MatcherEmail) MatchString(s string) bool {
+	start, _ := matcherEmailFindString(s)
+	return start >= 0
+}
+
+// Match reports whether b contains a match.
+func (MatcherEmail) Match(b []byte) bool {
+	start, _ := matcherEmailFind(b)
+	return start >= 0
+}
+
+// FindStringIndex returns the location of the leftmost match in s, or nil.
+func (MatcherEmail) FindStringIndex(s string) []int {
+	start, end := matcherEmailFindString(s)
+	if start < 0 {
+		return nil
+	}
+	return []int{start, end}
+}
+
+// FindIndex returns the location of the leftmost match in b, or nil.
+func (MatcherEmail) FindIndex(b []byte) []int {
+	start, end := matcherEmailFind(b)
+	if start < 0 {
+		return nil
+	}
+	return []int{start, end}
+}
+
+// String returns the regular expression.
+func (MatcherEmail) String() string {
+	return "[a-z]+@[a-z]+\\.com"
+}
+
+// LiteralPrefix returns the literal string *regexp.Regexp finds every match to start with, and whether
+// it is the whole regular expression.
+func (MatcherEmail) LiteralPrefix() (prefix string, complete bool) {
+	return "", false
+}
@@ -0,0 +1,172 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatcherEmailAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("[a-z]+@[a-z]+\\.com")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"axr@u.com",
+		"b@z.com",
+		"f@d.com",
+		"ij@xdvkab.com",
+		"ip@mht.com",
+		"jl@ze.com",
+		"l@nyw.com",
+		"lru@q.com",
+		"n@sv.com",
+		"ni@ptfk.com",
+		"q@vl.com",
+		"qt@p.com",
+		"qvg@b.com",
+		"r@v.com",
+		"rye@u.com",
+		"t@p.com",
+		"tvoemt@td.com",
+		"vcl@w.com",
+		"w@fdt.com",
+		"w@h.com",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"axr@u.c2m",
+		"axr@u.co+",
+		"axr@u.comn",
+		"l@|yw.com",
+		"n@sv",
+		"ni@ptf.com",
+		"q@",
+		"q@vl.comQ",
+		"qt@~.com",
+		"ry'@u.com",
+		"t@p.ctm",
+		"vcl@wcom",
+		"w",
+		"wIfdt.com",
+		"é",
+		"日本",
+		"\xff",
+		"xaxr@u.comx",
+		"axr@u.com axr@u.com",
+		"xb@z.comx",
+		"b@z.com b@z.com",
+		"xf@d.comx",
+		"f@d.com f@d.com",
+		"xij@xdvkab.comx",
+		"ij@xdvkab.com ij@xdvkab.com",
+		"xip@mht.comx",
+		"ip@mht.com ip@mht.com",
+		"xjl@ze.comx",
+		"jl@ze.com jl@ze.com",
+		"xl@nyw.comx",
+		"l@nyw.com l@nyw.com",
+		"xlru@q.comx",
+		"lru@q.com lru@q.com",
+		"xn@sv.comx",
+		"n@sv.com n@sv.com",
+		"xni@ptfk.comx",
+		"ni@ptfk.com ni@ptfk.com",
+		"xq@vl.comx",
+		"q@vl.com q@vl.com",
+		"xqt@p.comx",
+		"qt@p.com qt@p.com",
+		"xqvg@b.comx",
+		"qvg@b.com qvg@b.com",
+		"xr@v.comx",
+		"r@v.com r@v.com",
+		"xrye@u.comx",
+		"rye@u.com rye@u.com",
+		"xt@p.comx",
+		"t@p.com t@p.com",
+		"xtvoemt@td.comx",
+		"tvoemt@td.com tvoemt@td.com",
+		"xvcl@w.comx",
+		"vcl@w.com vcl@w.com",
+		"xw@fdt.comx",
+		"w@fdt.com w@fdt.com",
+		"xw@h.comx",
+		"w@h.com w@h.com",
+	} {
+		var m MatcherEmail
+		if got := m.String(); got != re.String() {
+			t.Errorf("String() = %q, want %q", got, re.String())
+		}
+		want := re.FindStringIndex(s)
+		if got := m.FindStringIndex(s); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindStringIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.FindIndex([]byte(s)); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.MatchString(s); got != (want != nil) {
+			t.Errorf("MatchString(%q) = %v, want %v", s, got, want != nil)
+		}
+		if got := m.Match([]byte(s)); got != (want != nil) {
+			t.Errorf("Match(%q) = %v, want %v", s, got, want != nil)
+		}
+		prefix, complete := m.LiteralPrefix()
+		if wantPrefix, wantComplete := re.LiteralPrefix(); prefix != wantPrefix || complete != wantComplete {
+			t.Errorf("LiteralPrefix() = %q, %v, want %q, %v", prefix, complete, wantPrefix, wantComplete)
+		}
+	}
+}
+
+func FuzzMatcherEmail(f *testing.F) {
+	re := regexp.MustCompile("[a-z]+@[a-z]+\\.com")
+
+	for _, s := range []string{
+		"axr@u.com",
+		"b@z.com",
+		"f@d.com",
+		"ij@xdvkab.com",
+		"ip@mht.com",
+		"jl@ze.com",
+		"l@nyw.com",
+		"lru@q.com",
+		"n@sv.com",
+		"ni@ptfk.com",
+		"q@vl.com",
+		"qt@p.com",
+		"qvg@b.com",
+		"r@v.com",
+		"rye@u.com",
+		"t@p.com",
+		"tvoemt@td.com",
+		"vcl@w.com",
+		"w@fdt.com",
+		"w@h.com",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		var m MatcherEmail
+		if got := m.String(); got != re.String() {
+			t.Errorf("String() = %q, want %q", got, re.String())
+		}
+		want := re.FindStringIndex(s)
+		if got := m.FindStringIndex(s); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindStringIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.FindIndex([]byte(s)); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.MatchString(s); got != (want != nil) {
+			t.Errorf("MatchString(%q) = %v, want %v", s, got, want != nil)
+		}
+		if got := m.Match([]byte(s)); got != (want != nil) {
+			t.Errorf("Match(%q) = %v, want %v", s, got, want != nil)
+		}
+		prefix, complete := m.LiteralPrefix()
+		if wantPrefix, wantComplete := re.LiteralPrefix(); prefix != wantPrefix || complete != wantComplete {
+			t.Errorf("LiteralPrefix() = %q, %v, want %q, %v", prefix, complete, wantPrefix, wantComplete)
+		}
+	})
+}
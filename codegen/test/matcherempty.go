@@ -0,0 +1,79 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+func matcherEmptyFindString(s string) (start, end int) {
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = i
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = end
+	i = end
+	return
+}
+
+func matcherEmptyFind(s []byte) (start, end int) {
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = i
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = end
+	i = end
+	return
+}
+
+// MatcherEmpty matches the regular expression "". It has the matching methods of *regexp.Regexp.
+type MatcherEmpty struct{}
+
+// MatchString reports whether s contains a match.
+func (MatcherEmpty) MatchString(s string) bool {
+	start, _ := matcherEmptyFindString(s)
+	return start >= 0
+}
+
+// Match reports whether b contains a match.
+func (MatcherEmpty) Match(b []byte) bool {
+	start, _ := matcherEmptyFind(b)
+	return start >= 0
+}
+
+// FindStringIndex returns the location of the leftmost match in s, or nil.
+func (MatcherEmpty) FindStringIndex(s string) []int {
+	start, end := matcherEmptyFindString(s)
+	if start < 0 {
+		return nil
+	}
+	return []int{start, end}
+}
+
+// FindIndex returns the location of the leftmost match in b, or nil.
+func (MatcherEmpty) FindIndex(b []byte) []int {
+	start, end := matcherEmptyFind(b)
+	if start < 0 {
+		return nil
+	}
+	return []int{start, end}
+}
+
+// String returns the regular expression.
+func (MatcherEmpty) String() string {
+	return ""
+}
+
+// LiteralPrefix returns the literal string *regexp.Regexp finds every match to start with, and whether
+// it is the whole regular expression.
+func (MatcherEmpty) LiteralPrefix() (prefix string, complete bool) {
+	return "", true
+}
@@ -0,0 +1,96 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatcherEmptyAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		".",
+		"2",
+		"3",
+		"4",
+		"6",
+		"C",
+		"E",
+		"G",
+		"K",
+		"L",
+		"R",
+		"]",
+		"g",
+		"p",
+		"{",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+	} {
+		var m MatcherEmpty
+		if got := m.String(); got != re.String() {
+			t.Errorf("String() = %q, want %q", got, re.String())
+		}
+		want := re.FindStringIndex(s)
+		if got := m.FindStringIndex(s); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindStringIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.FindIndex([]byte(s)); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.MatchString(s); got != (want != nil) {
+			t.Errorf("MatchString(%q) = %v, want %v", s, got, want != nil)
+		}
+		if got := m.Match([]byte(s)); got != (want != nil) {
+			t.Errorf("Match(%q) = %v, want %v", s, got, want != nil)
+		}
+		prefix, complete := m.LiteralPrefix()
+		if wantPrefix, wantComplete := re.LiteralPrefix(); prefix != wantPrefix || complete != wantComplete {
+			t.Errorf("LiteralPrefix() = %q, %v, want %q, %v", prefix, complete, wantPrefix, wantComplete)
+		}
+	}
+}
+
+func FuzzMatcherEmpty(f *testing.F) {
+	re := regexp.MustCompile("")
+
+	for _, s := range []string{
+		"",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		var m MatcherEmpty
+		if got := m.String(); got != re.String() {
+			t.Errorf("String() = %q, want %q", got, re.String())
+		}
+		want := re.FindStringIndex(s)
+		if got := m.FindStringIndex(s); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindStringIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.FindIndex([]byte(s)); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.MatchString(s); got != (want != nil) {
+			t.Errorf("MatchString(%q) = %v, want %v", s, got, want != nil)
+		}
+		if got := m.Match([]byte(s)); got != (want != nil) {
+			t.Errorf("Match(%q) = %v, want %v", s, got, want != nil)
+		}
+		prefix, complete := m.LiteralPrefix()
+		if wantPrefix, wantComplete := re.LiteralPrefix(); prefix != wantPrefix || complete != wantComplete {
+			t.Errorf("LiteralPrefix() = %q, %v, want %q, %v", prefix, complete, wantPrefix, wantComplete)
+		}
+	})
+}
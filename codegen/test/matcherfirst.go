@@ -0,0 +1,161 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+func matcherFirstFindString(s string) (start, end int) {
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	i = strings.IndexByte(s, 'a')
+	if i < 0 {
+		return -1, -1
+	}
+	end = -1
+f1:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		end = i
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		start = i
+	case r == 98:
+		goto r3
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		start = i
+	}
+	return
+}
+
+func matcherFirstFind(s []byte) (start, end int) {
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	i = bytes.IndexByte(s, 'a')
+	if i < 0 {
+		return -1, -1
+	}
+	end = -1
+f1:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		end = i
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		start = i
+	case r == 98:
+		goto r3
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		start = i
+	}
+	return
+}
+
+// MatcherFirst matches the regular expression "a|ab". It has the matching methods of *regexp.Regexp.
+type MatcherFirst struct{}
+
+// MatchString reports whether s contains a match.
+func (MatcherFirst) MatchString(s string) bool {
+	start, _ := matcherFirstFindString(s)
+	return start >= 0
+}
+
+// Match reports whether b contains a match.
+func (MatcherFirst) Match(b []byte) bool {
+	start, _ := matcherFirstFind(b)
+	return start >= 0
+}
+
+// FindStringIndex returns the location of the leftmost match in s, or nil.
+func (MatcherFirst) FindStringIndex(s string) []int {
+	start, end := matcherFirstFindString(s)
+	if start < 0 {
+		return nil
+	}
+	return []int{start, end}
+}
+
+// FindIndex returns the location of the leftmost match in b, or nil.
+func (MatcherFirst) FindIndex(b []byte) []int {
+	start, end := matcherFirstFind(b)
+	if start < 0 {
+		return nil
+	}
+	return []int{start, end}
+}
+
+// String returns the regular expression.
+func (MatcherFirst) String() string {
+	return "a|ab"
+}
+
+// LiteralPrefix returns the literal string *regexp.Regexp finds every match to start with, and whether
+// it is the whole regular expression.
+func (MatcherFirst) LiteralPrefix() (prefix string, complete bool) {
+	return "a", false
+}
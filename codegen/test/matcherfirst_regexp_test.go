@@ -0,0 +1,96 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatcherFirstAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a|ab")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"2",
+		"I",
+		"K",
+		"R",
+		"]",
+		"a)",
+		"a2",
+		"aK",
+		"aT",
+		"aj",
+		"at",
+		"k",
+		"o",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+		"xax",
+		"a a",
+	} {
+		var m MatcherFirst
+		if got := m.String(); got != re.String() {
+			t.Errorf("String() = %q, want %q", got, re.String())
+		}
+		want := re.FindStringIndex(s)
+		if got := m.FindStringIndex(s); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindStringIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.FindIndex([]byte(s)); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.MatchString(s); got != (want != nil) {
+			t.Errorf("MatchString(%q) = %v, want %v", s, got, want != nil)
+		}
+		if got := m.Match([]byte(s)); got != (want != nil) {
+			t.Errorf("Match(%q) = %v, want %v", s, got, want != nil)
+		}
+		prefix, complete := m.LiteralPrefix()
+		if wantPrefix, wantComplete := re.LiteralPrefix(); prefix != wantPrefix || complete != wantComplete {
+			t.Errorf("LiteralPrefix() = %q, %v, want %q, %v", prefix, complete, wantPrefix, wantComplete)
+		}
+	}
+}
+
+func FuzzMatcherFirst(f *testing.F) {
+	re := regexp.MustCompile("a|ab")
+
+	for _, s := range []string{
+		"a",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		var m MatcherFirst
+		if got := m.String(); got != re.String() {
+			t.Errorf("String() = %q, want %q", got, re.String())
+		}
+		want := re.FindStringIndex(s)
+		if got := m.FindStringIndex(s); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindStringIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.FindIndex([]byte(s)); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.MatchString(s); got != (want != nil) {
+			t.Errorf("MatchString(%q) = %v, want %v", s, got, want != nil)
+		}
+		if got := m.Match([]byte(s)); got != (want != nil) {
+			t.Errorf("Match(%q) = %v, want %v", s, got, want != nil)
+		}
+		prefix, complete := m.LiteralPrefix()
+		if wantPrefix, wantComplete := re.LiteralPrefix(); prefix != wantPrefix || complete != wantComplete {
+			t.Errorf("LiteralPrefix() = %q, %v, want %q, %v", prefix, complete, wantPrefix, wantComplete)
+		}
+	})
+}
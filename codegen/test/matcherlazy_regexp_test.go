@@ -0,0 +1,172 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatcherLazyAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("<!--.*?-->")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"<!--\r-\"-[)--+---\x03---2-\x10-]--$--D-->",
+		"<!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18--->",
+		"<!--\x14---<-!-\U0008bfae\U000ebb58\"-'-&+⸺-->",
+		"<!-- 9f)--7---`\t\x03-->",
+		"<!--&\t-->",
+		"<!--(.-\f(,'--?\b~--' \a!$-,\x01-(-,4-->",
+		"<!---\"'t!),-}-D#qfa\U000b089d-->",
+		"<!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w-->",
+		"<!---),\U00014b1f5--->",
+		"<!---- -->",
+		"<!------->",
+		"<!----->",
+		"<!----0l-----9}-\uffc9-?-->",
+		"<!---->",
+		"<!---@*---|3&-C-&s-+\x1c-->",
+		"<!---\U00040c8b\U000588a1--->",
+		"<!--1Q-+\x1a-\U0010ab30\x04----->",
+		"<!--J-𨍆\x04---}(c+\x02-@D---->",
+		"<!--hV\x13-T\"-u\b-->",
+		"<!--\U0004c0f8&----\x06-->",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		";!----->",
+		"<!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18--->:",
+		"<!-- 9f)--7---`\t\x03-->~",
+		"<!-- 9fZ--7---`\t\x03-->",
+		"<!--(.-\f('--?\b~--' \a!$-,\x01-(-,4-->",
+		"<!--(.-\f(,'--?\b",
+		"<!---%(---\x1d-)----M(--\x0e\x10-\x14--\U00103b8d\x1a&w-->",
+		"<!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w-->'",
+		"<!---%(---\x1d-)----M-(--\x0e\x10-\x14a-\U00103b8d\x1a&w-->",
+		"<!---- -->$",
+		"<!--hV",
+		"<!--hV\x13-T\"-u\b-->E",
+		"<!--hV\x13-T\"-u\b-7>",
+		"<!-\U0004c0f8&----\x06-->",
+		"é",
+		"日本",
+		"\xff",
+		"x<!--\r-\"-[)--+---\x03---2-\x10-]--$--D-->x",
+		"<!--\r-\"-[)--+---\x03---2-\x10-]--$--D--> <!--\r-\"-[)--+---\x03---2-\x10-]--$--D-->",
+		"x<!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18--->x",
+		"<!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18---> <!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18--->",
+		"x<!--\x14---<-!-\U0008bfae\U000ebb58\"-'-&+⸺-->x",
+		"<!--\x14---<-!-\U0008bfae\U000ebb58\"-'-&+⸺--> <!--\x14---<-!-\U0008bfae\U000ebb58\"-'-&+⸺-->",
+		"x<!-- 9f)--7---`\t\x03-->x",
+		"<!-- 9f)--7---`\t\x03--> <!-- 9f)--7---`\t\x03-->",
+		"x<!--&\t-->x",
+		"<!--&\t--> <!--&\t-->",
+		"x<!--(.-\f(,'--?\b~--' \a!$-,\x01-(-,4-->x",
+		"<!--(.-\f(,'--?\b~--' \a!$-,\x01-(-,4--> <!--(.-\f(,'--?\b~--' \a!$-,\x01-(-,4-->",
+		"x<!---\"'t!),-}-D#qfa\U000b089d-->x",
+		"<!---\"'t!),-}-D#qfa\U000b089d--> <!---\"'t!),-}-D#qfa\U000b089d-->",
+		"x<!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w-->x",
+		"<!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w--> <!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w-->",
+		"x<!---),\U00014b1f5--->x",
+		"<!---),\U00014b1f5---> <!---),\U00014b1f5--->",
+		"x<!---- -->x",
+		"<!---- --> <!---- -->",
+		"x<!------->x",
+		"<!-------> <!------->",
+		"x<!----->x",
+		"<!-----> <!----->",
+		"x<!----0l-----9}-\uffc9-?-->x",
+		"<!----0l-----9}-\uffc9-?--> <!----0l-----9}-\uffc9-?-->",
+		"x<!---->x",
+		"<!----> <!---->",
+		"x<!---@*---|3&-C-&s-+\x1c-->x",
+		"<!---@*---|3&-C-&s-+\x1c--> <!---@*---|3&-C-&s-+\x1c-->",
+		"x<!---\U00040c8b\U000588a1--->x",
+		"<!---\U00040c8b\U000588a1---> <!---\U00040c8b\U000588a1--->",
+		"x<!--1Q-+\x1a-\U0010ab30\x04----->x",
+		"<!--1Q-+\x1a-\U0010ab30\x04-----> <!--1Q-+\x1a-\U0010ab30\x04----->",
+		"x<!--J-𨍆\x04---}(c+\x02-@D---->x",
+		"<!--J-𨍆\x04---}(c+\x02-@D----> <!--J-𨍆\x04---}(c+\x02-@D---->",
+		"x<!--hV\x13-T\"-u\b-->x",
+		"<!--hV\x13-T\"-u\b--> <!--hV\x13-T\"-u\b-->",
+		"x<!--\U0004c0f8&----\x06-->x",
+		"<!--\U0004c0f8&----\x06--> <!--\U0004c0f8&----\x06-->",
+	} {
+		var m MatcherLazy
+		if got := m.String(); got != re.String() {
+			t.Errorf("String() = %q, want %q", got, re.String())
+		}
+		want := re.FindStringIndex(s)
+		if got := m.FindStringIndex(s); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindStringIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.FindIndex([]byte(s)); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.MatchString(s); got != (want != nil) {
+			t.Errorf("MatchString(%q) = %v, want %v", s, got, want != nil)
+		}
+		if got := m.Match([]byte(s)); got != (want != nil) {
+			t.Errorf("Match(%q) = %v, want %v", s, got, want != nil)
+		}
+		prefix, complete := m.LiteralPrefix()
+		if wantPrefix, wantComplete := re.LiteralPrefix(); prefix != wantPrefix || complete != wantComplete {
+			t.Errorf("LiteralPrefix() = %q, %v, want %q, %v", prefix, complete, wantPrefix, wantComplete)
+		}
+	}
+}
+
+func FuzzMatcherLazy(f *testing.F) {
+	re := regexp.MustCompile("<!--.*?-->")
+
+	for _, s := range []string{
+		"<!--\r-\"-[)--+---\x03---2-\x10-]--$--D-->",
+		"<!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18--->",
+		"<!--\x14---<-!-\U0008bfae\U000ebb58\"-'-&+⸺-->",
+		"<!-- 9f)--7---`\t\x03-->",
+		"<!--&\t-->",
+		"<!--(.-\f(,'--?\b~--' \a!$-,\x01-(-,4-->",
+		"<!---\"'t!),-}-D#qfa\U000b089d-->",
+		"<!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w-->",
+		"<!---),\U00014b1f5--->",
+		"<!---- -->",
+		"<!------->",
+		"<!----->",
+		"<!----0l-----9}-\uffc9-?-->",
+		"<!---->",
+		"<!---@*---|3&-C-&s-+\x1c-->",
+		"<!---\U00040c8b\U000588a1--->",
+		"<!--1Q-+\x1a-\U0010ab30\x04----->",
+		"<!--J-𨍆\x04---}(c+\x02-@D---->",
+		"<!--hV\x13-T\"-u\b-->",
+		"<!--\U0004c0f8&----\x06-->",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		var m MatcherLazy
+		if got := m.String(); got != re.String() {
+			t.Errorf("String() = %q, want %q", got, re.String())
+		}
+		want := re.FindStringIndex(s)
+		if got := m.FindStringIndex(s); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindStringIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.FindIndex([]byte(s)); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.MatchString(s); got != (want != nil) {
+			t.Errorf("MatchString(%q) = %v, want %v", s, got, want != nil)
+		}
+		if got := m.Match([]byte(s)); got != (want != nil) {
+			t.Errorf("Match(%q) = %v, want %v", s, got, want != nil)
+		}
+		prefix, complete := m.LiteralPrefix()
+		if wantPrefix, wantComplete := re.LiteralPrefix(); prefix != wantPrefix || complete != wantComplete {
+			t.Errorf("LiteralPrefix() = %q, %v, want %q, %v", prefix, complete, wantPrefix, wantComplete)
+		}
+	})
+}
@@ -0,0 +1,239 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+func matcherLiteralFindString(s string) (start, end int) {
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	i = strings.Index(s, "abc")
+	if i < 0 {
+		return -1, -1
+	}
+	end = -1
+f1:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f2
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f2
+	case r == 98:
+		goto f3
+	}
+	goto reverse
+f3:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r == 98 || r >= 100:
+		goto f1
+	case r == 97:
+		goto f2
+	case r == 99:
+		end = i
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 99:
+		goto r2
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 98:
+		goto r3
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		start = i
+	}
+	return
+}
+
+func matcherLiteralFind(s []byte) (start, end int) {
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	i = bytes.Index(s, []byte("abc"))
+	if i < 0 {
+		return -1, -1
+	}
+	end = -1
+f1:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f2
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f2
+	case r == 98:
+		goto f3
+	}
+	goto reverse
+f3:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r == 98 || r >= 100:
+		goto f1
+	case r == 97:
+		goto f2
+	case r == 99:
+		end = i
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 99:
+		goto r2
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 98:
+		goto r3
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		start = i
+	}
+	return
+}
+
+// MatcherLiteral matches the regular expression "abc". It has the matching methods of *regexp.Regexp.
+type MatcherLiteral struct{}
+
+// MatchString reports whether s contains a match.
+func (MatcherLiteral) MatchString(s string) bool {
+	start, _ := matcherLiteralFindString(s)
+	return start >= 0
+}
+
+// Match reports whether b contains a match.
+func (MatcherLiteral) Match(b []byte) bool {
+	start, _ := matcherLiteralFind(b)
+	return start >= 0
+}
+
+// FindStringIndex returns the location of the leftmost match in s, or nil.
+func (MatcherLiteral) FindStringIndex(s string) []int {
+	start, end := matcherLiteralFindString(s)
+	if start < 0 {
+		return nil
+	}
+	return []int{start, end}
+}
+
+// FindIndex returns the location of the leftmost match in b, or nil.
+func (MatcherLiteral) FindIndex(b []byte) []int {
+	start, end := matcherLiteralFind(b)
+	if start < 0 {
+		return nil
+	}
+	return []int{start, end}
+}
+
+// String returns the regular expression.
+func (MatcherLiteral) String() string {
+	return "abc"
+}
+
+// LiteralPrefix returns the literal string *regexp.Regexp finds every match to start with, and whether
+// it is the whole regular expression.
+func (MatcherLiteral) LiteralPrefix() (prefix string, complete bool) {
+	return "abc", true
+}
@@ -0,0 +1,96 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatcherLiteralAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("abc")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"abc",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"!bc",
+		"a",
+		"a+c",
+		"a5c",
+		"ab",
+		"abcG",
+		"abcb",
+		"abcf",
+		"abcp",
+		"abcs",
+		"abc~",
+		"ac",
+		"aoc",
+		"bc",
+		"é",
+		"日本",
+		"\xff",
+		"xabcx",
+		"abc abc",
+	} {
+		var m MatcherLiteral
+		if got := m.String(); got != re.String() {
+			t.Errorf("String() = %q, want %q", got, re.String())
+		}
+		want := re.FindStringIndex(s)
+		if got := m.FindStringIndex(s); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindStringIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.FindIndex([]byte(s)); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.MatchString(s); got != (want != nil) {
+			t.Errorf("MatchString(%q) = %v, want %v", s, got, want != nil)
+		}
+		if got := m.Match([]byte(s)); got != (want != nil) {
+			t.Errorf("Match(%q) = %v, want %v", s, got, want != nil)
+		}
+		prefix, complete := m.LiteralPrefix()
+		if wantPrefix, wantComplete := re.LiteralPrefix(); prefix != wantPrefix || complete != wantComplete {
+			t.Errorf("LiteralPrefix() = %q, %v, want %q, %v", prefix, complete, wantPrefix, wantComplete)
+		}
+	}
+}
+
+func FuzzMatcherLiteral(f *testing.F) {
+	re := regexp.MustCompile("abc")
+
+	for _, s := range []string{
+		"abc",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		var m MatcherLiteral
+		if got := m.String(); got != re.String() {
+			t.Errorf("String() = %q, want %q", got, re.String())
+		}
+		want := re.FindStringIndex(s)
+		if got := m.FindStringIndex(s); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindStringIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.FindIndex([]byte(s)); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("FindIndex(%q) = %v, want %v", s, got, want)
+		}
+		if got := m.MatchString(s); got != (want != nil) {
+			t.Errorf("MatchString(%q) = %v, want %v", s, got, want != nil)
+		}
+		if got := m.Match([]byte(s)); got != (want != nil) {
+			t.Errorf("Match(%q) = %v, want %v", s, got, want != nil)
+		}
+		prefix, complete := m.LiteralPrefix()
+		if wantPrefix, wantComplete := re.LiteralPrefix(); prefix != wantPrefix || complete != wantComplete {
+			t.Errorf("LiteralPrefix() = %q, %v, want %q, %v", prefix, complete, wantPrefix, wantComplete)
+		}
+	})
+}
@@ -0,0 +1,623 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import "unicode/utf8"
+
+func matcherWordBoundaryFindString(s string) (start, end int) {
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	switch {
+	case (i > 0 && matcherWordBoundarya974c6d3IsWordChar(s[i-1])) != (i < len(s) && matcherWordBoundarya974c6d3IsWordChar(s[i])):
+		goto f2
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99:
+		goto f1
+	case r == 98:
+		goto f3
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99 && r <= 101 || r >= 103:
+		goto f1
+	case r == 98:
+		goto f3
+	case r == 102:
+		goto f4
+	}
+	goto reverse
+f3:
+	switch {
+	case (i > 0 && matcherWordBoundarya974c6d3IsWordChar(s[i-1])) != (i < len(s) && matcherWordBoundarya974c6d3IsWordChar(s[i])):
+		goto f5
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f6
+	case r == 98:
+		goto f3
+	}
+	goto reverse
+f4:
+	switch {
+	case (i > 0 && matcherWordBoundarya974c6d3IsWordChar(s[i-1])) != (i < len(s) && matcherWordBoundarya974c6d3IsWordChar(s[i])):
+		goto f9
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99 && r <= 110 || r >= 112:
+		goto f1
+	case r == 98:
+		goto f3
+	case r == 111:
+		goto f10
+	}
+	goto reverse
+f5:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99 && r <= 101 || r >= 103:
+		goto f1
+	case r == 97:
+		goto f6
+	case r == 98:
+		goto f3
+	case r == 102:
+		goto f4
+	}
+	goto reverse
+f6:
+	switch {
+	case (i > 0 && matcherWordBoundarya974c6d3IsWordChar(s[i-1])) != (i < len(s) && matcherWordBoundarya974c6d3IsWordChar(s[i])):
+		goto f7
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99 && r <= 113 || r >= 115:
+		goto f1
+	case r == 98:
+		goto f3
+	case r == 114:
+		end = i
+	}
+	goto reverse
+f7:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99 && r <= 101 || r >= 103 && r <= 113 || r >= 115:
+		goto f1
+	case r == 98:
+		goto f3
+	case r == 102:
+		goto f4
+	case r == 114:
+		end = i
+	}
+	goto reverse
+f9:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99 && r <= 101 || r >= 103 && r <= 110 || r >= 112:
+		goto f1
+	case r == 98:
+		goto f3
+	case r == 102:
+		goto f4
+	case r == 111:
+		goto f10
+	}
+	goto reverse
+f10:
+	switch {
+	case (i > 0 && matcherWordBoundarya974c6d3IsWordChar(s[i-1])) != (i < len(s) && matcherWordBoundarya974c6d3IsWordChar(s[i])):
+		goto f11
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99 && r <= 110 || r >= 112:
+		goto f1
+	case r == 98:
+		goto f3
+	case r == 111:
+		goto f12
+	}
+	goto reverse
+f11:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99 && r <= 101 || r >= 103 && r <= 110 || r >= 112:
+		goto f1
+	case r == 98:
+		goto f3
+	case r == 102:
+		goto f4
+	case r == 111:
+		goto f12
+	}
+	goto reverse
+f12:
+	switch {
+	case (i > 0 && matcherWordBoundarya974c6d3IsWordChar(s[i-1])) != (i < len(s) && matcherWordBoundarya974c6d3IsWordChar(s[i])):
+		end = i
+		goto reverse
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99:
+		goto f1
+	case r == 98:
+		goto f3
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	switch {
+	case (i > 0 && matcherWordBoundarya974c6d3IsWordChar(s[i-1])) != (i < len(s) && matcherWordBoundarya974c6d3IsWordChar(s[i])):
+		goto r2
+	}
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 114:
+		goto r3
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 111:
+		goto r4
+	case r == 114:
+		goto r3
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		goto r8
+	}
+	return
+r4:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 111:
+		goto r5
+	}
+	return
+r5:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 102:
+		goto r6
+	}
+	return
+r6:
+	switch {
+	case (i > 0 && matcherWordBoundarya974c6d3IsWordChar(s[i-1])) != (i < len(s) && matcherWordBoundarya974c6d3IsWordChar(s[i])):
+		start = i
+	}
+	return
+r8:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 98:
+		start = i
+	}
+	return
+}
+
+func matcherWordBoundaryFind(s []byte) (start, end int) {
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	switch {
+	case (i > 0 && matcherWordBoundarya974c6d3IsWordChar(s[i-1])) != (i < len(s) && matcherWordBoundarya974c6d3IsWordChar(s[i])):
+		goto f2
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99:
+		goto f1
+	case r == 98:
+		goto f3
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99 && r <= 101 || r >= 103:
+		goto f1
+	case r == 98:
+		goto f3
+	case r == 102:
+		goto f4
+	}
+	goto reverse
+f3:
+	switch {
+	case (i > 0 && matcherWordBoundarya974c6d3IsWordChar(s[i-1])) != (i < len(s) && matcherWordBoundarya974c6d3IsWordChar(s[i])):
+		goto f5
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f6
+	case r == 98:
+		goto f3
+	}
+	goto reverse
+f4:
+	switch {
+	case (i > 0 && matcherWordBoundarya974c6d3IsWordChar(s[i-1])) != (i < len(s) && matcherWordBoundarya974c6d3IsWordChar(s[i])):
+		goto f9
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99 && r <= 110 || r >= 112:
+		goto f1
+	case r == 98:
+		goto f3
+	case r == 111:
+		goto f10
+	}
+	goto reverse
+f5:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99 && r <= 101 || r >= 103:
+		goto f1
+	case r == 97:
+		goto f6
+	case r == 98:
+		goto f3
+	case r == 102:
+		goto f4
+	}
+	goto reverse
+f6:
+	switch {
+	case (i > 0 && matcherWordBoundarya974c6d3IsWordChar(s[i-1])) != (i < len(s) && matcherWordBoundarya974c6d3IsWordChar(s[i])):
+		goto f7
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99 && r <= 113 || r >= 115:
+		goto f1
+	case r == 98:
+		goto f3
+	case r == 114:
+		end = i
+	}
+	goto reverse
+f7:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99 && r <= 101 || r >= 103 && r <= 113 || r >= 115:
+		goto f1
+	case r == 98:
+		goto f3
+	case r == 102:
+		goto f4
+	case r == 114:
+		end = i
+	}
+	goto reverse
+f9:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99 && r <= 101 || r >= 103 && r <= 110 || r >= 112:
+		goto f1
+	case r == 98:
+		goto f3
+	case r == 102:
+		goto f4
+	case r == 111:
+		goto f10
+	}
+	goto reverse
+f10:
+	switch {
+	case (i > 0 && matcherWordBoundarya974c6d3IsWordChar(s[i-1])) != (i < len(s) && matcherWordBoundarya974c6d3IsWordChar(s[i])):
+		goto f11
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99 && r <= 110 || r >= 112:
+		goto f1
+	case r == 98:
+		goto f3
+	case r == 111:
+		goto f12
+	}
+	goto reverse
+f11:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99 && r <= 101 || r >= 103 && r <= 110 || r >= 112:
+		goto f1
+	case r == 98:
+		goto f3
+	case r == 102:
+		goto f4
+	case r == 111:
+		goto f12
+	}
+	goto reverse
+f12:
+	switch {
+	case (i > 0 && matcherWordBoundarya974c6d3IsWordChar(s[i-1])) != (i < len(s) && matcherWordBoundarya974c6d3IsWordChar(s[i])):
+		end = i
+		goto reverse
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99:
+		goto f1
+	case r == 98:
+		goto f3
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	switch {
+	case (i > 0 && matcherWordBoundarya974c6d3IsWordChar(s[i-1])) != (i < len(s) && matcherWordBoundarya974c6d3IsWordChar(s[i])):
+		goto r2
+	}
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 114:
+		goto r3
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 111:
+		goto r4
+	case r == 114:
+		goto r3
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		goto r8
+	}
+	return
+r4:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 111:
+		goto r5
+	}
+	return
+r5:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 102:
+		goto r6
+	}
+	return
+r6:
+	switch {
+	case (i > 0 && matcherWordBoundarya974c6d3IsWordChar(s[i-1])) != (i < len(s) && matcherWordBoundarya974c6d3IsWordChar(s[i])):
+		start = i
+	}
+	return
+r8:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 98:
+		start = i
+	}
+	return
+}
+
+// MatcherWordBoundary matches the regular expression "\\bfoo\\b|bar". It has the matching methods of *regexp.Regexp.
+type MatcherWordBoundary struct{}
+
+// MatchString reports whether s contains a match.
+func (MatcherWordBoundary) MatchString(s string) bool {
+	start, _ := matcherWordBoundaryFindString(s)
+	return start >= 0
+}
+
+// Match reports whether b contains a match.
+func (MatcherWordBoundary) Match(b []byte) bool {
+	start, _ := matcherWordBoundaryFind(b)
+	return start >= 0
+}
+
+// FindStringIndex returns the location of the leftmost match in s, or nil.
+func (MatcherWordBoundary) FindStringIndex(s string) []int {
+	start, end := matcherWordBoundaryFindString(s)
+	if start < 0 {
+		return nil
+	}
+	return []int{start, end}
+}
+
+// FindIndex returns the location of the leftmost match in b, or nil.
+func (MatcherWordBoundary) FindIndex(b []byte) []int {
+	start, end := matcherWordBoundaryFind(b)
+	if start < 0 {
+		return nil
+	}
+	return []int{start, end}
+}
+
+// String returns the regular expression.
+func (MatcherWordBoundary) String() string {
+	return "\\bfoo\\b|bar"
+}
+
+// LiteralPrefix returns the literal string *regexp.Regexp finds every match to start with, and whether
+// it is the whole regular expression.
+func (MatcherWordBoundary) LiteralPrefix() (prefix string, complete bool) {
+	return "", false
+}
+
+func matcherWordBoundarya974c6d3IsWordChar(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_'
+}
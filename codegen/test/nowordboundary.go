@@ -0,0 +1,20 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+func matchNoWordBoundary(s string) (end int) {
+	end = -1
+	var r rune
+	var rlen int
+	i := 0
+	_, _, _ = r, rlen, i
+	switch {
+	case (i > 0 && matchNoWordBoundary51479b4fIsWordChar(s[i-1])) == (i < len(s) && matchNoWordBoundary51479b4fIsWordChar(s[i])):
+		end = i
+	}
+	return
+}
+
+func matchNoWordBoundary51479b4fIsWordChar(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_'
+}
@@ -0,0 +1,103 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchOptionsDotNLAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:(?s:a.b))")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a%b",
+		"a0b",
+		"a7b",
+		"a@b",
+		"aJb",
+		"aLb",
+		"aOb",
+		"aUb",
+		"a[b",
+		"a`b",
+		"ahb",
+		"asb",
+		"a{b",
+		"a\U000402d5b",
+		"a\U00045788b",
+		"a\U0004bf34b",
+		"a\U0005d7ebb",
+		"a\U0007790cb",
+		"a\U000ca901b",
+		"a\U000d154eb",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"%b",
+		"`\U0004bf34b",
+		"a",
+		"a%b[",
+		"a7",
+		"a@",
+		"aOb5",
+		"aUb:",
+		"a\\b",
+		"ab",
+		"alb",
+		"a\U00045788bi",
+		"a\U000d154eb*",
+		"m\U000402d5b",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchOptionsDotNL(s); got != want {
+			t.Errorf("matchOptionsDotNL(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchOptionsDotNL(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:(?s:a.b))")
+	re.Longest()
+	for _, s := range []string{
+		"a%b",
+		"a0b",
+		"a7b",
+		"a@b",
+		"aJb",
+		"aLb",
+		"aOb",
+		"aUb",
+		"a[b",
+		"a`b",
+		"ahb",
+		"asb",
+		"a{b",
+		"a\U000402d5b",
+		"a\U00045788b",
+		"a\U0004bf34b",
+		"a\U0005d7ebb",
+		"a\U0007790cb",
+		"a\U000ca901b",
+		"a\U000d154eb",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchOptionsDotNL(s); got != want {
+			t.Errorf("matchOptionsDotNL(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchOptionsIgnoreCaseAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:(?i:ABC))")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"ABC",
+		"ABc",
+		"AbC",
+		"Abc",
+		"aBC",
+		"aBc",
+		"abC",
+		"abc",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"!bc",
+		"A",
+		"A+C",
+		"ABCf",
+		"ABcs",
+		"Abcb",
+		"Abc~",
+		"BC",
+		"a",
+		"aB",
+		"abcG",
+		"abcp",
+		"aoc",
+		"bc",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchOptionsIgnoreCase(s); got != want {
+			t.Errorf("matchOptionsIgnoreCase(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchOptionsIgnoreCase(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:(?i:ABC))")
+	re.Longest()
+	for _, s := range []string{
+		"ABC",
+		"ABc",
+		"AbC",
+		"Abc",
+		"aBC",
+		"aBc",
+		"abC",
+		"abc",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchOptionsIgnoreCase(s); got != want {
+			t.Errorf("matchOptionsIgnoreCase(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchOptionsLiteralAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a\\.b\\*)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a.b*",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"a",
+		"a.",
+		"a.*",
+		"a.9*",
+		"a.b",
+		"a.b*U",
+		"a.b*b",
+		"a.b*o",
+		"a.b*{",
+		"a.b:",
+		"aUb*",
+		"ab*",
+		"ayb*",
+		"k.b*",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchOptionsLiteral(s); got != want {
+			t.Errorf("matchOptionsLiteral(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchOptionsLiteral(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a\\.b\\*)")
+	re.Longest()
+	for _, s := range []string{
+		"a.b*",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchOptionsLiteral(s); got != want {
+			t.Errorf("matchOptionsLiteral(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
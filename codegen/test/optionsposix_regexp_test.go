@@ -0,0 +1,103 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchOptionsPOSIXAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:(?m-s:^a.b$))")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a\x06b",
+		"a'b",
+		"a:b",
+		"a;b",
+		"a=b",
+		"aDb",
+		"aEb",
+		"aJb",
+		"aNb",
+		"aTb",
+		"aUb",
+		"aXb",
+		"aab",
+		"amb",
+		"apb",
+		"avb",
+		"awb",
+		"a\U00082040b",
+		"a\U000c56c6b",
+		"a\U0010e620b",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		">wb",
+		"?\x06b",
+		"a\x06bH",
+		"a7b",
+		"aD",
+		"aE",
+		"aJb*",
+		"aN!",
+		"aU",
+		"aUb}",
+		"ab",
+		"arb",
+		"av\"",
+		"r;b",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchOptionsPOSIX(s); got != want {
+			t.Errorf("matchOptionsPOSIX(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchOptionsPOSIX(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:(?m-s:^a.b$))")
+	re.Longest()
+	for _, s := range []string{
+		"a\x06b",
+		"a'b",
+		"a:b",
+		"a;b",
+		"a=b",
+		"aDb",
+		"aEb",
+		"aJb",
+		"aNb",
+		"aTb",
+		"aUb",
+		"aXb",
+		"aab",
+		"amb",
+		"apb",
+		"avb",
+		"awb",
+		"a\U00082040b",
+		"a\U000c56c6b",
+		"a\U0010e620b",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchOptionsPOSIX(s); got != want {
+			t.Errorf("matchOptionsPOSIX(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
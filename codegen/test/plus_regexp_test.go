@@ -0,0 +1,83 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchPlusAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a+)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaa",
+		"aaaaaaaaaa",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		";aaaaaaaaa",
+		"Da",
+		"a5aaa",
+		"aa$",
+		"aaaaR",
+		"aaaa`",
+		"aaaaaWaa",
+		"aaaaaa ",
+		"aaaaaaaG",
+		"aaaaaaaaaaS",
+		"aaaaaaaaah",
+		"aaaab",
+		"aaaa~",
+		"aaa}",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchPlus(s); got != want {
+			t.Errorf("matchPlus(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchPlus(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a+)")
+	re.Longest()
+	for _, s := range []string{
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaa",
+		"aaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchPlus(s); got != want {
+			t.Errorf("matchPlus(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
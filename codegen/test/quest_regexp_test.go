@@ -0,0 +1,67 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchQuestAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a?)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"a",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"\"",
+		"(",
+		")",
+		",",
+		"/",
+		"4",
+		"?",
+		"C",
+		"U",
+		"[",
+		"_",
+		"aH",
+		"a[",
+		"ar",
+		"j",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchQuest(s); got != want {
+			t.Errorf("matchQuest(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchQuest(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a?)")
+	re.Longest()
+	for _, s := range []string{
+		"",
+		"a",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchQuest(s); got != want {
+			t.Errorf("matchQuest(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchRepeat1AgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a{1,3})")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		"aa",
+		"aaa",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"'aa",
+		"@a",
+		"Eaa",
+		"a%a",
+		"aI",
+		"aK",
+		"aa;",
+		"aaD",
+		"aaa!",
+		"aaaa",
+		"aaf",
+		"haa",
+		"r",
+		"{",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchRepeat1(s); got != want {
+			t.Errorf("matchRepeat1(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchRepeat1(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a{1,3})")
+	re.Longest()
+	for _, s := range []string{
+		"a",
+		"aa",
+		"aaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchRepeat1(s); got != want {
+			t.Errorf("matchRepeat1(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
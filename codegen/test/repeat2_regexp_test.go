@@ -0,0 +1,71 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchRepeat2AgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a{0,3})")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"a",
+		"aa",
+		"aaa",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"-",
+		";",
+		"A",
+		"K",
+		"M",
+		"a\"",
+		"aa;",
+		"aaa&",
+		"aaa0",
+		"aaaP",
+		"aaat",
+		"d",
+		"i",
+		"k",
+		"x",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchRepeat2(s); got != want {
+			t.Errorf("matchRepeat2(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchRepeat2(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a{0,3})")
+	re.Longest()
+	for _, s := range []string{
+		"",
+		"a",
+		"aa",
+		"aaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchRepeat2(s); got != want {
+			t.Errorf("matchRepeat2(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
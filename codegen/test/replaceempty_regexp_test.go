@@ -0,0 +1,186 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchReplaceEmptyAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a*")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaaaaa",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"4",
+		"a=aaaaa",
+		"aaD",
+		"aaa,aaa",
+		"aaaEaa",
+		"aaa_",
+		"aaaaa!",
+		"aaaaaaaa6",
+		"aaaaaaaaaa",
+		"aaaaaaaaaaa",
+		"aaaaaf",
+		"aaaah",
+		"asaaaaa",
+		"b",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xaaaaaax",
+		"aaaaaa aaaaaa",
+		"xaaaaaaax",
+		"aaaaaaa aaaaaaa",
+		"xaaaaaaaax",
+		"aaaaaaaa aaaaaaaa",
+		"xaaaaaaaaaaaax",
+		"aaaaaaaaaaaa aaaaaaaaaaaa",
+	} {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchReplaceEmpty(s)); got != want {
+			t.Errorf("matchReplaceEmpty(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchReplaceEmpty(f *testing.F) {
+	re := regexp.MustCompile("a*")
+
+	for _, s := range []string{
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchReplaceEmpty(s)); got != want {
+			t.Errorf("matchReplaceEmpty(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
+
+func TestMatchReplaceEmptyBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a*")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaaaaa",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"4",
+		"a=aaaaa",
+		"aaD",
+		"aaa,aaa",
+		"aaaEaa",
+		"aaa_",
+		"aaaaa!",
+		"aaaaaaaa6",
+		"aaaaaaaaaa",
+		"aaaaaaaaaaa",
+		"aaaaaf",
+		"aaaah",
+		"asaaaaa",
+		"b",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xaaaaaax",
+		"aaaaaa aaaaaa",
+		"xaaaaaaax",
+		"aaaaaaa aaaaaaa",
+		"xaaaaaaaax",
+		"aaaaaaaa aaaaaaaa",
+		"xaaaaaaaaaaaax",
+		"aaaaaaaaaaaa aaaaaaaaaaaa",
+	} {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchReplaceEmptyBytes([]byte(s))); got != want {
+			t.Errorf("matchReplaceEmptyBytes(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchReplaceEmptyBytes(f *testing.F) {
+	re := regexp.MustCompile("a*")
+
+	for _, s := range []string{
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchReplaceEmptyBytes([]byte(s))); got != want {
+			t.Errorf("matchReplaceEmptyBytes(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchReplaceFirstAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a|ab")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"2",
+		"I",
+		"K",
+		"R",
+		"]",
+		"a)",
+		"a2",
+		"aK",
+		"aT",
+		"aj",
+		"at",
+		"k",
+		"o",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+		"xax",
+		"a a",
+	} {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchReplaceFirst(s)); got != want {
+			t.Errorf("matchReplaceFirst(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchReplaceFirst(f *testing.F) {
+	re := regexp.MustCompile("a|ab")
+
+	for _, s := range []string{
+		"a",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchReplaceFirst(s)); got != want {
+			t.Errorf("matchReplaceFirst(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
+
+func TestMatchReplaceFirstBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a|ab")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"2",
+		"I",
+		"K",
+		"R",
+		"]",
+		"a)",
+		"a2",
+		"aK",
+		"aT",
+		"aj",
+		"at",
+		"k",
+		"o",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+		"xax",
+		"a a",
+	} {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchReplaceFirstBytes([]byte(s))); got != want {
+			t.Errorf("matchReplaceFirstBytes(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchReplaceFirstBytes(f *testing.F) {
+	re := regexp.MustCompile("a|ab")
+
+	for _, s := range []string{
+		"a",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchReplaceFirstBytes([]byte(s))); got != want {
+			t.Errorf("matchReplaceFirstBytes(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,170 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchReplaceLazyAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("x*?y")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"xxxxxxxxxxy",
+		"xxxxxxy",
+		"xxxxxy",
+		"xxxxy",
+		"xxxy",
+		"xxy",
+		"xy",
+		"y",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"!xxxxy",
+		"%y",
+		"x$xxxy",
+		"xxpxy",
+		"xxxx",
+		"xxxxxxhxxxy",
+		"xxxxxxxxxxy;",
+		"xxxxxxxxxy",
+		"xxxxxxy3",
+		"xxxxxxyT",
+		"xxxxxy!",
+		"xxxyU",
+		"yK",
+		"zxy",
+		"é",
+		"日本",
+		"\xff",
+		"xxxxxxxxxxxyx",
+		"xxxxxxxxxxy xxxxxxxxxxy",
+		"xxxxxxxyx",
+		"xxxxxxy xxxxxxy",
+		"xxxxxxyx",
+		"xxxxxy xxxxxy",
+		"xxxxxyx",
+		"xxxxy xxxxy",
+		"xxxxyx",
+		"xxxy xxxy",
+		"xxxyx",
+		"xxy xxy",
+		"xxyx",
+		"xy xy",
+		"xyx",
+		"y y",
+	} {
+		want := re.ReplaceAllString(s, "")
+		if got := string(matchReplaceLazy(s)); got != want {
+			t.Errorf("matchReplaceLazy(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchReplaceLazy(f *testing.F) {
+	re := regexp.MustCompile("x*?y")
+
+	for _, s := range []string{
+		"xxxxxxxxxxy",
+		"xxxxxxy",
+		"xxxxxy",
+		"xxxxy",
+		"xxxy",
+		"xxy",
+		"xy",
+		"y",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, "")
+		if got := string(matchReplaceLazy(s)); got != want {
+			t.Errorf("matchReplaceLazy(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
+
+func TestMatchReplaceLazyBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("x*?y")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"xxxxxxxxxxy",
+		"xxxxxxy",
+		"xxxxxy",
+		"xxxxy",
+		"xxxy",
+		"xxy",
+		"xy",
+		"y",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"!xxxxy",
+		"%y",
+		"x$xxxy",
+		"xxpxy",
+		"xxxx",
+		"xxxxxxhxxxy",
+		"xxxxxxxxxxy;",
+		"xxxxxxxxxy",
+		"xxxxxxy3",
+		"xxxxxxyT",
+		"xxxxxy!",
+		"xxxyU",
+		"yK",
+		"zxy",
+		"é",
+		"日本",
+		"\xff",
+		"xxxxxxxxxxxyx",
+		"xxxxxxxxxxy xxxxxxxxxxy",
+		"xxxxxxxyx",
+		"xxxxxxy xxxxxxy",
+		"xxxxxxyx",
+		"xxxxxy xxxxxy",
+		"xxxxxyx",
+		"xxxxy xxxxy",
+		"xxxxyx",
+		"xxxy xxxy",
+		"xxxyx",
+		"xxy xxy",
+		"xxyx",
+		"xy xy",
+		"xyx",
+		"y y",
+	} {
+		want := re.ReplaceAllString(s, "")
+		if got := string(matchReplaceLazyBytes([]byte(s))); got != want {
+			t.Errorf("matchReplaceLazyBytes(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchReplaceLazyBytes(f *testing.F) {
+	re := regexp.MustCompile("x*?y")
+
+	for _, s := range []string{
+		"xxxxxxxxxxy",
+		"xxxxxxy",
+		"xxxxxy",
+		"xxxxy",
+		"xxxy",
+		"xxy",
+		"xy",
+		"y",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, "")
+		if got := string(matchReplaceLazyBytes([]byte(s))); got != want {
+			t.Errorf("matchReplaceLazyBytes(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
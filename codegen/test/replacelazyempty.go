@@ -0,0 +1,118 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+func matchReplaceLazyEmpty(s string) string {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = i
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = end
+		i = end
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+		}
+		return
+	}
+	var b strings.Builder
+	last := 0
+	for pos := 0; pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		b.WriteString(s[last:start])
+		// An empty match right after the previous match is not replaced.
+		if end > last || start == 0 {
+			b.WriteString("<")
+			b.WriteString(s[start:end])
+			b.WriteString(">")
+		}
+		last = end
+		// As in regexp.ReplaceAllString, but the position moves forward even at the end of s.
+		if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 && pos+width > end {
+			pos += width
+		} else if pos+1 > end {
+			pos++
+		} else {
+			pos = end
+		}
+	}
+	if last == 0 && b.Len() == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+func matchReplaceLazyEmptyBytes(s []byte) []byte {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = i
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = end
+		i = end
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r == 97:
+			start = i
+		}
+		return
+	}
+	var b []byte
+	last := 0
+	for pos := 0; pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		b = append(b, s[last:start]...)
+		// An empty match right after the previous match is not replaced.
+		if end > last || start == 0 {
+			b = append(b, "<"...)
+			b = append(b, s[start:end]...)
+			b = append(b, ">"...)
+		}
+		last = end
+		// As in regexp.ReplaceAllString, but the position moves forward even at the end of s.
+		if _, width := utf8.DecodeRune(s[pos:]); width > 0 && pos+width > end {
+			pos += width
+		} else if pos+1 > end {
+			pos++
+		} else {
+			pos = end
+		}
+	}
+	return append(b, s[last:]...)
+}
@@ -0,0 +1,114 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchReplaceLazyEmptyAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a??")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		".",
+		"2",
+		"3",
+		"4",
+		"6",
+		"C",
+		"E",
+		"G",
+		"K",
+		"L",
+		"R",
+		"]",
+		"g",
+		"p",
+		"{",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+	} {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchReplaceLazyEmpty(s)); got != want {
+			t.Errorf("matchReplaceLazyEmpty(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchReplaceLazyEmpty(f *testing.F) {
+	re := regexp.MustCompile("a??")
+
+	for _, s := range []string{
+		"",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchReplaceLazyEmpty(s)); got != want {
+			t.Errorf("matchReplaceLazyEmpty(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
+
+func TestMatchReplaceLazyEmptyBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a??")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		".",
+		"2",
+		"3",
+		"4",
+		"6",
+		"C",
+		"E",
+		"G",
+		"K",
+		"L",
+		"R",
+		"]",
+		"g",
+		"p",
+		"{",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+	} {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchReplaceLazyEmptyBytes([]byte(s))); got != want {
+			t.Errorf("matchReplaceLazyEmptyBytes(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchReplaceLazyEmptyBytes(f *testing.F) {
+	re := regexp.MustCompile("a??")
+
+	for _, s := range []string{
+		"",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, "<$0>")
+		if got := string(matchReplaceLazyEmptyBytes([]byte(s))); got != want {
+			t.Errorf("matchReplaceLazyEmptyBytes(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
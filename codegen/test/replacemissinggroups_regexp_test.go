@@ -0,0 +1,266 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchReplaceMissingGroupsAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("(\\d+)-(?P<n>\\d+)")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"10-53",
+		"1325-9",
+		"18-3",
+		"19-14",
+		"2-7",
+		"20009-5614698",
+		"227-7",
+		"28-95",
+		"3-8",
+		"5-7",
+		"6-5",
+		"6-920",
+		"7-49835",
+		"70-9",
+		"78157-1",
+		"8-17",
+		"8-83",
+		"957-3",
+		"964-7",
+		"976-7",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"-7",
+		"19-3",
+		"200095614698",
+		"227-7%",
+		"227-7Q",
+		"227-7W",
+		"28-9",
+		"3-8b",
+		"38",
+		"7-4Z835",
+		"8-",
+		"957",
+		"96--7",
+		"p27-7",
+		"é",
+		"日本",
+		"\xff",
+		"x10-53x",
+		"10-53 10-53",
+		"x1325-9x",
+		"1325-9 1325-9",
+		"x18-3x",
+		"18-3 18-3",
+		"x19-14x",
+		"19-14 19-14",
+		"x2-7x",
+		"2-7 2-7",
+		"x20009-5614698x",
+		"20009-5614698 20009-5614698",
+		"x227-7x",
+		"227-7 227-7",
+		"x28-95x",
+		"28-95 28-95",
+		"x3-8x",
+		"3-8 3-8",
+		"x5-7x",
+		"5-7 5-7",
+		"x6-5x",
+		"6-5 6-5",
+		"x6-920x",
+		"6-920 6-920",
+		"x7-49835x",
+		"7-49835 7-49835",
+		"x70-9x",
+		"70-9 70-9",
+		"x78157-1x",
+		"78157-1 78157-1",
+		"x8-17x",
+		"8-17 8-17",
+		"x8-83x",
+		"8-83 8-83",
+		"x957-3x",
+		"957-3 957-3",
+		"x964-7x",
+		"964-7 964-7",
+		"x976-7x",
+		"976-7 976-7",
+	} {
+		want := re.ReplaceAllString(s, "[$3$x$]")
+		if got := string(matchReplaceMissingGroups(s)); got != want {
+			t.Errorf("matchReplaceMissingGroups(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchReplaceMissingGroups(f *testing.F) {
+	re := regexp.MustCompile("(\\d+)-(?P<n>\\d+)")
+
+	for _, s := range []string{
+		"10-53",
+		"1325-9",
+		"18-3",
+		"19-14",
+		"2-7",
+		"20009-5614698",
+		"227-7",
+		"28-95",
+		"3-8",
+		"5-7",
+		"6-5",
+		"6-920",
+		"7-49835",
+		"70-9",
+		"78157-1",
+		"8-17",
+		"8-83",
+		"957-3",
+		"964-7",
+		"976-7",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, "[$3$x$]")
+		if got := string(matchReplaceMissingGroups(s)); got != want {
+			t.Errorf("matchReplaceMissingGroups(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
+
+func TestMatchReplaceMissingGroupsBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("(\\d+)-(?P<n>\\d+)")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"10-53",
+		"1325-9",
+		"18-3",
+		"19-14",
+		"2-7",
+		"20009-5614698",
+		"227-7",
+		"28-95",
+		"3-8",
+		"5-7",
+		"6-5",
+		"6-920",
+		"7-49835",
+		"70-9",
+		"78157-1",
+		"8-17",
+		"8-83",
+		"957-3",
+		"964-7",
+		"976-7",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"-7",
+		"19-3",
+		"200095614698",
+		"227-7%",
+		"227-7Q",
+		"227-7W",
+		"28-9",
+		"3-8b",
+		"38",
+		"7-4Z835",
+		"8-",
+		"957",
+		"96--7",
+		"p27-7",
+		"é",
+		"日本",
+		"\xff",
+		"x10-53x",
+		"10-53 10-53",
+		"x1325-9x",
+		"1325-9 1325-9",
+		"x18-3x",
+		"18-3 18-3",
+		"x19-14x",
+		"19-14 19-14",
+		"x2-7x",
+		"2-7 2-7",
+		"x20009-5614698x",
+		"20009-5614698 20009-5614698",
+		"x227-7x",
+		"227-7 227-7",
+		"x28-95x",
+		"28-95 28-95",
+		"x3-8x",
+		"3-8 3-8",
+		"x5-7x",
+		"5-7 5-7",
+		"x6-5x",
+		"6-5 6-5",
+		"x6-920x",
+		"6-920 6-920",
+		"x7-49835x",
+		"7-49835 7-49835",
+		"x70-9x",
+		"70-9 70-9",
+		"x78157-1x",
+		"78157-1 78157-1",
+		"x8-17x",
+		"8-17 8-17",
+		"x8-83x",
+		"8-83 8-83",
+		"x957-3x",
+		"957-3 957-3",
+		"x964-7x",
+		"964-7 964-7",
+		"x976-7x",
+		"976-7 976-7",
+	} {
+		want := re.ReplaceAllString(s, "[$3$x$]")
+		if got := string(matchReplaceMissingGroupsBytes([]byte(s))); got != want {
+			t.Errorf("matchReplaceMissingGroupsBytes(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchReplaceMissingGroupsBytes(f *testing.F) {
+	re := regexp.MustCompile("(\\d+)-(?P<n>\\d+)")
+
+	for _, s := range []string{
+		"10-53",
+		"1325-9",
+		"18-3",
+		"19-14",
+		"2-7",
+		"20009-5614698",
+		"227-7",
+		"28-95",
+		"3-8",
+		"5-7",
+		"6-5",
+		"6-920",
+		"7-49835",
+		"70-9",
+		"78157-1",
+		"8-17",
+		"8-83",
+		"957-3",
+		"964-7",
+		"976-7",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, "[$3$x$]")
+		if got := string(matchReplaceMissingGroupsBytes([]byte(s))); got != want {
+			t.Errorf("matchReplaceMissingGroupsBytes(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
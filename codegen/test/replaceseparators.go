@@ -0,0 +1,246 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+func matchReplaceSeparators(s string) string {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 8 || r >= 9 && r <= 10 || r == 11 || r >= 12 && r <= 13 || r >= 14 && r <= 31 || r == 32 || r >= 33 && r <= 43 || r >= 45 && r <= 58 || r >= 60:
+			goto f1
+		case r == 44 || r == 59:
+			end = i
+			goto f2
+		}
+		goto reverse
+	f2:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			end = i
+			goto f2
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			goto r2
+		case r == 44 || r == 59:
+			start = i
+			goto r3
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			goto r2
+		case r == 44 || r == 59:
+			start = i
+			goto r3
+		}
+		return
+	r3:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			start = i
+			goto r4
+		}
+		return
+	r4:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			start = i
+			goto r4
+		}
+		return
+	}
+	var b strings.Builder
+	last := 0
+	for pos := 0; pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		b.WriteString(s[last:start])
+		// An empty match right after the previous match is not replaced.
+		if end > last || start == 0 {
+			b.WriteString(",")
+		}
+		last = end
+		// As in regexp.ReplaceAllString, but the position moves forward even at the end of s.
+		if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 && pos+width > end {
+			pos += width
+		} else if pos+1 > end {
+			pos++
+		} else {
+			pos = end
+		}
+	}
+	if last == 0 && b.Len() == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+func matchReplaceSeparatorsBytes(s []byte) []byte {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 8 || r >= 9 && r <= 10 || r == 11 || r >= 12 && r <= 13 || r >= 14 && r <= 31 || r == 32 || r >= 33 && r <= 43 || r >= 45 && r <= 58 || r >= 60:
+			goto f1
+		case r == 44 || r == 59:
+			end = i
+			goto f2
+		}
+		goto reverse
+	f2:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			end = i
+			goto f2
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			goto r2
+		case r == 44 || r == 59:
+			start = i
+			goto r3
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			goto r2
+		case r == 44 || r == 59:
+			start = i
+			goto r3
+		}
+		return
+	r3:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			start = i
+			goto r4
+		}
+		return
+	r4:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 9 && r <= 10 || r >= 12 && r <= 13 || r == 32:
+			start = i
+			goto r4
+		}
+		return
+	}
+	var b []byte
+	last := 0
+	for pos := 0; pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		b = append(b, s[last:start]...)
+		// An empty match right after the previous match is not replaced.
+		if end > last || start == 0 {
+			b = append(b, ","...)
+		}
+		last = end
+		// As in regexp.ReplaceAllString, but the position moves forward even at the end of s.
+		if _, width := utf8.DecodeRune(s[pos:]); width > 0 && pos+width > end {
+			pos += width
+		} else if pos+1 > end {
+			pos++
+		} else {
+			pos = end
+		}
+	}
+	return append(b, s[last:]...)
+}
@@ -0,0 +1,266 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchReplaceSeparatorsAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\s*[,;]\\s*")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"\t   , ",
+		"\t, ",
+		"\n;",
+		" \f \t ;     ",
+		"  \n  ,\t",
+		"     ; ",
+		"  ;      ",
+		" ,\n ",
+		" , ",
+		",",
+		",\n\f ",
+		",  ",
+		",  \t",
+		",   ",
+		",    \r ",
+		",     ",
+		";",
+		";\r",
+		"; ",
+		";  ",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\t, 4",
+		"\n",
+		"\nI",
+		"  \t",
+		"  \n  ,\t*",
+		"  \n ,\t",
+		"    ",
+		"  ;      ?",
+		", ",
+		",  \t%",
+		"/  \t",
+		";j",
+		"R",
+		"Z, ",
+		"z ;      ",
+		"é",
+		"日本",
+		"\xff",
+		"x\t   , x",
+		"\t   ,  \t   , ",
+		"x\t, x",
+		"\t,  \t, ",
+		"x\n;x",
+		"\n; \n;",
+		"x \f \t ;     x",
+		" \f \t ;       \f \t ;     ",
+		"x  \n  ,\tx",
+		"  \n  ,\t   \n  ,\t",
+		"x     ; x",
+		"     ;       ; ",
+		"x  ;      x",
+		"  ;         ;      ",
+		"x ,\n x",
+		" ,\n   ,\n ",
+		"x , x",
+		" ,   , ",
+		"x,x",
+		", ,",
+		"x,\n\f x",
+		",\n\f  ,\n\f ",
+		"x,  x",
+		",   ,  ",
+		"x,  \tx",
+		",  \t ,  \t",
+		"x,   x",
+		",    ,   ",
+		"x,    \r x",
+		",    \r  ,    \r ",
+		"x,     x",
+		",      ,     ",
+		"x;x",
+		"; ;",
+		"x;\rx",
+		";\r ;\r",
+		"x; x",
+		";  ; ",
+		"x;  x",
+		";   ;  ",
+	} {
+		want := re.ReplaceAllString(s, ",")
+		if got := string(matchReplaceSeparators(s)); got != want {
+			t.Errorf("matchReplaceSeparators(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchReplaceSeparators(f *testing.F) {
+	re := regexp.MustCompile("\\s*[,;]\\s*")
+
+	for _, s := range []string{
+		"\t   , ",
+		"\t, ",
+		"\n;",
+		" \f \t ;     ",
+		"  \n  ,\t",
+		"     ; ",
+		"  ;      ",
+		" ,\n ",
+		" , ",
+		",",
+		",\n\f ",
+		",  ",
+		",  \t",
+		",   ",
+		",    \r ",
+		",     ",
+		";",
+		";\r",
+		"; ",
+		";  ",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, ",")
+		if got := string(matchReplaceSeparators(s)); got != want {
+			t.Errorf("matchReplaceSeparators(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
+
+func TestMatchReplaceSeparatorsBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\s*[,;]\\s*")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"\t   , ",
+		"\t, ",
+		"\n;",
+		" \f \t ;     ",
+		"  \n  ,\t",
+		"     ; ",
+		"  ;      ",
+		" ,\n ",
+		" , ",
+		",",
+		",\n\f ",
+		",  ",
+		",  \t",
+		",   ",
+		",    \r ",
+		",     ",
+		";",
+		";\r",
+		"; ",
+		";  ",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\t, 4",
+		"\n",
+		"\nI",
+		"  \t",
+		"  \n  ,\t*",
+		"  \n ,\t",
+		"    ",
+		"  ;      ?",
+		", ",
+		",  \t%",
+		"/  \t",
+		";j",
+		"R",
+		"Z, ",
+		"z ;      ",
+		"é",
+		"日本",
+		"\xff",
+		"x\t   , x",
+		"\t   ,  \t   , ",
+		"x\t, x",
+		"\t,  \t, ",
+		"x\n;x",
+		"\n; \n;",
+		"x \f \t ;     x",
+		" \f \t ;       \f \t ;     ",
+		"x  \n  ,\tx",
+		"  \n  ,\t   \n  ,\t",
+		"x     ; x",
+		"     ;       ; ",
+		"x  ;      x",
+		"  ;         ;      ",
+		"x ,\n x",
+		" ,\n   ,\n ",
+		"x , x",
+		" ,   , ",
+		"x,x",
+		", ,",
+		"x,\n\f x",
+		",\n\f  ,\n\f ",
+		"x,  x",
+		",   ,  ",
+		"x,  \tx",
+		",  \t ,  \t",
+		"x,   x",
+		",    ,   ",
+		"x,    \r x",
+		",    \r  ,    \r ",
+		"x,     x",
+		",      ,     ",
+		"x;x",
+		"; ;",
+		"x;\rx",
+		";\r ;\r",
+		"x; x",
+		";  ; ",
+		"x;  x",
+		";   ;  ",
+	} {
+		want := re.ReplaceAllString(s, ",")
+		if got := string(matchReplaceSeparatorsBytes([]byte(s))); got != want {
+			t.Errorf("matchReplaceSeparatorsBytes(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchReplaceSeparatorsBytes(f *testing.F) {
+	re := regexp.MustCompile("\\s*[,;]\\s*")
+
+	for _, s := range []string{
+		"\t   , ",
+		"\t, ",
+		"\n;",
+		" \f \t ;     ",
+		"  \n  ,\t",
+		"     ; ",
+		"  ;      ",
+		" ,\n ",
+		" , ",
+		",",
+		",\n\f ",
+		",  ",
+		",  \t",
+		",   ",
+		",    \r ",
+		",     ",
+		";",
+		";\r",
+		"; ",
+		";  ",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, ",")
+		if got := string(matchReplaceSeparatorsBytes([]byte(s))); got != want {
+			t.Errorf("matchReplaceSeparatorsBytes(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
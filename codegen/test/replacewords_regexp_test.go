@@ -0,0 +1,266 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchReplaceWordsAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("[a-z]+")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"alcs",
+		"b",
+		"bymul",
+		"d",
+		"dhdm",
+		"eqez",
+		"gdknlm",
+		"h",
+		"ja",
+		"m",
+		"mld",
+		"nc",
+		"nu",
+		"owf",
+		"pc",
+		"quihu",
+		"s",
+		"tls",
+		"u",
+		"wq",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"a7cs",
+		"al`s",
+		"als",
+		"dhdm[",
+		"dhdmm",
+		"di",
+		"eqez*",
+		"gdkn\\m",
+		"hdm",
+		"nE",
+		"t",
+		"tls:",
+		"ts",
+		"w",
+		"é",
+		"日本",
+		"\xff",
+		"xalcsx",
+		"alcs alcs",
+		"xbx",
+		"b b",
+		"xbymulx",
+		"bymul bymul",
+		"xdx",
+		"d d",
+		"xdhdmx",
+		"dhdm dhdm",
+		"xeqezx",
+		"eqez eqez",
+		"xgdknlmx",
+		"gdknlm gdknlm",
+		"xhx",
+		"h h",
+		"xjax",
+		"ja ja",
+		"xmx",
+		"m m",
+		"xmldx",
+		"mld mld",
+		"xncx",
+		"nc nc",
+		"xnux",
+		"nu nu",
+		"xowfx",
+		"owf owf",
+		"xpcx",
+		"pc pc",
+		"xquihux",
+		"quihu quihu",
+		"xsx",
+		"s s",
+		"xtlsx",
+		"tls tls",
+		"xux",
+		"u u",
+		"xwqx",
+		"wq wq",
+	} {
+		want := re.ReplaceAllString(s, "${0}_$$")
+		if got := string(matchReplaceWords(s)); got != want {
+			t.Errorf("matchReplaceWords(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchReplaceWords(f *testing.F) {
+	re := regexp.MustCompile("[a-z]+")
+
+	for _, s := range []string{
+		"alcs",
+		"b",
+		"bymul",
+		"d",
+		"dhdm",
+		"eqez",
+		"gdknlm",
+		"h",
+		"ja",
+		"m",
+		"mld",
+		"nc",
+		"nu",
+		"owf",
+		"pc",
+		"quihu",
+		"s",
+		"tls",
+		"u",
+		"wq",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, "${0}_$$")
+		if got := string(matchReplaceWords(s)); got != want {
+			t.Errorf("matchReplaceWords(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
+
+func TestMatchReplaceWordsBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("[a-z]+")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"alcs",
+		"b",
+		"bymul",
+		"d",
+		"dhdm",
+		"eqez",
+		"gdknlm",
+		"h",
+		"ja",
+		"m",
+		"mld",
+		"nc",
+		"nu",
+		"owf",
+		"pc",
+		"quihu",
+		"s",
+		"tls",
+		"u",
+		"wq",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"a7cs",
+		"al`s",
+		"als",
+		"dhdm[",
+		"dhdmm",
+		"di",
+		"eqez*",
+		"gdkn\\m",
+		"hdm",
+		"nE",
+		"t",
+		"tls:",
+		"ts",
+		"w",
+		"é",
+		"日本",
+		"\xff",
+		"xalcsx",
+		"alcs alcs",
+		"xbx",
+		"b b",
+		"xbymulx",
+		"bymul bymul",
+		"xdx",
+		"d d",
+		"xdhdmx",
+		"dhdm dhdm",
+		"xeqezx",
+		"eqez eqez",
+		"xgdknlmx",
+		"gdknlm gdknlm",
+		"xhx",
+		"h h",
+		"xjax",
+		"ja ja",
+		"xmx",
+		"m m",
+		"xmldx",
+		"mld mld",
+		"xncx",
+		"nc nc",
+		"xnux",
+		"nu nu",
+		"xowfx",
+		"owf owf",
+		"xpcx",
+		"pc pc",
+		"xquihux",
+		"quihu quihu",
+		"xsx",
+		"s s",
+		"xtlsx",
+		"tls tls",
+		"xux",
+		"u u",
+		"xwqx",
+		"wq wq",
+	} {
+		want := re.ReplaceAllString(s, "${0}_$$")
+		if got := string(matchReplaceWordsBytes([]byte(s))); got != want {
+			t.Errorf("matchReplaceWordsBytes(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchReplaceWordsBytes(f *testing.F) {
+	re := regexp.MustCompile("[a-z]+")
+
+	for _, s := range []string{
+		"alcs",
+		"b",
+		"bymul",
+		"d",
+		"dhdm",
+		"eqez",
+		"gdknlm",
+		"h",
+		"ja",
+		"m",
+		"mld",
+		"nc",
+		"nu",
+		"owf",
+		"pc",
+		"quihu",
+		"s",
+		"tls",
+		"u",
+		"wq",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := re.ReplaceAllString(s, "${0}_$$")
+		if got := string(matchReplaceWordsBytes([]byte(s))); got != want {
+			t.Errorf("matchReplaceWordsBytes(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
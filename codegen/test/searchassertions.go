@@ -0,0 +1,587 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import "unicode/utf8"
+
+func matchSearchAssertions(s string) (start, end int) {
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	switch {
+	case i == 0 || s[i-1] == '\n':
+		goto f2
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 47 || r >= 58:
+		goto f1
+	case r >= 48 && r <= 57:
+		goto f3
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 47 || r >= 58 && r <= 96 || r >= 123:
+		goto f1
+	case r >= 48 && r <= 57:
+		goto f3
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f3:
+	switch {
+	case (i > 0 && matchSearchAssertions9ef41babIsWordChar(s[i-1])) != (i < len(s) && matchSearchAssertions9ef41babIsWordChar(s[i])):
+		end = i
+		goto f5
+	case i == 0 || s[i-1] == '\n':
+		goto f6
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 47 || r >= 58:
+		goto f1
+	case r >= 48 && r <= 57:
+		goto f3
+	}
+	goto reverse
+f4:
+	switch {
+	case i == len(s) || s[i] == '\n':
+		end = i
+		goto f8
+	case i == 0 || s[i-1] == '\n':
+		goto f9
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 47 || r >= 58 && r <= 96 || r >= 123:
+		goto f1
+	case r >= 48 && r <= 57:
+		goto f3
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f5:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto f7
+	}
+	goto reverse
+f6:
+	switch {
+	case (i > 0 && matchSearchAssertions9ef41babIsWordChar(s[i-1])) != (i < len(s) && matchSearchAssertions9ef41babIsWordChar(s[i])):
+		end = i
+		goto f5
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 47 || r >= 58 && r <= 96 || r >= 123:
+		goto f1
+	case r >= 48 && r <= 57:
+		goto f3
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f7:
+	switch {
+	case (i > 0 && matchSearchAssertions9ef41babIsWordChar(s[i-1])) != (i < len(s) && matchSearchAssertions9ef41babIsWordChar(s[i])):
+		end = i
+		goto f5
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto f7
+	}
+	goto reverse
+f8:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto f10
+	}
+	goto reverse
+f9:
+	switch {
+	case i == len(s) || s[i] == '\n':
+		end = i
+		goto f8
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 47 || r >= 58 && r <= 96 || r >= 123:
+		goto f1
+	case r >= 48 && r <= 57:
+		goto f3
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f10:
+	switch {
+	case i == len(s) || s[i] == '\n':
+		end = i
+		goto f8
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto f10
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	switch {
+	case (i > 0 && matchSearchAssertions9ef41babIsWordChar(s[i-1])) != (i < len(s) && matchSearchAssertions9ef41babIsWordChar(s[i])):
+		goto r2
+	case i == len(s) || s[i] == '\n':
+		goto r3
+	}
+	return
+r2:
+	switch {
+	case i == len(s) || s[i] == '\n':
+		goto r4
+	}
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 48 && r <= 57:
+		start = i
+		goto r5
+	}
+	return
+r3:
+	switch {
+	case (i > 0 && matchSearchAssertions9ef41babIsWordChar(s[i-1])) != (i < len(s) && matchSearchAssertions9ef41babIsWordChar(s[i])):
+		goto r4
+	}
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto r6
+	}
+	return
+r4:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 48 && r <= 57:
+		start = i
+		goto r5
+	case r >= 97 && r <= 122:
+		goto r6
+	}
+	return
+r5:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 48 && r <= 57:
+		start = i
+		goto r5
+	}
+	return
+r6:
+	switch {
+	case i == 0 || s[i-1] == '\n':
+		start = i
+		goto r7
+	}
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto r6
+	}
+	return
+r7:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto r8
+	}
+	return
+r8:
+	switch {
+	case i == 0 || s[i-1] == '\n':
+		start = i
+		goto r7
+	}
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto r8
+	}
+	return
+}
+
+func matchSearchAssertionsBytes(s []byte) (start, end int) {
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	switch {
+	case i == 0 || s[i-1] == '\n':
+		goto f2
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 47 || r >= 58:
+		goto f1
+	case r >= 48 && r <= 57:
+		goto f3
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 47 || r >= 58 && r <= 96 || r >= 123:
+		goto f1
+	case r >= 48 && r <= 57:
+		goto f3
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f3:
+	switch {
+	case (i > 0 && matchSearchAssertions9ef41babIsWordChar(s[i-1])) != (i < len(s) && matchSearchAssertions9ef41babIsWordChar(s[i])):
+		end = i
+		goto f5
+	case i == 0 || s[i-1] == '\n':
+		goto f6
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 47 || r >= 58:
+		goto f1
+	case r >= 48 && r <= 57:
+		goto f3
+	}
+	goto reverse
+f4:
+	switch {
+	case i == len(s) || s[i] == '\n':
+		end = i
+		goto f8
+	case i == 0 || s[i-1] == '\n':
+		goto f9
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 47 || r >= 58 && r <= 96 || r >= 123:
+		goto f1
+	case r >= 48 && r <= 57:
+		goto f3
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f5:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto f7
+	}
+	goto reverse
+f6:
+	switch {
+	case (i > 0 && matchSearchAssertions9ef41babIsWordChar(s[i-1])) != (i < len(s) && matchSearchAssertions9ef41babIsWordChar(s[i])):
+		end = i
+		goto f5
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 47 || r >= 58 && r <= 96 || r >= 123:
+		goto f1
+	case r >= 48 && r <= 57:
+		goto f3
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f7:
+	switch {
+	case (i > 0 && matchSearchAssertions9ef41babIsWordChar(s[i-1])) != (i < len(s) && matchSearchAssertions9ef41babIsWordChar(s[i])):
+		end = i
+		goto f5
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r >= 48 && r <= 57:
+		goto f7
+	}
+	goto reverse
+f8:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto f10
+	}
+	goto reverse
+f9:
+	switch {
+	case i == len(s) || s[i] == '\n':
+		end = i
+		goto f8
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 47 || r >= 58 && r <= 96 || r >= 123:
+		goto f1
+	case r >= 48 && r <= 57:
+		goto f3
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f10:
+	switch {
+	case i == len(s) || s[i] == '\n':
+		end = i
+		goto f8
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto f10
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	switch {
+	case (i > 0 && matchSearchAssertions9ef41babIsWordChar(s[i-1])) != (i < len(s) && matchSearchAssertions9ef41babIsWordChar(s[i])):
+		goto r2
+	case i == len(s) || s[i] == '\n':
+		goto r3
+	}
+	return
+r2:
+	switch {
+	case i == len(s) || s[i] == '\n':
+		goto r4
+	}
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 48 && r <= 57:
+		start = i
+		goto r5
+	}
+	return
+r3:
+	switch {
+	case (i > 0 && matchSearchAssertions9ef41babIsWordChar(s[i-1])) != (i < len(s) && matchSearchAssertions9ef41babIsWordChar(s[i])):
+		goto r4
+	}
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto r6
+	}
+	return
+r4:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 48 && r <= 57:
+		start = i
+		goto r5
+	case r >= 97 && r <= 122:
+		goto r6
+	}
+	return
+r5:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 48 && r <= 57:
+		start = i
+		goto r5
+	}
+	return
+r6:
+	switch {
+	case i == 0 || s[i-1] == '\n':
+		start = i
+		goto r7
+	}
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto r6
+	}
+	return
+r7:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto r8
+	}
+	return
+r8:
+	switch {
+	case i == 0 || s[i-1] == '\n':
+		start = i
+		goto r7
+	}
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto r8
+	}
+	return
+}
+
+func matchSearchAssertions9ef41babIsWordChar(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_'
+}
@@ -0,0 +1,278 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchSearchAssertionsAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("(?m)^[a-z]+$|\\d+\\b")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"008765",
+		"18",
+		"32",
+		"33597349541120",
+		"418",
+		"5274656",
+		"6",
+		"61710262454",
+		"82372199901236",
+		"9",
+		"992",
+		"993174447864095",
+		"alt",
+		"gu",
+		"j",
+		"tkuqvgcir",
+		"tvmbda",
+		"wdt",
+		"x",
+		"yw",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"008N65",
+		"322",
+		"3359734954110",
+		"33597349541120)",
+		"8237219990",
+		"82372199901236W",
+		"99",
+		"g",
+		"jT",
+		"k8",
+		"tkqvgcir",
+		"tvmb",
+		"wdt|",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+		"x008765x",
+		"008765 008765",
+		"x18x",
+		"18 18",
+		"x32x",
+		"32 32",
+		"x33597349541120x",
+		"33597349541120 33597349541120",
+		"x418x",
+		"418 418",
+		"x5274656x",
+		"5274656 5274656",
+		"x6x",
+		"6 6",
+		"x61710262454x",
+		"61710262454 61710262454",
+		"x82372199901236x",
+		"82372199901236 82372199901236",
+		"x9x",
+		"9 9",
+		"x992x",
+		"992 992",
+		"x993174447864095x",
+		"993174447864095 993174447864095",
+		"xaltx",
+		"alt alt",
+		"xgux",
+		"gu gu",
+		"xjx",
+		"j j",
+		"xtkuqvgcirx",
+		"tkuqvgcir tkuqvgcir",
+		"xtvmbdax",
+		"tvmbda tvmbda",
+		"xwdtx",
+		"wdt wdt",
+		"xxx",
+		"x x",
+		"xywx",
+		"yw yw",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchAssertions(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchAssertions(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchAssertions(f *testing.F) {
+	re := regexp.MustCompile("(?m)^[a-z]+$|\\d+\\b")
+
+	for _, s := range []string{
+		"008765",
+		"18",
+		"32",
+		"33597349541120",
+		"418",
+		"5274656",
+		"6",
+		"61710262454",
+		"82372199901236",
+		"9",
+		"992",
+		"993174447864095",
+		"alt",
+		"gu",
+		"j",
+		"tkuqvgcir",
+		"tvmbda",
+		"wdt",
+		"x",
+		"yw",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchAssertions(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchAssertions(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchSearchAssertionsBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("(?m)^[a-z]+$|\\d+\\b")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"008765",
+		"18",
+		"32",
+		"33597349541120",
+		"418",
+		"5274656",
+		"6",
+		"61710262454",
+		"82372199901236",
+		"9",
+		"992",
+		"993174447864095",
+		"alt",
+		"gu",
+		"j",
+		"tkuqvgcir",
+		"tvmbda",
+		"wdt",
+		"x",
+		"yw",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"008N65",
+		"322",
+		"3359734954110",
+		"33597349541120)",
+		"8237219990",
+		"82372199901236W",
+		"99",
+		"g",
+		"jT",
+		"k8",
+		"tkqvgcir",
+		"tvmb",
+		"wdt|",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+		"x008765x",
+		"008765 008765",
+		"x18x",
+		"18 18",
+		"x32x",
+		"32 32",
+		"x33597349541120x",
+		"33597349541120 33597349541120",
+		"x418x",
+		"418 418",
+		"x5274656x",
+		"5274656 5274656",
+		"x6x",
+		"6 6",
+		"x61710262454x",
+		"61710262454 61710262454",
+		"x82372199901236x",
+		"82372199901236 82372199901236",
+		"x9x",
+		"9 9",
+		"x992x",
+		"992 992",
+		"x993174447864095x",
+		"993174447864095 993174447864095",
+		"xaltx",
+		"alt alt",
+		"xgux",
+		"gu gu",
+		"xjx",
+		"j j",
+		"xtkuqvgcirx",
+		"tkuqvgcir tkuqvgcir",
+		"xtvmbdax",
+		"tvmbda tvmbda",
+		"xwdtx",
+		"wdt wdt",
+		"xxx",
+		"x x",
+		"xywx",
+		"yw yw",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchAssertionsBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchAssertionsBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchAssertionsBytes(f *testing.F) {
+	re := regexp.MustCompile("(?m)^[a-z]+$|\\d+\\b")
+
+	for _, s := range []string{
+		"008765",
+		"18",
+		"32",
+		"33597349541120",
+		"418",
+		"5274656",
+		"6",
+		"61710262454",
+		"82372199901236",
+		"9",
+		"992",
+		"993174447864095",
+		"alt",
+		"gu",
+		"j",
+		"tkuqvgcir",
+		"tvmbda",
+		"wdt",
+		"x",
+		"yw",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchAssertionsBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchAssertionsBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
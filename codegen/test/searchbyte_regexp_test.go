@@ -0,0 +1,278 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchSearchByteAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("x[a-c]*")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"x",
+		"xa",
+		"xaa",
+		"xaaac",
+		"xaba",
+		"xabc",
+		"xabccc",
+		"xb",
+		"xba",
+		"xbaab",
+		"xbaaccabc",
+		"xbb",
+		"xbc",
+		"xbcc",
+		"xc",
+		"xca",
+		"xcaa",
+		"xcb",
+		"xcba",
+		"xcbb",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"b",
+		"cbb",
+		"kc",
+		"obb",
+		"x2",
+		"x2aa",
+		"x]",
+		"xaK",
+		"xabNcc",
+		"xb)",
+		"xbKab",
+		"xbat",
+		"xcbT",
+		"xza",
+		"é",
+		"日本",
+		"\xff",
+		"xxx",
+		"x x",
+		"xxax",
+		"xa xa",
+		"xxaax",
+		"xaa xaa",
+		"xxaaacx",
+		"xaaac xaaac",
+		"xxabax",
+		"xaba xaba",
+		"xxabcx",
+		"xabc xabc",
+		"xxabcccx",
+		"xabccc xabccc",
+		"xxbx",
+		"xb xb",
+		"xxbax",
+		"xba xba",
+		"xxbaabx",
+		"xbaab xbaab",
+		"xxbaaccabcx",
+		"xbaaccabc xbaaccabc",
+		"xxbbx",
+		"xbb xbb",
+		"xxbcx",
+		"xbc xbc",
+		"xxbccx",
+		"xbcc xbcc",
+		"xxcx",
+		"xc xc",
+		"xxcax",
+		"xca xca",
+		"xxcaax",
+		"xcaa xcaa",
+		"xxcbx",
+		"xcb xcb",
+		"xxcbax",
+		"xcba xcba",
+		"xxcbbx",
+		"xcbb xcbb",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchByte(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchByte(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchByte(f *testing.F) {
+	re := regexp.MustCompile("x[a-c]*")
+
+	for _, s := range []string{
+		"x",
+		"xa",
+		"xaa",
+		"xaaac",
+		"xaba",
+		"xabc",
+		"xabccc",
+		"xb",
+		"xba",
+		"xbaab",
+		"xbaaccabc",
+		"xbb",
+		"xbc",
+		"xbcc",
+		"xc",
+		"xca",
+		"xcaa",
+		"xcb",
+		"xcba",
+		"xcbb",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchByte(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchByte(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchSearchByteBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("x[a-c]*")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"x",
+		"xa",
+		"xaa",
+		"xaaac",
+		"xaba",
+		"xabc",
+		"xabccc",
+		"xb",
+		"xba",
+		"xbaab",
+		"xbaaccabc",
+		"xbb",
+		"xbc",
+		"xbcc",
+		"xc",
+		"xca",
+		"xcaa",
+		"xcb",
+		"xcba",
+		"xcbb",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"b",
+		"cbb",
+		"kc",
+		"obb",
+		"x2",
+		"x2aa",
+		"x]",
+		"xaK",
+		"xabNcc",
+		"xb)",
+		"xbKab",
+		"xbat",
+		"xcbT",
+		"xza",
+		"é",
+		"日本",
+		"\xff",
+		"xxx",
+		"x x",
+		"xxax",
+		"xa xa",
+		"xxaax",
+		"xaa xaa",
+		"xxaaacx",
+		"xaaac xaaac",
+		"xxabax",
+		"xaba xaba",
+		"xxabcx",
+		"xabc xabc",
+		"xxabcccx",
+		"xabccc xabccc",
+		"xxbx",
+		"xb xb",
+		"xxbax",
+		"xba xba",
+		"xxbaabx",
+		"xbaab xbaab",
+		"xxbaaccabcx",
+		"xbaaccabc xbaaccabc",
+		"xxbbx",
+		"xbb xbb",
+		"xxbcx",
+		"xbc xbc",
+		"xxbccx",
+		"xbcc xbcc",
+		"xxcx",
+		"xc xc",
+		"xxcax",
+		"xca xca",
+		"xxcaax",
+		"xcaa xcaa",
+		"xxcbx",
+		"xcb xcb",
+		"xxcbax",
+		"xcba xcba",
+		"xxcbbx",
+		"xcbb xcbb",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchByteBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchByteBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchByteBytes(f *testing.F) {
+	re := regexp.MustCompile("x[a-c]*")
+
+	for _, s := range []string{
+		"x",
+		"xa",
+		"xaa",
+		"xaaac",
+		"xaba",
+		"xabc",
+		"xabccc",
+		"xb",
+		"xba",
+		"xbaab",
+		"xbaaccabc",
+		"xbb",
+		"xbc",
+		"xbcc",
+		"xc",
+		"xca",
+		"xcaa",
+		"xcb",
+		"xcba",
+		"xcbb",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchByteBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchByteBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
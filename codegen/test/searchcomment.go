@@ -0,0 +1,723 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+func matchSearchComment(s string) (start, end int) {
+	if strings.IndexByte(s, '>') < 0 {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	i = strings.Index(s, "<!--")
+	if i < 0 {
+		return -1, -1
+	}
+	end = -1
+f1:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 59 || r >= 61:
+		goto f1
+	case r == 60:
+		goto f2
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 32 || r >= 34 && r <= 59 || r >= 61:
+		goto f1
+	case r == 33:
+		goto f3
+	case r == 60:
+		goto f2
+	}
+	goto reverse
+f3:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto f1
+	case r == 45:
+		goto f4
+	case r == 60:
+		goto f2
+	}
+	goto reverse
+f4:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto f1
+	case r == 45:
+		goto f5
+	case r == 60:
+		goto f2
+	}
+	goto reverse
+f5:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto f5
+	case r == 10:
+		goto f1
+	case r == 45:
+		goto f6
+	case r == 60:
+		goto f7
+	}
+	goto reverse
+f6:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto f5
+	case r == 10:
+		goto f1
+	case r == 45:
+		goto f8
+	case r == 60:
+		goto f7
+	}
+	goto reverse
+f7:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 32 || r >= 34 && r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto f5
+	case r == 10:
+		goto f1
+	case r == 33:
+		goto f10
+	case r == 45:
+		goto f6
+	case r == 60:
+		goto f7
+	}
+	goto reverse
+f8:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46 && r <= 59 || r == 61 || r >= 63:
+		goto f5
+	case r == 10:
+		goto f1
+	case r == 45:
+		goto f8
+	case r == 60:
+		goto f7
+	case r == 62:
+		end = i
+	}
+	goto reverse
+f10:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto f5
+	case r == 10:
+		goto f1
+	case r == 45:
+		goto f11
+	case r == 60:
+		goto f7
+	}
+	goto reverse
+f11:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto f5
+	case r == 10:
+		goto f1
+	case r == 45:
+		goto f8
+	case r == 60:
+		goto f7
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 62:
+		goto r2
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 45:
+		goto r3
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 45:
+		goto r4
+	}
+	return
+r4:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46:
+		goto r5
+	case r == 45:
+		goto r6
+	}
+	return
+r5:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46:
+		goto r5
+	case r == 45:
+		goto r6
+	}
+	return
+r6:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46:
+		goto r5
+	case r == 45:
+		goto r7
+	}
+	return
+r7:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 32 || r >= 34 && r <= 44 || r >= 46:
+		goto r5
+	case r == 33:
+		goto r8
+	case r == 45:
+		goto r7
+	}
+	return
+r8:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto r5
+	case r == 45:
+		goto r6
+	case r == 60:
+		start = i
+		goto r9
+	}
+	return
+r9:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46:
+		goto r10
+	case r == 45:
+		goto r11
+	}
+	return
+r10:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46:
+		goto r10
+	case r == 45:
+		goto r11
+	}
+	return
+r11:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46:
+		goto r10
+	case r == 45:
+		goto r12
+	}
+	return
+r12:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 32 || r >= 34 && r <= 44 || r >= 46:
+		goto r10
+	case r == 33:
+		goto r13
+	case r == 45:
+		goto r12
+	}
+	return
+r13:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto r10
+	case r == 45:
+		goto r11
+	case r == 60:
+		start = i
+		goto r9
+	}
+	return
+}
+
+func matchSearchCommentBytes(s []byte) (start, end int) {
+	if bytes.IndexByte(s, '>') < 0 {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	i = bytes.Index(s, []byte("<!--"))
+	if i < 0 {
+		return -1, -1
+	}
+	end = -1
+f1:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 59 || r >= 61:
+		goto f1
+	case r == 60:
+		goto f2
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 32 || r >= 34 && r <= 59 || r >= 61:
+		goto f1
+	case r == 33:
+		goto f3
+	case r == 60:
+		goto f2
+	}
+	goto reverse
+f3:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto f1
+	case r == 45:
+		goto f4
+	case r == 60:
+		goto f2
+	}
+	goto reverse
+f4:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto f1
+	case r == 45:
+		goto f5
+	case r == 60:
+		goto f2
+	}
+	goto reverse
+f5:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto f5
+	case r == 10:
+		goto f1
+	case r == 45:
+		goto f6
+	case r == 60:
+		goto f7
+	}
+	goto reverse
+f6:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto f5
+	case r == 10:
+		goto f1
+	case r == 45:
+		goto f8
+	case r == 60:
+		goto f7
+	}
+	goto reverse
+f7:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 32 || r >= 34 && r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto f5
+	case r == 10:
+		goto f1
+	case r == 33:
+		goto f10
+	case r == 45:
+		goto f6
+	case r == 60:
+		goto f7
+	}
+	goto reverse
+f8:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46 && r <= 59 || r == 61 || r >= 63:
+		goto f5
+	case r == 10:
+		goto f1
+	case r == 45:
+		goto f8
+	case r == 60:
+		goto f7
+	case r == 62:
+		end = i
+	}
+	goto reverse
+f10:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto f5
+	case r == 10:
+		goto f1
+	case r == 45:
+		goto f11
+	case r == 60:
+		goto f7
+	}
+	goto reverse
+f11:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto f5
+	case r == 10:
+		goto f1
+	case r == 45:
+		goto f8
+	case r == 60:
+		goto f7
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 62:
+		goto r2
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 45:
+		goto r3
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 45:
+		goto r4
+	}
+	return
+r4:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46:
+		goto r5
+	case r == 45:
+		goto r6
+	}
+	return
+r5:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46:
+		goto r5
+	case r == 45:
+		goto r6
+	}
+	return
+r6:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46:
+		goto r5
+	case r == 45:
+		goto r7
+	}
+	return
+r7:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 32 || r >= 34 && r <= 44 || r >= 46:
+		goto r5
+	case r == 33:
+		goto r8
+	case r == 45:
+		goto r7
+	}
+	return
+r8:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto r5
+	case r == 45:
+		goto r6
+	case r == 60:
+		start = i
+		goto r9
+	}
+	return
+r9:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46:
+		goto r10
+	case r == 45:
+		goto r11
+	}
+	return
+r10:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46:
+		goto r10
+	case r == 45:
+		goto r11
+	}
+	return
+r11:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46:
+		goto r10
+	case r == 45:
+		goto r12
+	}
+	return
+r12:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 32 || r >= 34 && r <= 44 || r >= 46:
+		goto r10
+	case r == 33:
+		goto r13
+	case r == 45:
+		goto r12
+	}
+	return
+r13:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 9 || r >= 11 && r <= 44 || r >= 46 && r <= 59 || r >= 61:
+		goto r10
+	case r == 45:
+		goto r11
+	case r == 60:
+		start = i
+		goto r9
+	}
+	return
+}
@@ -0,0 +1,278 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchSearchCommentAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("<!--.*?-->")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"<!--\r-\"-[)--+---\x03---2-\x10-]--$--D-->",
+		"<!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18--->",
+		"<!--\x14---<-!-\U0008bfae\U000ebb58\"-'-&+⸺-->",
+		"<!-- 9f)--7---`\t\x03-->",
+		"<!--&\t-->",
+		"<!--(.-\f(,'--?\b~--' \a!$-,\x01-(-,4-->",
+		"<!---\"'t!),-}-D#qfa\U000b089d-->",
+		"<!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w-->",
+		"<!---),\U00014b1f5--->",
+		"<!---- -->",
+		"<!------->",
+		"<!----->",
+		"<!----0l-----9}-\uffc9-?-->",
+		"<!---->",
+		"<!---@*---|3&-C-&s-+\x1c-->",
+		"<!---\U00040c8b\U000588a1--->",
+		"<!--1Q-+\x1a-\U0010ab30\x04----->",
+		"<!--J-𨍆\x04---}(c+\x02-@D---->",
+		"<!--hV\x13-T\"-u\b-->",
+		"<!--\U0004c0f8&----\x06-->",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		";!----->",
+		"<!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18--->:",
+		"<!-- 9f)--7---`\t\x03-->~",
+		"<!-- 9fZ--7---`\t\x03-->",
+		"<!--(.-\f('--?\b~--' \a!$-,\x01-(-,4-->",
+		"<!--(.-\f(,'--?\b",
+		"<!---%(---\x1d-)----M(--\x0e\x10-\x14--\U00103b8d\x1a&w-->",
+		"<!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w-->'",
+		"<!---%(---\x1d-)----M-(--\x0e\x10-\x14a-\U00103b8d\x1a&w-->",
+		"<!---- -->$",
+		"<!--hV",
+		"<!--hV\x13-T\"-u\b-->E",
+		"<!--hV\x13-T\"-u\b-7>",
+		"<!-\U0004c0f8&----\x06-->",
+		"é",
+		"日本",
+		"\xff",
+		"x<!--\r-\"-[)--+---\x03---2-\x10-]--$--D-->x",
+		"<!--\r-\"-[)--+---\x03---2-\x10-]--$--D--> <!--\r-\"-[)--+---\x03---2-\x10-]--$--D-->",
+		"x<!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18--->x",
+		"<!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18---> <!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18--->",
+		"x<!--\x14---<-!-\U0008bfae\U000ebb58\"-'-&+⸺-->x",
+		"<!--\x14---<-!-\U0008bfae\U000ebb58\"-'-&+⸺--> <!--\x14---<-!-\U0008bfae\U000ebb58\"-'-&+⸺-->",
+		"x<!-- 9f)--7---`\t\x03-->x",
+		"<!-- 9f)--7---`\t\x03--> <!-- 9f)--7---`\t\x03-->",
+		"x<!--&\t-->x",
+		"<!--&\t--> <!--&\t-->",
+		"x<!--(.-\f(,'--?\b~--' \a!$-,\x01-(-,4-->x",
+		"<!--(.-\f(,'--?\b~--' \a!$-,\x01-(-,4--> <!--(.-\f(,'--?\b~--' \a!$-,\x01-(-,4-->",
+		"x<!---\"'t!),-}-D#qfa\U000b089d-->x",
+		"<!---\"'t!),-}-D#qfa\U000b089d--> <!---\"'t!),-}-D#qfa\U000b089d-->",
+		"x<!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w-->x",
+		"<!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w--> <!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w-->",
+		"x<!---),\U00014b1f5--->x",
+		"<!---),\U00014b1f5---> <!---),\U00014b1f5--->",
+		"x<!---- -->x",
+		"<!---- --> <!---- -->",
+		"x<!------->x",
+		"<!-------> <!------->",
+		"x<!----->x",
+		"<!-----> <!----->",
+		"x<!----0l-----9}-\uffc9-?-->x",
+		"<!----0l-----9}-\uffc9-?--> <!----0l-----9}-\uffc9-?-->",
+		"x<!---->x",
+		"<!----> <!---->",
+		"x<!---@*---|3&-C-&s-+\x1c-->x",
+		"<!---@*---|3&-C-&s-+\x1c--> <!---@*---|3&-C-&s-+\x1c-->",
+		"x<!---\U00040c8b\U000588a1--->x",
+		"<!---\U00040c8b\U000588a1---> <!---\U00040c8b\U000588a1--->",
+		"x<!--1Q-+\x1a-\U0010ab30\x04----->x",
+		"<!--1Q-+\x1a-\U0010ab30\x04-----> <!--1Q-+\x1a-\U0010ab30\x04----->",
+		"x<!--J-𨍆\x04---}(c+\x02-@D---->x",
+		"<!--J-𨍆\x04---}(c+\x02-@D----> <!--J-𨍆\x04---}(c+\x02-@D---->",
+		"x<!--hV\x13-T\"-u\b-->x",
+		"<!--hV\x13-T\"-u\b--> <!--hV\x13-T\"-u\b-->",
+		"x<!--\U0004c0f8&----\x06-->x",
+		"<!--\U0004c0f8&----\x06--> <!--\U0004c0f8&----\x06-->",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchComment(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchComment(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchComment(f *testing.F) {
+	re := regexp.MustCompile("<!--.*?-->")
+
+	for _, s := range []string{
+		"<!--\r-\"-[)--+---\x03---2-\x10-]--$--D-->",
+		"<!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18--->",
+		"<!--\x14---<-!-\U0008bfae\U000ebb58\"-'-&+⸺-->",
+		"<!-- 9f)--7---`\t\x03-->",
+		"<!--&\t-->",
+		"<!--(.-\f(,'--?\b~--' \a!$-,\x01-(-,4-->",
+		"<!---\"'t!),-}-D#qfa\U000b089d-->",
+		"<!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w-->",
+		"<!---),\U00014b1f5--->",
+		"<!---- -->",
+		"<!------->",
+		"<!----->",
+		"<!----0l-----9}-\uffc9-?-->",
+		"<!---->",
+		"<!---@*---|3&-C-&s-+\x1c-->",
+		"<!---\U00040c8b\U000588a1--->",
+		"<!--1Q-+\x1a-\U0010ab30\x04----->",
+		"<!--J-𨍆\x04---}(c+\x02-@D---->",
+		"<!--hV\x13-T\"-u\b-->",
+		"<!--\U0004c0f8&----\x06-->",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchComment(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchComment(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchSearchCommentBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("<!--.*?-->")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"<!--\r-\"-[)--+---\x03---2-\x10-]--$--D-->",
+		"<!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18--->",
+		"<!--\x14---<-!-\U0008bfae\U000ebb58\"-'-&+⸺-->",
+		"<!-- 9f)--7---`\t\x03-->",
+		"<!--&\t-->",
+		"<!--(.-\f(,'--?\b~--' \a!$-,\x01-(-,4-->",
+		"<!---\"'t!),-}-D#qfa\U000b089d-->",
+		"<!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w-->",
+		"<!---),\U00014b1f5--->",
+		"<!---- -->",
+		"<!------->",
+		"<!----->",
+		"<!----0l-----9}-\uffc9-?-->",
+		"<!---->",
+		"<!---@*---|3&-C-&s-+\x1c-->",
+		"<!---\U00040c8b\U000588a1--->",
+		"<!--1Q-+\x1a-\U0010ab30\x04----->",
+		"<!--J-𨍆\x04---}(c+\x02-@D---->",
+		"<!--hV\x13-T\"-u\b-->",
+		"<!--\U0004c0f8&----\x06-->",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		";!----->",
+		"<!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18--->:",
+		"<!-- 9f)--7---`\t\x03-->~",
+		"<!-- 9fZ--7---`\t\x03-->",
+		"<!--(.-\f('--?\b~--' \a!$-,\x01-(-,4-->",
+		"<!--(.-\f(,'--?\b",
+		"<!---%(---\x1d-)----M(--\x0e\x10-\x14--\U00103b8d\x1a&w-->",
+		"<!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w-->'",
+		"<!---%(---\x1d-)----M-(--\x0e\x10-\x14a-\U00103b8d\x1a&w-->",
+		"<!---- -->$",
+		"<!--hV",
+		"<!--hV\x13-T\"-u\b-->E",
+		"<!--hV\x13-T\"-u\b-7>",
+		"<!-\U0004c0f8&----\x06-->",
+		"é",
+		"日本",
+		"\xff",
+		"x<!--\r-\"-[)--+---\x03---2-\x10-]--$--D-->x",
+		"<!--\r-\"-[)--+---\x03---2-\x10-]--$--D--> <!--\r-\"-[)--+---\x03---2-\x10-]--$--D-->",
+		"x<!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18--->x",
+		"<!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18---> <!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18--->",
+		"x<!--\x14---<-!-\U0008bfae\U000ebb58\"-'-&+⸺-->x",
+		"<!--\x14---<-!-\U0008bfae\U000ebb58\"-'-&+⸺--> <!--\x14---<-!-\U0008bfae\U000ebb58\"-'-&+⸺-->",
+		"x<!-- 9f)--7---`\t\x03-->x",
+		"<!-- 9f)--7---`\t\x03--> <!-- 9f)--7---`\t\x03-->",
+		"x<!--&\t-->x",
+		"<!--&\t--> <!--&\t-->",
+		"x<!--(.-\f(,'--?\b~--' \a!$-,\x01-(-,4-->x",
+		"<!--(.-\f(,'--?\b~--' \a!$-,\x01-(-,4--> <!--(.-\f(,'--?\b~--' \a!$-,\x01-(-,4-->",
+		"x<!---\"'t!),-}-D#qfa\U000b089d-->x",
+		"<!---\"'t!),-}-D#qfa\U000b089d--> <!---\"'t!),-}-D#qfa\U000b089d-->",
+		"x<!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w-->x",
+		"<!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w--> <!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w-->",
+		"x<!---),\U00014b1f5--->x",
+		"<!---),\U00014b1f5---> <!---),\U00014b1f5--->",
+		"x<!---- -->x",
+		"<!---- --> <!---- -->",
+		"x<!------->x",
+		"<!-------> <!------->",
+		"x<!----->x",
+		"<!-----> <!----->",
+		"x<!----0l-----9}-\uffc9-?-->x",
+		"<!----0l-----9}-\uffc9-?--> <!----0l-----9}-\uffc9-?-->",
+		"x<!---->x",
+		"<!----> <!---->",
+		"x<!---@*---|3&-C-&s-+\x1c-->x",
+		"<!---@*---|3&-C-&s-+\x1c--> <!---@*---|3&-C-&s-+\x1c-->",
+		"x<!---\U00040c8b\U000588a1--->x",
+		"<!---\U00040c8b\U000588a1---> <!---\U00040c8b\U000588a1--->",
+		"x<!--1Q-+\x1a-\U0010ab30\x04----->x",
+		"<!--1Q-+\x1a-\U0010ab30\x04-----> <!--1Q-+\x1a-\U0010ab30\x04----->",
+		"x<!--J-𨍆\x04---}(c+\x02-@D---->x",
+		"<!--J-𨍆\x04---}(c+\x02-@D----> <!--J-𨍆\x04---}(c+\x02-@D---->",
+		"x<!--hV\x13-T\"-u\b-->x",
+		"<!--hV\x13-T\"-u\b--> <!--hV\x13-T\"-u\b-->",
+		"x<!--\U0004c0f8&----\x06-->x",
+		"<!--\U0004c0f8&----\x06--> <!--\U0004c0f8&----\x06-->",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchCommentBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchCommentBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchCommentBytes(f *testing.F) {
+	re := regexp.MustCompile("<!--.*?-->")
+
+	for _, s := range []string{
+		"<!--\r-\"-[)--+---\x03---2-\x10-]--$--D-->",
+		"<!--\x14,!\U0007a9f0g$\U0009ab5cM!-\x18--->",
+		"<!--\x14---<-!-\U0008bfae\U000ebb58\"-'-&+⸺-->",
+		"<!-- 9f)--7---`\t\x03-->",
+		"<!--&\t-->",
+		"<!--(.-\f(,'--?\b~--' \a!$-,\x01-(-,4-->",
+		"<!---\"'t!),-}-D#qfa\U000b089d-->",
+		"<!---%(---\x1d-)----M-(--\x0e\x10-\x14--\U00103b8d\x1a&w-->",
+		"<!---),\U00014b1f5--->",
+		"<!---- -->",
+		"<!------->",
+		"<!----->",
+		"<!----0l-----9}-\uffc9-?-->",
+		"<!---->",
+		"<!---@*---|3&-C-&s-+\x1c-->",
+		"<!---\U00040c8b\U000588a1--->",
+		"<!--1Q-+\x1a-\U0010ab30\x04----->",
+		"<!--J-𨍆\x04---}(c+\x02-@D---->",
+		"<!--hV\x13-T\"-u\b-->",
+		"<!--\U0004c0f8&----\x06-->",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchCommentBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchCommentBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
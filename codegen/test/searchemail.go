@@ -0,0 +1,589 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+func matchSearchEmail(s string) (start, end int) {
+	if strings.IndexByte(s, '.') < 0 || strings.IndexByte(s, '@') < 0 || strings.IndexByte(s, 'o') < 0 {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46 || r >= 97 && r <= 122:
+		goto f2
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46 || r >= 97 && r <= 122:
+		goto f2
+	case r == 64:
+		goto f3
+	}
+	goto reverse
+f3:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46:
+		goto f2
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f4:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46:
+		goto f5
+	case r == 64:
+		goto f3
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f5:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46 || r >= 97 && r <= 98 || r >= 100 && r <= 110 || r >= 112 && r <= 122:
+		goto f2
+	case r == 64:
+		goto f3
+	case r == 99:
+		goto f6
+	case r == 111:
+		goto f7
+	}
+	goto reverse
+f6:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46 || r >= 97 && r <= 110 || r >= 112 && r <= 122:
+		goto f2
+	case r == 64:
+		goto f3
+	case r == 111:
+		goto f8
+	}
+	goto reverse
+f7:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46 || r >= 97 && r <= 113 || r >= 115 && r <= 122:
+		goto f2
+	case r == 64:
+		goto f3
+	case r == 114:
+		goto f10
+	}
+	goto reverse
+f8:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46 || r >= 97 && r <= 108 || r >= 110 && r <= 122:
+		goto f2
+	case r == 64:
+		goto f3
+	case r == 109:
+		end = i
+	}
+	goto reverse
+f10:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46 || r >= 97 && r <= 102 || r >= 104 && r <= 122:
+		goto f2
+	case r == 64:
+		goto f3
+	case r == 103:
+		end = i
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 103:
+		goto r2
+	case r == 109:
+		goto r3
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 114:
+		goto r4
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 111:
+		goto r10
+	}
+	return
+r4:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 111:
+		goto r5
+	}
+	return
+r5:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 46:
+		goto r6
+	}
+	return
+r6:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto r7
+	}
+	return
+r7:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 64:
+		goto r8
+	case r >= 97 && r <= 122:
+		goto r7
+	}
+	return
+r8:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 46 || r >= 97 && r <= 122:
+		start = i
+		goto r9
+	}
+	return
+r9:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 46 || r >= 97 && r <= 122:
+		start = i
+		goto r9
+	}
+	return
+r10:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 99:
+		goto r11
+	}
+	return
+r11:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 46:
+		goto r6
+	}
+	return
+}
+
+func matchSearchEmailBytes(s []byte) (start, end int) {
+	if bytes.IndexByte(s, '.') < 0 || bytes.IndexByte(s, '@') < 0 || bytes.IndexByte(s, 'o') < 0 {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46 || r >= 97 && r <= 122:
+		goto f2
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46 || r >= 97 && r <= 122:
+		goto f2
+	case r == 64:
+		goto f3
+	}
+	goto reverse
+f3:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46:
+		goto f2
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f4:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46:
+		goto f5
+	case r == 64:
+		goto f3
+	case r >= 97 && r <= 122:
+		goto f4
+	}
+	goto reverse
+f5:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46 || r >= 97 && r <= 98 || r >= 100 && r <= 110 || r >= 112 && r <= 122:
+		goto f2
+	case r == 64:
+		goto f3
+	case r == 99:
+		goto f6
+	case r == 111:
+		goto f7
+	}
+	goto reverse
+f6:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46 || r >= 97 && r <= 110 || r >= 112 && r <= 122:
+		goto f2
+	case r == 64:
+		goto f3
+	case r == 111:
+		goto f8
+	}
+	goto reverse
+f7:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46 || r >= 97 && r <= 113 || r >= 115 && r <= 122:
+		goto f2
+	case r == 64:
+		goto f3
+	case r == 114:
+		goto f10
+	}
+	goto reverse
+f8:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46 || r >= 97 && r <= 108 || r >= 110 && r <= 122:
+		goto f2
+	case r == 64:
+		goto f3
+	case r == 109:
+		end = i
+	}
+	goto reverse
+f10:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 45 || r >= 47 && r <= 63 || r >= 65 && r <= 96 || r >= 123:
+		goto f1
+	case r == 46 || r >= 97 && r <= 102 || r >= 104 && r <= 122:
+		goto f2
+	case r == 64:
+		goto f3
+	case r == 103:
+		end = i
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 103:
+		goto r2
+	case r == 109:
+		goto r3
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 114:
+		goto r4
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 111:
+		goto r10
+	}
+	return
+r4:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 111:
+		goto r5
+	}
+	return
+r5:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 46:
+		goto r6
+	}
+	return
+r6:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r >= 97 && r <= 122:
+		goto r7
+	}
+	return
+r7:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 64:
+		goto r8
+	case r >= 97 && r <= 122:
+		goto r7
+	}
+	return
+r8:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 46 || r >= 97 && r <= 122:
+		start = i
+		goto r9
+	}
+	return
+r9:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 46 || r >= 97 && r <= 122:
+		start = i
+		goto r9
+	}
+	return
+r10:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 99:
+		goto r11
+	}
+	return
+r11:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 46:
+		goto r6
+	}
+	return
+}
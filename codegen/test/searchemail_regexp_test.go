@@ -0,0 +1,278 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchSearchEmailAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("[a-z.]+@[a-z]+\\.(com|org)")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"...@avl.org",
+		"...@vcl.com",
+		".@n.com",
+		".@p.org",
+		".@t.com",
+		".@t.org",
+		".@tvoemt.com",
+		".@y.org",
+		".c@hmwvv.org",
+		".f.@j.com",
+		".guij@t.org",
+		"ah@krb.org",
+		"gc@f.org",
+		"i@b.com",
+		"j@b.com",
+		"n@t.org",
+		"nn@y.com",
+		"o@u.com",
+		"r@w.com",
+		"v@yuf.org",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"...@a|l.org",
+		"...@vclcom",
+		".@p.o",
+		".@p.orgn",
+		".@t.comQ",
+		"ah@krb.orgm",
+		"c@hmwvv.org",
+		"gc@'.org",
+		"gc@f.org}",
+		"nn@y.com(",
+		"nn@y.comW",
+		"o@g.com",
+		"o@u.comK",
+		"r",
+		"é",
+		"日本",
+		"\xff",
+		"x...@avl.orgx",
+		"...@avl.org ...@avl.org",
+		"x...@vcl.comx",
+		"...@vcl.com ...@vcl.com",
+		"x.@n.comx",
+		".@n.com .@n.com",
+		"x.@p.orgx",
+		".@p.org .@p.org",
+		"x.@t.comx",
+		".@t.com .@t.com",
+		"x.@t.orgx",
+		".@t.org .@t.org",
+		"x.@tvoemt.comx",
+		".@tvoemt.com .@tvoemt.com",
+		"x.@y.orgx",
+		".@y.org .@y.org",
+		"x.c@hmwvv.orgx",
+		".c@hmwvv.org .c@hmwvv.org",
+		"x.f.@j.comx",
+		".f.@j.com .f.@j.com",
+		"x.guij@t.orgx",
+		".guij@t.org .guij@t.org",
+		"xah@krb.orgx",
+		"ah@krb.org ah@krb.org",
+		"xgc@f.orgx",
+		"gc@f.org gc@f.org",
+		"xi@b.comx",
+		"i@b.com i@b.com",
+		"xj@b.comx",
+		"j@b.com j@b.com",
+		"xn@t.orgx",
+		"n@t.org n@t.org",
+		"xnn@y.comx",
+		"nn@y.com nn@y.com",
+		"xo@u.comx",
+		"o@u.com o@u.com",
+		"xr@w.comx",
+		"r@w.com r@w.com",
+		"xv@yuf.orgx",
+		"v@yuf.org v@yuf.org",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchEmail(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchEmail(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchEmail(f *testing.F) {
+	re := regexp.MustCompile("[a-z.]+@[a-z]+\\.(com|org)")
+
+	for _, s := range []string{
+		"...@avl.org",
+		"...@vcl.com",
+		".@n.com",
+		".@p.org",
+		".@t.com",
+		".@t.org",
+		".@tvoemt.com",
+		".@y.org",
+		".c@hmwvv.org",
+		".f.@j.com",
+		".guij@t.org",
+		"ah@krb.org",
+		"gc@f.org",
+		"i@b.com",
+		"j@b.com",
+		"n@t.org",
+		"nn@y.com",
+		"o@u.com",
+		"r@w.com",
+		"v@yuf.org",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchEmail(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchEmail(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchSearchEmailBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("[a-z.]+@[a-z]+\\.(com|org)")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"...@avl.org",
+		"...@vcl.com",
+		".@n.com",
+		".@p.org",
+		".@t.com",
+		".@t.org",
+		".@tvoemt.com",
+		".@y.org",
+		".c@hmwvv.org",
+		".f.@j.com",
+		".guij@t.org",
+		"ah@krb.org",
+		"gc@f.org",
+		"i@b.com",
+		"j@b.com",
+		"n@t.org",
+		"nn@y.com",
+		"o@u.com",
+		"r@w.com",
+		"v@yuf.org",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"...@a|l.org",
+		"...@vclcom",
+		".@p.o",
+		".@p.orgn",
+		".@t.comQ",
+		"ah@krb.orgm",
+		"c@hmwvv.org",
+		"gc@'.org",
+		"gc@f.org}",
+		"nn@y.com(",
+		"nn@y.comW",
+		"o@g.com",
+		"o@u.comK",
+		"r",
+		"é",
+		"日本",
+		"\xff",
+		"x...@avl.orgx",
+		"...@avl.org ...@avl.org",
+		"x...@vcl.comx",
+		"...@vcl.com ...@vcl.com",
+		"x.@n.comx",
+		".@n.com .@n.com",
+		"x.@p.orgx",
+		".@p.org .@p.org",
+		"x.@t.comx",
+		".@t.com .@t.com",
+		"x.@t.orgx",
+		".@t.org .@t.org",
+		"x.@tvoemt.comx",
+		".@tvoemt.com .@tvoemt.com",
+		"x.@y.orgx",
+		".@y.org .@y.org",
+		"x.c@hmwvv.orgx",
+		".c@hmwvv.org .c@hmwvv.org",
+		"x.f.@j.comx",
+		".f.@j.com .f.@j.com",
+		"x.guij@t.orgx",
+		".guij@t.org .guij@t.org",
+		"xah@krb.orgx",
+		"ah@krb.org ah@krb.org",
+		"xgc@f.orgx",
+		"gc@f.org gc@f.org",
+		"xi@b.comx",
+		"i@b.com i@b.com",
+		"xj@b.comx",
+		"j@b.com j@b.com",
+		"xn@t.orgx",
+		"n@t.org n@t.org",
+		"xnn@y.comx",
+		"nn@y.com nn@y.com",
+		"xo@u.comx",
+		"o@u.com o@u.com",
+		"xr@w.comx",
+		"r@w.com r@w.com",
+		"xv@yuf.orgx",
+		"v@yuf.org v@yuf.org",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchEmailBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchEmailBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchEmailBytes(f *testing.F) {
+	re := regexp.MustCompile("[a-z.]+@[a-z]+\\.(com|org)")
+
+	for _, s := range []string{
+		"...@avl.org",
+		"...@vcl.com",
+		".@n.com",
+		".@p.org",
+		".@t.com",
+		".@t.org",
+		".@tvoemt.com",
+		".@y.org",
+		".c@hmwvv.org",
+		".f.@j.com",
+		".guij@t.org",
+		"ah@krb.org",
+		"gc@f.org",
+		"i@b.com",
+		"j@b.com",
+		"n@t.org",
+		"nn@y.com",
+		"o@u.com",
+		"r@w.com",
+		"v@yuf.org",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchEmailBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchEmailBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
@@ -0,0 +1,198 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchSearchEmptyAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a*")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaaaaa",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"4",
+		"a=aaaaa",
+		"aaD",
+		"aaa,aaa",
+		"aaaEaa",
+		"aaa_",
+		"aaaaa!",
+		"aaaaaaaa6",
+		"aaaaaaaaaa",
+		"aaaaaaaaaaa",
+		"aaaaaf",
+		"aaaah",
+		"asaaaaa",
+		"b",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xaaaaaax",
+		"aaaaaa aaaaaa",
+		"xaaaaaaax",
+		"aaaaaaa aaaaaaa",
+		"xaaaaaaaax",
+		"aaaaaaaa aaaaaaaa",
+		"xaaaaaaaaaaaax",
+		"aaaaaaaaaaaa aaaaaaaaaaaa",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchEmpty(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchEmpty(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchEmpty(f *testing.F) {
+	re := regexp.MustCompile("a*")
+
+	for _, s := range []string{
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchEmpty(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchEmpty(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchSearchEmptyBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a*")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaaaaa",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"4",
+		"a=aaaaa",
+		"aaD",
+		"aaa,aaa",
+		"aaaEaa",
+		"aaa_",
+		"aaaaa!",
+		"aaaaaaaa6",
+		"aaaaaaaaaa",
+		"aaaaaaaaaaa",
+		"aaaaaf",
+		"aaaah",
+		"asaaaaa",
+		"b",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xaaaaaax",
+		"aaaaaa aaaaaa",
+		"xaaaaaaax",
+		"aaaaaaa aaaaaaa",
+		"xaaaaaaaax",
+		"aaaaaaaa aaaaaaaa",
+		"xaaaaaaaaaaaax",
+		"aaaaaaaaaaaa aaaaaaaaaaaa",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchEmptyBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchEmptyBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchEmptyBytes(f *testing.F) {
+	re := regexp.MustCompile("a*")
+
+	for _, s := range []string{
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchEmptyBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchEmptyBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
@@ -0,0 +1,278 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchSearchErrorAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("ERROR: [0-9]+")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"ERROR: 0",
+		"ERROR: 1",
+		"ERROR: 1321",
+		"ERROR: 17",
+		"ERROR: 18",
+		"ERROR: 2",
+		"ERROR: 225",
+		"ERROR: 287920",
+		"ERROR: 3",
+		"ERROR: 33691",
+		"ERROR: 4",
+		"ERROR: 42",
+		"ERROR: 596",
+		"ERROR: 60",
+		"ERROR: 7",
+		"ERROR: 7743702953",
+		"ERROR: 8",
+		"ERROR: 850",
+		"ERROR: 90",
+		"ERROR: 95",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"ERIOR: 95",
+		"ERQOR: 42",
+		"ERR",
+		"ERR R: 95",
+		"ERROR: ",
+		"ERROR: 1321`",
+		"ERROR: 3n",
+		"ERROR: 42,",
+		"ERROR: 60_",
+		"ERROR: 850g",
+		"ERROR: H7",
+		"ERROR:\\17",
+		"ERROR~ 1321",
+		"RROR: 90",
+		"é",
+		"日本",
+		"\xff",
+		"xERROR: 0x",
+		"ERROR: 0 ERROR: 0",
+		"xERROR: 1x",
+		"ERROR: 1 ERROR: 1",
+		"xERROR: 1321x",
+		"ERROR: 1321 ERROR: 1321",
+		"xERROR: 17x",
+		"ERROR: 17 ERROR: 17",
+		"xERROR: 18x",
+		"ERROR: 18 ERROR: 18",
+		"xERROR: 2x",
+		"ERROR: 2 ERROR: 2",
+		"xERROR: 225x",
+		"ERROR: 225 ERROR: 225",
+		"xERROR: 287920x",
+		"ERROR: 287920 ERROR: 287920",
+		"xERROR: 3x",
+		"ERROR: 3 ERROR: 3",
+		"xERROR: 33691x",
+		"ERROR: 33691 ERROR: 33691",
+		"xERROR: 4x",
+		"ERROR: 4 ERROR: 4",
+		"xERROR: 42x",
+		"ERROR: 42 ERROR: 42",
+		"xERROR: 596x",
+		"ERROR: 596 ERROR: 596",
+		"xERROR: 60x",
+		"ERROR: 60 ERROR: 60",
+		"xERROR: 7x",
+		"ERROR: 7 ERROR: 7",
+		"xERROR: 7743702953x",
+		"ERROR: 7743702953 ERROR: 7743702953",
+		"xERROR: 8x",
+		"ERROR: 8 ERROR: 8",
+		"xERROR: 850x",
+		"ERROR: 850 ERROR: 850",
+		"xERROR: 90x",
+		"ERROR: 90 ERROR: 90",
+		"xERROR: 95x",
+		"ERROR: 95 ERROR: 95",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchError(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchError(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchError(f *testing.F) {
+	re := regexp.MustCompile("ERROR: [0-9]+")
+
+	for _, s := range []string{
+		"ERROR: 0",
+		"ERROR: 1",
+		"ERROR: 1321",
+		"ERROR: 17",
+		"ERROR: 18",
+		"ERROR: 2",
+		"ERROR: 225",
+		"ERROR: 287920",
+		"ERROR: 3",
+		"ERROR: 33691",
+		"ERROR: 4",
+		"ERROR: 42",
+		"ERROR: 596",
+		"ERROR: 60",
+		"ERROR: 7",
+		"ERROR: 7743702953",
+		"ERROR: 8",
+		"ERROR: 850",
+		"ERROR: 90",
+		"ERROR: 95",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchError(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchError(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchSearchErrorBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("ERROR: [0-9]+")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"ERROR: 0",
+		"ERROR: 1",
+		"ERROR: 1321",
+		"ERROR: 17",
+		"ERROR: 18",
+		"ERROR: 2",
+		"ERROR: 225",
+		"ERROR: 287920",
+		"ERROR: 3",
+		"ERROR: 33691",
+		"ERROR: 4",
+		"ERROR: 42",
+		"ERROR: 596",
+		"ERROR: 60",
+		"ERROR: 7",
+		"ERROR: 7743702953",
+		"ERROR: 8",
+		"ERROR: 850",
+		"ERROR: 90",
+		"ERROR: 95",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"ERIOR: 95",
+		"ERQOR: 42",
+		"ERR",
+		"ERR R: 95",
+		"ERROR: ",
+		"ERROR: 1321`",
+		"ERROR: 3n",
+		"ERROR: 42,",
+		"ERROR: 60_",
+		"ERROR: 850g",
+		"ERROR: H7",
+		"ERROR:\\17",
+		"ERROR~ 1321",
+		"RROR: 90",
+		"é",
+		"日本",
+		"\xff",
+		"xERROR: 0x",
+		"ERROR: 0 ERROR: 0",
+		"xERROR: 1x",
+		"ERROR: 1 ERROR: 1",
+		"xERROR: 1321x",
+		"ERROR: 1321 ERROR: 1321",
+		"xERROR: 17x",
+		"ERROR: 17 ERROR: 17",
+		"xERROR: 18x",
+		"ERROR: 18 ERROR: 18",
+		"xERROR: 2x",
+		"ERROR: 2 ERROR: 2",
+		"xERROR: 225x",
+		"ERROR: 225 ERROR: 225",
+		"xERROR: 287920x",
+		"ERROR: 287920 ERROR: 287920",
+		"xERROR: 3x",
+		"ERROR: 3 ERROR: 3",
+		"xERROR: 33691x",
+		"ERROR: 33691 ERROR: 33691",
+		"xERROR: 4x",
+		"ERROR: 4 ERROR: 4",
+		"xERROR: 42x",
+		"ERROR: 42 ERROR: 42",
+		"xERROR: 596x",
+		"ERROR: 596 ERROR: 596",
+		"xERROR: 60x",
+		"ERROR: 60 ERROR: 60",
+		"xERROR: 7x",
+		"ERROR: 7 ERROR: 7",
+		"xERROR: 7743702953x",
+		"ERROR: 7743702953 ERROR: 7743702953",
+		"xERROR: 8x",
+		"ERROR: 8 ERROR: 8",
+		"xERROR: 850x",
+		"ERROR: 850 ERROR: 850",
+		"xERROR: 90x",
+		"ERROR: 90 ERROR: 90",
+		"xERROR: 95x",
+		"ERROR: 95 ERROR: 95",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchErrorBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchErrorBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchErrorBytes(f *testing.F) {
+	re := regexp.MustCompile("ERROR: [0-9]+")
+
+	for _, s := range []string{
+		"ERROR: 0",
+		"ERROR: 1",
+		"ERROR: 1321",
+		"ERROR: 17",
+		"ERROR: 18",
+		"ERROR: 2",
+		"ERROR: 225",
+		"ERROR: 287920",
+		"ERROR: 3",
+		"ERROR: 33691",
+		"ERROR: 4",
+		"ERROR: 42",
+		"ERROR: 596",
+		"ERROR: 60",
+		"ERROR: 7",
+		"ERROR: 7743702953",
+		"ERROR: 8",
+		"ERROR: 850",
+		"ERROR: 90",
+		"ERROR: 95",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchErrorBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchErrorBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
@@ -0,0 +1,150 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchSearchFirstAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("(a|ab)(c|bcd)")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"abbcd",
+		"abc",
+		"abcd",
+		"ac",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"a",
+		"a0",
+		"aI",
+		"ab",
+		"ab$",
+		"abbc",
+		"abbca",
+		"abbcdC",
+		"abbd",
+		"abbjd",
+		"abcz",
+		"ach",
+		"bbcd",
+		"c",
+		"é",
+		"日本",
+		"\xff",
+		"xabbcdx",
+		"abbcd abbcd",
+		"xabcx",
+		"abc abc",
+		"xabcdx",
+		"abcd abcd",
+		"xacx",
+		"ac ac",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchFirst(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchFirst(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchFirst(f *testing.F) {
+	re := regexp.MustCompile("(a|ab)(c|bcd)")
+
+	for _, s := range []string{
+		"abbcd",
+		"abc",
+		"abcd",
+		"ac",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchFirst(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchFirst(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchSearchFirstBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("(a|ab)(c|bcd)")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"abbcd",
+		"abc",
+		"abcd",
+		"ac",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"a",
+		"a0",
+		"aI",
+		"ab",
+		"ab$",
+		"abbc",
+		"abbca",
+		"abbcdC",
+		"abbd",
+		"abbjd",
+		"abcz",
+		"ach",
+		"bbcd",
+		"c",
+		"é",
+		"日本",
+		"\xff",
+		"xabbcdx",
+		"abbcd abbcd",
+		"xabcx",
+		"abc abc",
+		"xabcdx",
+		"abcd abcd",
+		"xacx",
+		"ac ac",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchFirstBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchFirstBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchFirstBytes(f *testing.F) {
+	re := regexp.MustCompile("(a|ab)(c|bcd)")
+
+	for _, s := range []string{
+		"abbcd",
+		"abc",
+		"abcd",
+		"ac",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchFirstBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchFirstBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
@@ -0,0 +1,174 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchSearchLazyAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a+?b")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"aaaaaaaaaab",
+		"aaaaaab",
+		"aaaaab",
+		"aaaab",
+		"aaab",
+		"aab",
+		"ab",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"Daaab",
+		"a",
+		"a;aaaab",
+		"aa",
+		"aaaaa",
+		"aaaaaa5",
+		"aaaaaaaaaab~",
+		"aaaaaab$",
+		"aaaabG",
+		"aaabS",
+		"aaabh",
+		"aab ",
+		"ab`",
+		"b",
+		"é",
+		"日本",
+		"\xff",
+		"xaaaaaaaaaabx",
+		"aaaaaaaaaab aaaaaaaaaab",
+		"xaaaaaabx",
+		"aaaaaab aaaaaab",
+		"xaaaaabx",
+		"aaaaab aaaaab",
+		"xaaaabx",
+		"aaaab aaaab",
+		"xaaabx",
+		"aaab aaab",
+		"xaabx",
+		"aab aab",
+		"xabx",
+		"ab ab",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchLazy(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchLazy(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchLazy(f *testing.F) {
+	re := regexp.MustCompile("a+?b")
+
+	for _, s := range []string{
+		"aaaaaaaaaab",
+		"aaaaaab",
+		"aaaaab",
+		"aaaab",
+		"aaab",
+		"aab",
+		"ab",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchLazy(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchLazy(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchSearchLazyBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a+?b")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"aaaaaaaaaab",
+		"aaaaaab",
+		"aaaaab",
+		"aaaab",
+		"aaab",
+		"aab",
+		"ab",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"Daaab",
+		"a",
+		"a;aaaab",
+		"aa",
+		"aaaaa",
+		"aaaaaa5",
+		"aaaaaaaaaab~",
+		"aaaaaab$",
+		"aaaabG",
+		"aaabS",
+		"aaabh",
+		"aab ",
+		"ab`",
+		"b",
+		"é",
+		"日本",
+		"\xff",
+		"xaaaaaaaaaabx",
+		"aaaaaaaaaab aaaaaaaaaab",
+		"xaaaaaabx",
+		"aaaaaab aaaaaab",
+		"xaaaaabx",
+		"aaaaab aaaaab",
+		"xaaaabx",
+		"aaaab aaaab",
+		"xaaabx",
+		"aaab aaab",
+		"xaabx",
+		"aab aab",
+		"xabx",
+		"ab ab",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchLazyBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchLazyBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchLazyBytes(f *testing.F) {
+	re := regexp.MustCompile("a+?b")
+
+	for _, s := range []string{
+		"aaaaaaaaaab",
+		"aaaaaab",
+		"aaaaab",
+		"aaaab",
+		"aaab",
+		"aab",
+		"ab",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchLazyBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchLazyBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
@@ -0,0 +1,134 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchSearchLeftmostAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a|bcd")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		"bcd",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		" cd",
+		"#",
+		"Bcd",
+		"a)",
+		"a+",
+		"a,",
+		"a/",
+		"ag",
+		"bc",
+		"bcA",
+		"bcd;",
+		"bcdH",
+		"bcdb",
+		"s",
+		"é",
+		"日本",
+		"\xff",
+		"xax",
+		"a a",
+		"xbcdx",
+		"bcd bcd",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchLeftmost(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchLeftmost(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchLeftmost(f *testing.F) {
+	re := regexp.MustCompile("a|bcd")
+
+	for _, s := range []string{
+		"a",
+		"bcd",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchLeftmost(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchLeftmost(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchSearchLeftmostBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a|bcd")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		"bcd",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		" cd",
+		"#",
+		"Bcd",
+		"a)",
+		"a+",
+		"a,",
+		"a/",
+		"ag",
+		"bc",
+		"bcA",
+		"bcd;",
+		"bcdH",
+		"bcdb",
+		"s",
+		"é",
+		"日本",
+		"\xff",
+		"xax",
+		"a a",
+		"xbcdx",
+		"bcd bcd",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchLeftmostBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchLeftmostBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchLeftmostBytes(f *testing.F) {
+	re := regexp.MustCompile("a|bcd")
+
+	for _, s := range []string{
+		"a",
+		"bcd",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchLeftmostBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchLeftmostBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
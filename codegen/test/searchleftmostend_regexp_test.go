@@ -0,0 +1,134 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchSearchLeftmostEndAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("abcd|c")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"abcd",
+		"c",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"'",
+		":",
+		"A",
+		"a!cd",
+		"a4cd",
+		"abc",
+		"abcd4",
+		"acd",
+		"c/",
+		"cA",
+		"cV",
+		"c[",
+		"cj",
+		"cv",
+		"é",
+		"日本",
+		"\xff",
+		"xabcdx",
+		"abcd abcd",
+		"xcx",
+		"c c",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchLeftmostEnd(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchLeftmostEnd(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchLeftmostEnd(f *testing.F) {
+	re := regexp.MustCompile("abcd|c")
+
+	for _, s := range []string{
+		"abcd",
+		"c",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchLeftmostEnd(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchLeftmostEnd(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchSearchLeftmostEndBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("abcd|c")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"abcd",
+		"c",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"'",
+		":",
+		"A",
+		"a!cd",
+		"a4cd",
+		"abc",
+		"abcd4",
+		"acd",
+		"c/",
+		"cA",
+		"cV",
+		"c[",
+		"cj",
+		"cv",
+		"é",
+		"日本",
+		"\xff",
+		"xabcdx",
+		"abcd abcd",
+		"xcx",
+		"c c",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchLeftmostEndBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchLeftmostEndBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchLeftmostEndBytes(f *testing.F) {
+	re := regexp.MustCompile("abcd|c")
+
+	for _, s := range []string{
+		"abcd",
+		"c",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchLeftmostEndBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchLeftmostEndBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
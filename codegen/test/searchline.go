@@ -0,0 +1,347 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+func matchSearchLine(s string) (start, end int) {
+	if strings.IndexByte(s, 'a') < 0 {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	switch {
+	case i == 0 || s[i-1] == '\n':
+		goto f2
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 1114111:
+		goto f1
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f3
+	}
+	goto reverse
+f3:
+	switch {
+	case i == len(s) || s[i] == '\n':
+		end = i
+		goto f4
+	case i == 0 || s[i-1] == '\n':
+		goto f5
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f3
+	}
+	goto reverse
+f4:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r == 97:
+		goto f6
+	}
+	goto reverse
+f5:
+	switch {
+	case i == len(s) || s[i] == '\n':
+		end = i
+		goto f4
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f3
+	}
+	goto reverse
+f6:
+	switch {
+	case i == len(s) || s[i] == '\n':
+		end = i
+		goto f4
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r == 97:
+		goto f6
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	switch {
+	case i == len(s) || s[i] == '\n':
+		goto r2
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		goto r3
+	}
+	return
+r3:
+	switch {
+	case i == 0 || s[i-1] == '\n':
+		start = i
+		goto r4
+	}
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		goto r3
+	}
+	return
+r4:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		goto r5
+	}
+	return
+r5:
+	switch {
+	case i == 0 || s[i-1] == '\n':
+		start = i
+		goto r4
+	}
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		goto r5
+	}
+	return
+}
+
+func matchSearchLineBytes(s []byte) (start, end int) {
+	if bytes.IndexByte(s, 'a') < 0 {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	switch {
+	case i == 0 || s[i-1] == '\n':
+		goto f2
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 1114111:
+		goto f1
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f3
+	}
+	goto reverse
+f3:
+	switch {
+	case i == len(s) || s[i] == '\n':
+		end = i
+		goto f4
+	case i == 0 || s[i-1] == '\n':
+		goto f5
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f3
+	}
+	goto reverse
+f4:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r == 97:
+		goto f6
+	}
+	goto reverse
+f5:
+	switch {
+	case i == len(s) || s[i] == '\n':
+		end = i
+		goto f4
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f3
+	}
+	goto reverse
+f6:
+	switch {
+	case i == len(s) || s[i] == '\n':
+		end = i
+		goto f4
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r == 97:
+		goto f6
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	switch {
+	case i == len(s) || s[i] == '\n':
+		goto r2
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		goto r3
+	}
+	return
+r3:
+	switch {
+	case i == 0 || s[i-1] == '\n':
+		start = i
+		goto r4
+	}
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		goto r3
+	}
+	return
+r4:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		goto r5
+	}
+	return
+r5:
+	switch {
+	case i == 0 || s[i-1] == '\n':
+		start = i
+		goto r4
+	}
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		goto r5
+	}
+	return
+}
@@ -0,0 +1,254 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchSearchLineAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("(?m)^a+$")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaa",
+		"aaaaaaaaaa",
+		"aaaaaaaaaaa",
+		"aaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaaaaaa",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"Qaaa",
+		"a@",
+		"aa%aaaaa",
+		"aaaa&",
+		"aaaa?aaaaaaaa",
+		"aaaaN",
+		"aaaaaa%aaaaaaaaaa",
+		"aaaaaaaaaa ",
+		"aaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaa#",
+		"aaaaaaaaaaaaaaaaaa?",
+		"aaaaaaaaaaaaaaaaaaaI",
+		"aaaaaaaaaaaaaaaaaan",
+		"naaaaaaaa",
+		"é",
+		"日本",
+		"\xff",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xaaaaaax",
+		"aaaaaa aaaaaa",
+		"xaaaaaaax",
+		"aaaaaaa aaaaaaa",
+		"xaaaaaaaax",
+		"aaaaaaaa aaaaaaaa",
+		"xaaaaaaaaax",
+		"aaaaaaaaa aaaaaaaaa",
+		"xaaaaaaaaaax",
+		"aaaaaaaaaa aaaaaaaaaa",
+		"xaaaaaaaaaaax",
+		"aaaaaaaaaaa aaaaaaaaaaa",
+		"xaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaa aaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaa aaaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaaaaaaaa",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchLine(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchLine(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchLine(f *testing.F) {
+	re := regexp.MustCompile("(?m)^a+$")
+
+	for _, s := range []string{
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaa",
+		"aaaaaaaaaa",
+		"aaaaaaaaaaa",
+		"aaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchLine(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchLine(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchSearchLineBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("(?m)^a+$")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaa",
+		"aaaaaaaaaa",
+		"aaaaaaaaaaa",
+		"aaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaaaaaa",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"Qaaa",
+		"a@",
+		"aa%aaaaa",
+		"aaaa&",
+		"aaaa?aaaaaaaa",
+		"aaaaN",
+		"aaaaaa%aaaaaaaaaa",
+		"aaaaaaaaaa ",
+		"aaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaa#",
+		"aaaaaaaaaaaaaaaaaa?",
+		"aaaaaaaaaaaaaaaaaaaI",
+		"aaaaaaaaaaaaaaaaaan",
+		"naaaaaaaa",
+		"é",
+		"日本",
+		"\xff",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xaaaaaax",
+		"aaaaaa aaaaaa",
+		"xaaaaaaax",
+		"aaaaaaa aaaaaaa",
+		"xaaaaaaaax",
+		"aaaaaaaa aaaaaaaa",
+		"xaaaaaaaaax",
+		"aaaaaaaaa aaaaaaaaa",
+		"xaaaaaaaaaax",
+		"aaaaaaaaaa aaaaaaaaaa",
+		"xaaaaaaaaaaax",
+		"aaaaaaaaaaa aaaaaaaaaaa",
+		"xaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaa aaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaa aaaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaaaaa",
+		"xaaaaaaaaaaaaaaaaaaaaaax",
+		"aaaaaaaaaaaaaaaaaaaaaa aaaaaaaaaaaaaaaaaaaaaa",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchLineBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchLineBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchLineBytes(f *testing.F) {
+	re := regexp.MustCompile("(?m)^a+$")
+
+	for _, s := range []string{
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaa",
+		"aaaaaaaaaa",
+		"aaaaaaaaaaa",
+		"aaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaaa",
+		"aaaaaaaaaaaaaaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchLineBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchLineBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
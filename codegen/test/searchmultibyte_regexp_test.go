@@ -0,0 +1,198 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchSearchMultibyteAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("日本+")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"日本",
+		"日本本",
+		"日本本本",
+		"日本本本本",
+		"日本本本本本",
+		"日本本本本本本",
+		"日本本本本本本本",
+		"日本本本本本本本本",
+		"日本本本本本本本本本",
+		"日本本本本本本本本本本",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"S本本本本本本本本",
+		"c本",
+		"o本本本本",
+		"u本本本本本",
+		"é",
+		"日",
+		"日9本本本本本",
+		"日本:本本本本",
+		"日本本W本本本",
+		"日本本{",
+		"日本本本本o",
+		"日本本本本本本U",
+		"日本本本本本本本I",
+		"日本本本本本本本U",
+		"本本",
+		"本本本",
+		"\xff",
+		"x日本x",
+		"日本 日本",
+		"x日本本x",
+		"日本本 日本本",
+		"x日本本本x",
+		"日本本本 日本本本",
+		"x日本本本本x",
+		"日本本本本 日本本本本",
+		"x日本本本本本x",
+		"日本本本本本 日本本本本本",
+		"x日本本本本本本x",
+		"日本本本本本本 日本本本本本本",
+		"x日本本本本本本本x",
+		"日本本本本本本本 日本本本本本本本",
+		"x日本本本本本本本本x",
+		"日本本本本本本本本 日本本本本本本本本",
+		"x日本本本本本本本本本x",
+		"日本本本本本本本本本 日本本本本本本本本本",
+		"x日本本本本本本本本本本x",
+		"日本本本本本本本本本本 日本本本本本本本本本本",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchMultibyte(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchMultibyte(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchMultibyte(f *testing.F) {
+	re := regexp.MustCompile("日本+")
+
+	for _, s := range []string{
+		"日本",
+		"日本本",
+		"日本本本",
+		"日本本本本",
+		"日本本本本本",
+		"日本本本本本本",
+		"日本本本本本本本",
+		"日本本本本本本本本",
+		"日本本本本本本本本本",
+		"日本本本本本本本本本本",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchMultibyte(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchMultibyte(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchSearchMultibyteBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("日本+")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"日本",
+		"日本本",
+		"日本本本",
+		"日本本本本",
+		"日本本本本本",
+		"日本本本本本本",
+		"日本本本本本本本",
+		"日本本本本本本本本",
+		"日本本本本本本本本本",
+		"日本本本本本本本本本本",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"S本本本本本本本本",
+		"c本",
+		"o本本本本",
+		"u本本本本本",
+		"é",
+		"日",
+		"日9本本本本本",
+		"日本:本本本本",
+		"日本本W本本本",
+		"日本本{",
+		"日本本本本o",
+		"日本本本本本本U",
+		"日本本本本本本本I",
+		"日本本本本本本本U",
+		"本本",
+		"本本本",
+		"\xff",
+		"x日本x",
+		"日本 日本",
+		"x日本本x",
+		"日本本 日本本",
+		"x日本本本x",
+		"日本本本 日本本本",
+		"x日本本本本x",
+		"日本本本本 日本本本本",
+		"x日本本本本本x",
+		"日本本本本本 日本本本本本",
+		"x日本本本本本本x",
+		"日本本本本本本 日本本本本本本",
+		"x日本本本本本本本x",
+		"日本本本本本本本 日本本本本本本本",
+		"x日本本本本本本本本x",
+		"日本本本本本本本本 日本本本本本本本本",
+		"x日本本本本本本本本本x",
+		"日本本本本本本本本本 日本本本本本本本本本",
+		"x日本本本本本本本本本本x",
+		"日本本本本本本本本本本 日本本本本本本本本本本",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchMultibyteBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchMultibyteBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchMultibyteBytes(f *testing.F) {
+	re := regexp.MustCompile("日本+")
+
+	for _, s := range []string{
+		"日本",
+		"日本本",
+		"日本本本",
+		"日本本本本",
+		"日本本本本本",
+		"日本本本本本本",
+		"日本本本本本本本",
+		"日本本本本本本本本",
+		"日本本本本本本本本本",
+		"日本本本本本本本本本本",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchMultibyteBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchMultibyteBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
@@ -0,0 +1,865 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+func matchSearchText(s string) (start, end int) {
+	if strings.IndexByte(s, 'b') < 0 {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		goto f2
+	case i == 0:
+		goto f3
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f4
+	}
+	goto reverse
+f2:
+	switch {
+	case i == 0:
+		goto f5
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f4
+	case r == 98:
+		end = i
+	}
+	goto reverse
+f3:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		goto f5
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f7
+	}
+	goto reverse
+f4:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		goto f11
+	case i == 0:
+		goto f12
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f4
+	case r == 98:
+		goto f13
+	}
+	goto reverse
+f5:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f7
+	case r == 98:
+		end = i
+	}
+	goto reverse
+f7:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		goto f8
+	case i == 0:
+		goto f9
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f4
+	case r == 98:
+		end = i
+	}
+	goto reverse
+f8:
+	switch {
+	case i == 0:
+		goto f10
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f4
+	case r == 98:
+		end = i
+	}
+	goto reverse
+f9:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		goto f10
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f7
+	case r == 98:
+		end = i
+	}
+	goto reverse
+f10:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f7
+	case r == 98:
+		end = i
+	}
+	goto reverse
+f11:
+	switch {
+	case i == 0:
+		goto f14
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f4
+	case r == 98:
+		end = i
+		goto f15
+	}
+	goto reverse
+f12:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		goto f14
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f7
+	case r == 98:
+		goto f13
+	}
+	goto reverse
+f13:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		goto f16
+	case i == len(s):
+		end = i
+		goto reverse
+	case i == 0:
+		goto f17
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f4
+	}
+	goto reverse
+f14:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f7
+	case r == 98:
+		end = i
+		goto f15
+	}
+	goto reverse
+f15:
+	switch {
+	case i == len(s):
+		end = i
+	}
+	goto reverse
+f16:
+	switch {
+	case i == len(s):
+		end = i
+		goto reverse
+	case i == 0:
+		goto f18
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r == 98:
+		end = i
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f4
+	}
+	goto reverse
+f17:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		goto f18
+	case i == len(s):
+		end = i
+		goto reverse
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f7
+	}
+	goto reverse
+f18:
+	switch {
+	case i == len(s):
+		end = i
+		goto reverse
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r == 98:
+		end = i
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f7
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	switch {
+	case i == len(s):
+		goto r2
+	}
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 98:
+		goto r3
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 98:
+		goto r4
+	}
+	return
+r3:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		start = i
+		goto r8
+	}
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		goto r9
+	}
+	return
+r4:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		start = i
+		goto r5
+	}
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		start = i
+		goto r6
+	}
+	return
+r5:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		start = i
+		goto r6
+	}
+	return
+r6:
+	switch {
+	case i == 0:
+		start = i
+	}
+	return
+r8:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		goto r10
+	}
+	return
+r9:
+	switch {
+	case i == 0:
+		start = i
+	}
+	return
+r10:
+	switch {
+	case i == 0:
+		start = i
+	}
+	return
+}
+
+func matchSearchTextBytes(s []byte) (start, end int) {
+	if bytes.IndexByte(s, 'b') < 0 {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		goto f2
+	case i == 0:
+		goto f3
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f4
+	}
+	goto reverse
+f2:
+	switch {
+	case i == 0:
+		goto f5
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f4
+	case r == 98:
+		end = i
+	}
+	goto reverse
+f3:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		goto f5
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f7
+	}
+	goto reverse
+f4:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		goto f11
+	case i == 0:
+		goto f12
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f4
+	case r == 98:
+		goto f13
+	}
+	goto reverse
+f5:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f7
+	case r == 98:
+		end = i
+	}
+	goto reverse
+f7:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		goto f8
+	case i == 0:
+		goto f9
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f4
+	case r == 98:
+		end = i
+	}
+	goto reverse
+f8:
+	switch {
+	case i == 0:
+		goto f10
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f4
+	case r == 98:
+		end = i
+	}
+	goto reverse
+f9:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		goto f10
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f7
+	case r == 98:
+		end = i
+	}
+	goto reverse
+f10:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f7
+	case r == 98:
+		end = i
+	}
+	goto reverse
+f11:
+	switch {
+	case i == 0:
+		goto f14
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f4
+	case r == 98:
+		end = i
+		goto f15
+	}
+	goto reverse
+f12:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		goto f14
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f7
+	case r == 98:
+		goto f13
+	}
+	goto reverse
+f13:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		goto f16
+	case i == len(s):
+		end = i
+		goto reverse
+	case i == 0:
+		goto f17
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f4
+	}
+	goto reverse
+f14:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f7
+	case r == 98:
+		end = i
+		goto f15
+	}
+	goto reverse
+f15:
+	switch {
+	case i == len(s):
+		end = i
+	}
+	goto reverse
+f16:
+	switch {
+	case i == len(s):
+		end = i
+		goto reverse
+	case i == 0:
+		goto f18
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r == 98:
+		end = i
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f4
+	}
+	goto reverse
+f17:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		goto f18
+	case i == len(s):
+		end = i
+		goto reverse
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f7
+	}
+	goto reverse
+f18:
+	switch {
+	case i == len(s):
+		end = i
+		goto reverse
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r == 98:
+		end = i
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f7
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	switch {
+	case i == len(s):
+		goto r2
+	}
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 98:
+		goto r3
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 98:
+		goto r4
+	}
+	return
+r3:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		start = i
+		goto r8
+	}
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		goto r9
+	}
+	return
+r4:
+	switch {
+	case (i > 0 && matchSearchTextba299585IsWordChar(s[i-1])) == (i < len(s) && matchSearchTextba299585IsWordChar(s[i])):
+		start = i
+		goto r5
+	}
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		start = i
+		goto r6
+	}
+	return
+r5:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		start = i
+		goto r6
+	}
+	return
+r6:
+	switch {
+	case i == 0:
+		start = i
+	}
+	return
+r8:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		goto r10
+	}
+	return
+r9:
+	switch {
+	case i == 0:
+		start = i
+	}
+	return
+r10:
+	switch {
+	case i == 0:
+		start = i
+	}
+	return
+}
+
+func matchSearchTextba299585IsWordChar(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_'
+}
@@ -0,0 +1,134 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchSearchTextAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("^ab|ab$|\\Bb")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"ab",
+		"b",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"4b",
+		"A",
+		"Y",
+		"a",
+		"a$",
+		"a3",
+		"abB",
+		"b3",
+		"bX",
+		"b\\",
+		"m",
+		"q",
+		"~",
+		"~b",
+		"é",
+		"日本",
+		"\xff",
+		"xabx",
+		"ab ab",
+		"xbx",
+		"b b",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchText(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchText(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchText(f *testing.F) {
+	re := regexp.MustCompile("^ab|ab$|\\Bb")
+
+	for _, s := range []string{
+		"ab",
+		"b",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchText(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchText(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchSearchTextBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("^ab|ab$|\\Bb")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"ab",
+		"b",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"4b",
+		"A",
+		"Y",
+		"a",
+		"a$",
+		"a3",
+		"abB",
+		"b3",
+		"bX",
+		"b\\",
+		"m",
+		"q",
+		"~",
+		"~b",
+		"é",
+		"日本",
+		"\xff",
+		"xabx",
+		"ab ab",
+		"xbx",
+		"b b",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchTextBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchTextBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchTextBytes(f *testing.F) {
+	re := regexp.MustCompile("^ab|ab$|\\Bb")
+
+	for _, s := range []string{
+		"ab",
+		"b",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchTextBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchTextBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
@@ -0,0 +1,491 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+func matchSearchURL(s string) (start, end int) {
+	if !strings.Contains(s, "://") {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 123:
+		goto f1
+	case r >= 97 && r <= 122:
+		goto f2
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 57 || r >= 59 && r <= 96 || r >= 123:
+		goto f1
+	case r == 58:
+		goto f3
+	case r >= 97 && r <= 122:
+		goto f2
+	}
+	goto reverse
+f3:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 46 || r >= 48 && r <= 96 || r >= 123:
+		goto f1
+	case r == 47:
+		goto f4
+	case r >= 97 && r <= 122:
+		goto f2
+	}
+	goto reverse
+f4:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 46 || r >= 48 && r <= 96 || r >= 123:
+		goto f1
+	case r == 47:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f2
+	}
+	goto reverse
+f5:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 96 || r >= 97 && r <= 122 || r >= 123:
+		end = i
+		goto f6
+	case r == 32:
+		goto f1
+	}
+	goto reverse
+f6:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 31 || r >= 33:
+		end = i
+		goto f6
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33:
+		goto r2
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48:
+		goto r2
+	case r == 47:
+		goto r3
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48:
+		goto r2
+	case r == 47:
+		goto r4
+	}
+	return
+r4:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48 && r <= 57 || r >= 59:
+		goto r2
+	case r == 47:
+		goto r4
+	case r == 58:
+		goto r5
+	}
+	return
+r5:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48 && r <= 96 || r >= 123:
+		goto r2
+	case r == 47:
+		goto r3
+	case r >= 97 && r <= 122:
+		start = i
+		goto r6
+	}
+	return
+r6:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48 && r <= 96 || r >= 123:
+		goto r7
+	case r == 47:
+		goto r8
+	case r >= 97 && r <= 122:
+		start = i
+		goto r6
+	}
+	return
+r7:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48:
+		goto r7
+	case r == 47:
+		goto r8
+	}
+	return
+r8:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48:
+		goto r7
+	case r == 47:
+		goto r9
+	}
+	return
+r9:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48 && r <= 57 || r >= 59:
+		goto r7
+	case r == 47:
+		goto r9
+	case r == 58:
+		goto r10
+	}
+	return
+r10:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48 && r <= 96 || r >= 123:
+		goto r7
+	case r == 47:
+		goto r8
+	case r >= 97 && r <= 122:
+		start = i
+		goto r6
+	}
+	return
+}
+
+func matchSearchURLBytes(s []byte) (start, end int) {
+	if !bytes.Contains(s, []byte("://")) {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 123:
+		goto f1
+	case r >= 97 && r <= 122:
+		goto f2
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 57 || r >= 59 && r <= 96 || r >= 123:
+		goto f1
+	case r == 58:
+		goto f3
+	case r >= 97 && r <= 122:
+		goto f2
+	}
+	goto reverse
+f3:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 46 || r >= 48 && r <= 96 || r >= 123:
+		goto f1
+	case r == 47:
+		goto f4
+	case r >= 97 && r <= 122:
+		goto f2
+	}
+	goto reverse
+f4:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 46 || r >= 48 && r <= 96 || r >= 123:
+		goto f1
+	case r == 47:
+		goto f5
+	case r >= 97 && r <= 122:
+		goto f2
+	}
+	goto reverse
+f5:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 96 || r >= 97 && r <= 122 || r >= 123:
+		end = i
+		goto f6
+	case r == 32:
+		goto f1
+	}
+	goto reverse
+f6:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 31 || r >= 33:
+		end = i
+		goto f6
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33:
+		goto r2
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48:
+		goto r2
+	case r == 47:
+		goto r3
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48:
+		goto r2
+	case r == 47:
+		goto r4
+	}
+	return
+r4:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48 && r <= 57 || r >= 59:
+		goto r2
+	case r == 47:
+		goto r4
+	case r == 58:
+		goto r5
+	}
+	return
+r5:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48 && r <= 96 || r >= 123:
+		goto r2
+	case r == 47:
+		goto r3
+	case r >= 97 && r <= 122:
+		start = i
+		goto r6
+	}
+	return
+r6:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48 && r <= 96 || r >= 123:
+		goto r7
+	case r == 47:
+		goto r8
+	case r >= 97 && r <= 122:
+		start = i
+		goto r6
+	}
+	return
+r7:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48:
+		goto r7
+	case r == 47:
+		goto r8
+	}
+	return
+r8:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48:
+		goto r7
+	case r == 47:
+		goto r9
+	}
+	return
+r9:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48 && r <= 57 || r >= 59:
+		goto r7
+	case r == 47:
+		goto r9
+	case r == 58:
+		goto r10
+	}
+	return
+r10:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r <= 31 || r >= 33 && r <= 46 || r >= 48 && r <= 96 || r >= 123:
+		goto r7
+	case r == 47:
+		goto r8
+	case r >= 97 && r <= 122:
+		start = i
+		goto r6
+	}
+	return
+}
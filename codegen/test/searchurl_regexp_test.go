@@ -0,0 +1,278 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchSearchURLAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("[a-z]+://[^ ]+")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a://|NO",
+		"b://0HW",
+		"cex://-",
+		"i://\t<\U0006f5ba",
+		"j://:",
+		"md://.E+qQ",
+		"miu://4",
+		"mt://z9",
+		"ni://2",
+		"nj://_",
+		"oon://m\x00\U000eb33cyd@",
+		"ow://x",
+		"pemqa://^&",
+		"pm://tX",
+		"q://m",
+		"qdg://\U000d18a0",
+		"slxp://\U0009e544",
+		"tjrrzpg://=OX",
+		"vjyuf://\n",
+		"x://c",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"://0HW",
+		"cex:/-",
+		"i://6<\U0006f5ba",
+		"miu://4h",
+		"mt://z9L",
+		"o!://x",
+		"oon://m\x00\U000eb33cy",
+		"oon://m\U000eb33cyd@",
+		"qdg://\U000d18a0F",
+		"slxpV//\U0009e544",
+		"tjrrzpg:'/=OX",
+		"tjrrzpg://=",
+		"tjrrzpg://=X",
+		"vyuf://\n",
+		"é",
+		"日本",
+		"\xff",
+		"xa://|NOx",
+		"a://|NO a://|NO",
+		"xb://0HWx",
+		"b://0HW b://0HW",
+		"xcex://-x",
+		"cex://- cex://-",
+		"xi://\t<\U0006f5bax",
+		"i://\t<\U0006f5ba i://\t<\U0006f5ba",
+		"xj://:x",
+		"j://: j://:",
+		"xmd://.E+qQx",
+		"md://.E+qQ md://.E+qQ",
+		"xmiu://4x",
+		"miu://4 miu://4",
+		"xmt://z9x",
+		"mt://z9 mt://z9",
+		"xni://2x",
+		"ni://2 ni://2",
+		"xnj://_x",
+		"nj://_ nj://_",
+		"xoon://m\x00\U000eb33cyd@x",
+		"oon://m\x00\U000eb33cyd@ oon://m\x00\U000eb33cyd@",
+		"xow://xx",
+		"ow://x ow://x",
+		"xpemqa://^&x",
+		"pemqa://^& pemqa://^&",
+		"xpm://tXx",
+		"pm://tX pm://tX",
+		"xq://mx",
+		"q://m q://m",
+		"xqdg://\U000d18a0x",
+		"qdg://\U000d18a0 qdg://\U000d18a0",
+		"xslxp://\U0009e544x",
+		"slxp://\U0009e544 slxp://\U0009e544",
+		"xtjrrzpg://=OXx",
+		"tjrrzpg://=OX tjrrzpg://=OX",
+		"xvjyuf://\nx",
+		"vjyuf://\n vjyuf://\n",
+		"xx://cx",
+		"x://c x://c",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchURL(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchURL(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchURL(f *testing.F) {
+	re := regexp.MustCompile("[a-z]+://[^ ]+")
+
+	for _, s := range []string{
+		"a://|NO",
+		"b://0HW",
+		"cex://-",
+		"i://\t<\U0006f5ba",
+		"j://:",
+		"md://.E+qQ",
+		"miu://4",
+		"mt://z9",
+		"ni://2",
+		"nj://_",
+		"oon://m\x00\U000eb33cyd@",
+		"ow://x",
+		"pemqa://^&",
+		"pm://tX",
+		"q://m",
+		"qdg://\U000d18a0",
+		"slxp://\U0009e544",
+		"tjrrzpg://=OX",
+		"vjyuf://\n",
+		"x://c",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchURL(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchURL(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchSearchURLBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("[a-z]+://[^ ]+")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a://|NO",
+		"b://0HW",
+		"cex://-",
+		"i://\t<\U0006f5ba",
+		"j://:",
+		"md://.E+qQ",
+		"miu://4",
+		"mt://z9",
+		"ni://2",
+		"nj://_",
+		"oon://m\x00\U000eb33cyd@",
+		"ow://x",
+		"pemqa://^&",
+		"pm://tX",
+		"q://m",
+		"qdg://\U000d18a0",
+		"slxp://\U0009e544",
+		"tjrrzpg://=OX",
+		"vjyuf://\n",
+		"x://c",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"://0HW",
+		"cex:/-",
+		"i://6<\U0006f5ba",
+		"miu://4h",
+		"mt://z9L",
+		"o!://x",
+		"oon://m\x00\U000eb33cy",
+		"oon://m\U000eb33cyd@",
+		"qdg://\U000d18a0F",
+		"slxpV//\U0009e544",
+		"tjrrzpg:'/=OX",
+		"tjrrzpg://=",
+		"tjrrzpg://=X",
+		"vyuf://\n",
+		"é",
+		"日本",
+		"\xff",
+		"xa://|NOx",
+		"a://|NO a://|NO",
+		"xb://0HWx",
+		"b://0HW b://0HW",
+		"xcex://-x",
+		"cex://- cex://-",
+		"xi://\t<\U0006f5bax",
+		"i://\t<\U0006f5ba i://\t<\U0006f5ba",
+		"xj://:x",
+		"j://: j://:",
+		"xmd://.E+qQx",
+		"md://.E+qQ md://.E+qQ",
+		"xmiu://4x",
+		"miu://4 miu://4",
+		"xmt://z9x",
+		"mt://z9 mt://z9",
+		"xni://2x",
+		"ni://2 ni://2",
+		"xnj://_x",
+		"nj://_ nj://_",
+		"xoon://m\x00\U000eb33cyd@x",
+		"oon://m\x00\U000eb33cyd@ oon://m\x00\U000eb33cyd@",
+		"xow://xx",
+		"ow://x ow://x",
+		"xpemqa://^&x",
+		"pemqa://^& pemqa://^&",
+		"xpm://tXx",
+		"pm://tX pm://tX",
+		"xq://mx",
+		"q://m q://m",
+		"xqdg://\U000d18a0x",
+		"qdg://\U000d18a0 qdg://\U000d18a0",
+		"xslxp://\U0009e544x",
+		"slxp://\U0009e544 slxp://\U0009e544",
+		"xtjrrzpg://=OXx",
+		"tjrrzpg://=OX tjrrzpg://=OX",
+		"xvjyuf://\nx",
+		"vjyuf://\n vjyuf://\n",
+		"xx://cx",
+		"x://c x://c",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchURLBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchURLBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchURLBytes(f *testing.F) {
+	re := regexp.MustCompile("[a-z]+://[^ ]+")
+
+	for _, s := range []string{
+		"a://|NO",
+		"b://0HW",
+		"cex://-",
+		"i://\t<\U0006f5ba",
+		"j://:",
+		"md://.E+qQ",
+		"miu://4",
+		"mt://z9",
+		"ni://2",
+		"nj://_",
+		"oon://m\x00\U000eb33cyd@",
+		"ow://x",
+		"pemqa://^&",
+		"pm://tX",
+		"q://m",
+		"qdg://\U000d18a0",
+		"slxp://\U0009e544",
+		"tjrrzpg://=OX",
+		"vjyuf://\n",
+		"x://c",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchURLBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchURLBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
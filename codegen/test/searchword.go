@@ -0,0 +1,327 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+func matchSearchWord(s string) (start, end int) {
+	if !strings.Contains(s, "ab") {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	switch {
+	case (i > 0 && matchSearchWord7d1e89c2IsWordChar(s[i-1])) != (i < len(s) && matchSearchWord7d1e89c2IsWordChar(s[i])):
+		goto f2
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 1114111:
+		goto f1
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f3
+	}
+	goto reverse
+f3:
+	switch {
+	case (i > 0 && matchSearchWord7d1e89c2IsWordChar(s[i-1])) != (i < len(s) && matchSearchWord7d1e89c2IsWordChar(s[i])):
+		goto f4
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99:
+		goto f1
+	case r == 98:
+		goto f5
+	}
+	goto reverse
+f4:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f3
+	case r == 98:
+		goto f5
+	}
+	goto reverse
+f5:
+	switch {
+	case (i > 0 && matchSearchWord7d1e89c2IsWordChar(s[i-1])) != (i < len(s) && matchSearchWord7d1e89c2IsWordChar(s[i])):
+		end = i
+		goto f6
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99:
+		goto f1
+	case r == 98:
+		goto f5
+	}
+	goto reverse
+f6:
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r == 98:
+		goto f7
+	}
+	goto reverse
+f7:
+	switch {
+	case (i > 0 && matchSearchWord7d1e89c2IsWordChar(s[i-1])) != (i < len(s) && matchSearchWord7d1e89c2IsWordChar(s[i])):
+		end = i
+		goto f6
+	}
+	r, rlen = utf8.DecodeRuneInString(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r == 98:
+		goto f7
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	switch {
+	case (i > 0 && matchSearchWord7d1e89c2IsWordChar(s[i-1])) != (i < len(s) && matchSearchWord7d1e89c2IsWordChar(s[i])):
+		goto r2
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 98:
+		goto r3
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRuneInString(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		goto r4
+	case r == 98:
+		goto r3
+	}
+	return
+r4:
+	switch {
+	case (i > 0 && matchSearchWord7d1e89c2IsWordChar(s[i-1])) != (i < len(s) && matchSearchWord7d1e89c2IsWordChar(s[i])):
+		start = i
+	}
+	return
+}
+
+func matchSearchWordBytes(s []byte) (start, end int) {
+	if !bytes.Contains(s, []byte("ab")) {
+		return -1, -1
+	}
+	var r rune
+	var rlen int
+	var i int
+	_, _, _ = r, rlen, i
+	end = -1
+f1:
+	switch {
+	case (i > 0 && matchSearchWord7d1e89c2IsWordChar(s[i-1])) != (i < len(s) && matchSearchWord7d1e89c2IsWordChar(s[i])):
+		goto f2
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 1114111:
+		goto f1
+	}
+	goto reverse
+f2:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 98:
+		goto f1
+	case r == 97:
+		goto f3
+	}
+	goto reverse
+f3:
+	switch {
+	case (i > 0 && matchSearchWord7d1e89c2IsWordChar(s[i-1])) != (i < len(s) && matchSearchWord7d1e89c2IsWordChar(s[i])):
+		goto f4
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99:
+		goto f1
+	case r == 98:
+		goto f5
+	}
+	goto reverse
+f4:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 96 || r >= 99:
+		goto f1
+	case r == 97:
+		goto f3
+	case r == 98:
+		goto f5
+	}
+	goto reverse
+f5:
+	switch {
+	case (i > 0 && matchSearchWord7d1e89c2IsWordChar(s[i-1])) != (i < len(s) && matchSearchWord7d1e89c2IsWordChar(s[i])):
+		end = i
+		goto f6
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r <= 97 || r >= 99:
+		goto f1
+	case r == 98:
+		goto f5
+	}
+	goto reverse
+f6:
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r == 98:
+		goto f7
+	}
+	goto reverse
+f7:
+	switch {
+	case (i > 0 && matchSearchWord7d1e89c2IsWordChar(s[i-1])) != (i < len(s) && matchSearchWord7d1e89c2IsWordChar(s[i])):
+		end = i
+		goto f6
+	}
+	r, rlen = utf8.DecodeRune(s[i:])
+	if rlen == 0 {
+		goto reverse
+	}
+	i += rlen
+	switch {
+	case r == 98:
+		goto f7
+	}
+	goto reverse
+reverse:
+	if end < 0 {
+		return -1, -1
+	}
+	start = -1
+	i = end
+	switch {
+	case (i > 0 && matchSearchWord7d1e89c2IsWordChar(s[i-1])) != (i < len(s) && matchSearchWord7d1e89c2IsWordChar(s[i])):
+		goto r2
+	}
+	return
+r2:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 98:
+		goto r3
+	}
+	return
+r3:
+	r, rlen = utf8.DecodeLastRune(s[:i])
+	if rlen == 0 {
+		return
+	}
+	i -= rlen
+	switch {
+	case r == 97:
+		goto r4
+	case r == 98:
+		goto r3
+	}
+	return
+r4:
+	switch {
+	case (i > 0 && matchSearchWord7d1e89c2IsWordChar(s[i-1])) != (i < len(s) && matchSearchWord7d1e89c2IsWordChar(s[i])):
+		start = i
+	}
+	return
+}
+
+func matchSearchWord7d1e89c2IsWordChar(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_'
+}
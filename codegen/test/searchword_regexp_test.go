@@ -0,0 +1,254 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchSearchWordAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\bab+\\b")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"ab",
+		"abb",
+		"abbb",
+		"abbbb",
+		"abbbbb",
+		"abbbbbb",
+		"abbbbbbb",
+		"abbbbbbbb",
+		"abbbbbbbbb",
+		"abbbbbbbbbb",
+		"abbbbbbbbbbb",
+		"abbbbbbbbbbbb",
+		"abbbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbbbbbb",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"ab/bbbbbbbb",
+		"abbQ",
+		"abbb7bbb",
+		"abbb:bbb",
+		"abbbRbbbbbbbbbbbbb",
+		"abbbbObbbbbbbbbbbb",
+		"abbbbbb)b",
+		"abbbbbbb5",
+		"abbbbbbb@",
+		"abbbbbbbb+",
+		"abbbbbbbbbbb\\",
+		"abbbbbbbbbbbbbbbbbbbbbi",
+		"abbbbbbfbbbbb",
+		"bbbbbbbbbbbbb",
+		"é",
+		"日本",
+		"\xff",
+		"xabx",
+		"ab ab",
+		"xabbx",
+		"abb abb",
+		"xabbbx",
+		"abbb abbb",
+		"xabbbbx",
+		"abbbb abbbb",
+		"xabbbbbx",
+		"abbbbb abbbbb",
+		"xabbbbbbx",
+		"abbbbbb abbbbbb",
+		"xabbbbbbbx",
+		"abbbbbbb abbbbbbb",
+		"xabbbbbbbbx",
+		"abbbbbbbb abbbbbbbb",
+		"xabbbbbbbbbx",
+		"abbbbbbbbb abbbbbbbbb",
+		"xabbbbbbbbbbx",
+		"abbbbbbbbbb abbbbbbbbbb",
+		"xabbbbbbbbbbbx",
+		"abbbbbbbbbbb abbbbbbbbbbb",
+		"xabbbbbbbbbbbbx",
+		"abbbbbbbbbbbb abbbbbbbbbbbb",
+		"xabbbbbbbbbbbbbx",
+		"abbbbbbbbbbbbb abbbbbbbbbbbbb",
+		"xabbbbbbbbbbbbbbbbbx",
+		"abbbbbbbbbbbbbbbbb abbbbbbbbbbbbbbbbb",
+		"xabbbbbbbbbbbbbbbbbbx",
+		"abbbbbbbbbbbbbbbbbb abbbbbbbbbbbbbbbbbb",
+		"xabbbbbbbbbbbbbbbbbbbx",
+		"abbbbbbbbbbbbbbbbbbb abbbbbbbbbbbbbbbbbbb",
+		"xabbbbbbbbbbbbbbbbbbbbbx",
+		"abbbbbbbbbbbbbbbbbbbbb abbbbbbbbbbbbbbbbbbbbb",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchWord(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchWord(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchWord(f *testing.F) {
+	re := regexp.MustCompile("\\bab+\\b")
+
+	for _, s := range []string{
+		"ab",
+		"abb",
+		"abbb",
+		"abbbb",
+		"abbbbb",
+		"abbbbbb",
+		"abbbbbbb",
+		"abbbbbbbb",
+		"abbbbbbbbb",
+		"abbbbbbbbbb",
+		"abbbbbbbbbbb",
+		"abbbbbbbbbbbb",
+		"abbbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbbbbbb",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchWord(s); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchWord(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
+
+func TestMatchSearchWordBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\bab+\\b")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"ab",
+		"abb",
+		"abbb",
+		"abbbb",
+		"abbbbb",
+		"abbbbbb",
+		"abbbbbbb",
+		"abbbbbbbb",
+		"abbbbbbbbb",
+		"abbbbbbbbbb",
+		"abbbbbbbbbbb",
+		"abbbbbbbbbbbb",
+		"abbbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbbbbbb",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"ab/bbbbbbbb",
+		"abbQ",
+		"abbb7bbb",
+		"abbb:bbb",
+		"abbbRbbbbbbbbbbbbb",
+		"abbbbObbbbbbbbbbbb",
+		"abbbbbb)b",
+		"abbbbbbb5",
+		"abbbbbbb@",
+		"abbbbbbbb+",
+		"abbbbbbbbbbb\\",
+		"abbbbbbbbbbbbbbbbbbbbbi",
+		"abbbbbbfbbbbb",
+		"bbbbbbbbbbbbb",
+		"é",
+		"日本",
+		"\xff",
+		"xabx",
+		"ab ab",
+		"xabbx",
+		"abb abb",
+		"xabbbx",
+		"abbb abbb",
+		"xabbbbx",
+		"abbbb abbbb",
+		"xabbbbbx",
+		"abbbbb abbbbb",
+		"xabbbbbbx",
+		"abbbbbb abbbbbb",
+		"xabbbbbbbx",
+		"abbbbbbb abbbbbbb",
+		"xabbbbbbbbx",
+		"abbbbbbbb abbbbbbbb",
+		"xabbbbbbbbbx",
+		"abbbbbbbbb abbbbbbbbb",
+		"xabbbbbbbbbbx",
+		"abbbbbbbbbb abbbbbbbbbb",
+		"xabbbbbbbbbbbx",
+		"abbbbbbbbbbb abbbbbbbbbbb",
+		"xabbbbbbbbbbbbx",
+		"abbbbbbbbbbbb abbbbbbbbbbbb",
+		"xabbbbbbbbbbbbbx",
+		"abbbbbbbbbbbbb abbbbbbbbbbbbb",
+		"xabbbbbbbbbbbbbbbbbx",
+		"abbbbbbbbbbbbbbbbb abbbbbbbbbbbbbbbbb",
+		"xabbbbbbbbbbbbbbbbbbx",
+		"abbbbbbbbbbbbbbbbbb abbbbbbbbbbbbbbbbbb",
+		"xabbbbbbbbbbbbbbbbbbbx",
+		"abbbbbbbbbbbbbbbbbbb abbbbbbbbbbbbbbbbbbb",
+		"xabbbbbbbbbbbbbbbbbbbbbx",
+		"abbbbbbbbbbbbbbbbbbbbb abbbbbbbbbbbbbbbbbbbbb",
+	} {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchWordBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchWordBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	}
+}
+
+func FuzzMatchSearchWordBytes(f *testing.F) {
+	re := regexp.MustCompile("\\bab+\\b")
+
+	for _, s := range []string{
+		"ab",
+		"abb",
+		"abbb",
+		"abbbb",
+		"abbbbb",
+		"abbbbbb",
+		"abbbbbbb",
+		"abbbbbbbb",
+		"abbbbbbbbb",
+		"abbbbbbbbbb",
+		"abbbbbbbbbbb",
+		"abbbbbbbbbbbb",
+		"abbbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbbbb",
+		"abbbbbbbbbbbbbbbbbbbbb",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc
+		}
+		if start, end := matchSearchWordBytes([]byte(s)); start != want[0] || end != want[1] {
+			t.Errorf("matchSearchWordBytes(%q) = %d, %d, want %d, %d", s, start, end, want[0], want[1])
+		}
+	})
+}
@@ -0,0 +1,133 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import "unicode/utf8"
+
+func matchSplitEmpty(s string, n int) []string {
+	if n == 0 {
+		return nil
+	}
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = i
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = end
+		i = end
+		return
+	}
+	parts := []string{}
+	beg, last := 0, 0
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if n > 0 && len(parts) == n-1 {
+				break
+			}
+			last = start
+			if end != 0 {
+				parts = append(parts, s[beg:start])
+			}
+			beg = end
+			k++
+		}
+	}
+	if last != len(s) {
+		parts = append(parts, s[beg:])
+	}
+	return parts
+}
+
+func matchSplitEmptyBytes(s []byte, n int) [][]byte {
+	if n == 0 {
+		return nil
+	}
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = i
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = end
+		i = end
+		return
+	}
+	parts := [][]byte{}
+	beg, last := 0, 0
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRune(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if n > 0 && len(parts) == n-1 {
+				break
+			}
+			last = start
+			if end != 0 {
+				parts = append(parts, s[beg:start])
+			}
+			beg = end
+			k++
+		}
+	}
+	if last != len(s) {
+		parts = append(parts, s[beg:])
+	}
+	return parts
+}
@@ -0,0 +1,123 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatchSplitEndOfLineAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("(?m)$")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"$",
+		",",
+		"0",
+		"5",
+		"@",
+		"C",
+		"H",
+		"M",
+		"U",
+		"V",
+		"c",
+		"k",
+		"q",
+		"w",
+		"{",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+	} {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitEndOfLine(s, n)); got != want {
+				t.Errorf("matchSplitEndOfLine(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchSplitEndOfLine(f *testing.F) {
+	re := regexp.MustCompile("(?m)$")
+
+	for _, s := range []string{
+		"",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitEndOfLine(s, n)); got != want {
+				t.Errorf("matchSplitEndOfLine(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchSplitEndOfLineBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("(?m)$")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"$",
+		",",
+		"0",
+		"5",
+		"@",
+		"C",
+		"H",
+		"M",
+		"U",
+		"V",
+		"c",
+		"k",
+		"q",
+		"w",
+		"{",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+	} {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitEndOfLineBytes([]byte(s), n)); got != want {
+				t.Errorf("matchSplitEndOfLineBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchSplitEndOfLineBytes(f *testing.F) {
+	re := regexp.MustCompile("(?m)$")
+
+	for _, s := range []string{
+		"",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitEndOfLineBytes([]byte(s), n)); got != want {
+				t.Errorf("matchSplitEndOfLineBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
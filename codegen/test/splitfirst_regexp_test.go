@@ -0,0 +1,123 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatchSplitFirstAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a|ab")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"2",
+		"I",
+		"K",
+		"R",
+		"]",
+		"a)",
+		"a2",
+		"aK",
+		"aT",
+		"aj",
+		"at",
+		"k",
+		"o",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+		"xax",
+		"a a",
+	} {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitFirst(s, n)); got != want {
+				t.Errorf("matchSplitFirst(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchSplitFirst(f *testing.F) {
+	re := regexp.MustCompile("a|ab")
+
+	for _, s := range []string{
+		"a",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitFirst(s, n)); got != want {
+				t.Errorf("matchSplitFirst(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchSplitFirstBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a|ab")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"2",
+		"I",
+		"K",
+		"R",
+		"]",
+		"a)",
+		"a2",
+		"aK",
+		"aT",
+		"aj",
+		"at",
+		"k",
+		"o",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+		"xax",
+		"a a",
+	} {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitFirstBytes([]byte(s), n)); got != want {
+				t.Errorf("matchSplitFirstBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchSplitFirstBytes(f *testing.F) {
+	re := regexp.MustCompile("a|ab")
+
+	for _, s := range []string{
+		"a",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitFirstBytes([]byte(s), n)); got != want {
+				t.Errorf("matchSplitFirstBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
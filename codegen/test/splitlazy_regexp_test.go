@@ -0,0 +1,179 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatchSplitLazyAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("x*?y")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"xxxxxxxxxxy",
+		"xxxxxxy",
+		"xxxxxy",
+		"xxxxy",
+		"xxxy",
+		"xxy",
+		"xy",
+		"y",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"!xxxxy",
+		"%y",
+		"x$xxxy",
+		"xxpxy",
+		"xxxx",
+		"xxxxxxhxxxy",
+		"xxxxxxxxxxy;",
+		"xxxxxxxxxy",
+		"xxxxxxy3",
+		"xxxxxxyT",
+		"xxxxxy!",
+		"xxxyU",
+		"yK",
+		"zxy",
+		"é",
+		"日本",
+		"\xff",
+		"xxxxxxxxxxxyx",
+		"xxxxxxxxxxy xxxxxxxxxxy",
+		"xxxxxxxyx",
+		"xxxxxxy xxxxxxy",
+		"xxxxxxyx",
+		"xxxxxy xxxxxy",
+		"xxxxxyx",
+		"xxxxy xxxxy",
+		"xxxxyx",
+		"xxxy xxxy",
+		"xxxyx",
+		"xxy xxy",
+		"xxyx",
+		"xy xy",
+		"xyx",
+		"y y",
+	} {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitLazy(s, n)); got != want {
+				t.Errorf("matchSplitLazy(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchSplitLazy(f *testing.F) {
+	re := regexp.MustCompile("x*?y")
+
+	for _, s := range []string{
+		"xxxxxxxxxxy",
+		"xxxxxxy",
+		"xxxxxy",
+		"xxxxy",
+		"xxxy",
+		"xxy",
+		"xy",
+		"y",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitLazy(s, n)); got != want {
+				t.Errorf("matchSplitLazy(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchSplitLazyBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("x*?y")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"xxxxxxxxxxy",
+		"xxxxxxy",
+		"xxxxxy",
+		"xxxxy",
+		"xxxy",
+		"xxy",
+		"xy",
+		"y",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"!xxxxy",
+		"%y",
+		"x$xxxy",
+		"xxpxy",
+		"xxxx",
+		"xxxxxxhxxxy",
+		"xxxxxxxxxxy;",
+		"xxxxxxxxxy",
+		"xxxxxxy3",
+		"xxxxxxyT",
+		"xxxxxy!",
+		"xxxyU",
+		"yK",
+		"zxy",
+		"é",
+		"日本",
+		"\xff",
+		"xxxxxxxxxxxyx",
+		"xxxxxxxxxxy xxxxxxxxxxy",
+		"xxxxxxxyx",
+		"xxxxxxy xxxxxxy",
+		"xxxxxxyx",
+		"xxxxxy xxxxxy",
+		"xxxxxyx",
+		"xxxxy xxxxy",
+		"xxxxyx",
+		"xxxy xxxy",
+		"xxxyx",
+		"xxy xxy",
+		"xxyx",
+		"xy xy",
+		"xyx",
+		"y y",
+	} {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitLazyBytes([]byte(s), n)); got != want {
+				t.Errorf("matchSplitLazyBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchSplitLazyBytes(f *testing.F) {
+	re := regexp.MustCompile("x*?y")
+
+	for _, s := range []string{
+		"xxxxxxxxxxy",
+		"xxxxxxy",
+		"xxxxxy",
+		"xxxxy",
+		"xxxy",
+		"xxy",
+		"xy",
+		"y",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitLazyBytes([]byte(s), n)); got != want {
+				t.Errorf("matchSplitLazyBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,275 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatchSplitSeparatorsAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\s*[,;]\\s*")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"\t   , ",
+		"\t, ",
+		"\n;",
+		" \f \t ;     ",
+		"  \n  ,\t",
+		"     ; ",
+		"  ;      ",
+		" ,\n ",
+		" , ",
+		",",
+		",\n\f ",
+		",  ",
+		",  \t",
+		",   ",
+		",    \r ",
+		",     ",
+		";",
+		";\r",
+		"; ",
+		";  ",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\t, 4",
+		"\n",
+		"\nI",
+		"  \t",
+		"  \n  ,\t*",
+		"  \n ,\t",
+		"    ",
+		"  ;      ?",
+		", ",
+		",  \t%",
+		"/  \t",
+		";j",
+		"R",
+		"Z, ",
+		"z ;      ",
+		"é",
+		"日本",
+		"\xff",
+		"x\t   , x",
+		"\t   ,  \t   , ",
+		"x\t, x",
+		"\t,  \t, ",
+		"x\n;x",
+		"\n; \n;",
+		"x \f \t ;     x",
+		" \f \t ;       \f \t ;     ",
+		"x  \n  ,\tx",
+		"  \n  ,\t   \n  ,\t",
+		"x     ; x",
+		"     ;       ; ",
+		"x  ;      x",
+		"  ;         ;      ",
+		"x ,\n x",
+		" ,\n   ,\n ",
+		"x , x",
+		" ,   , ",
+		"x,x",
+		", ,",
+		"x,\n\f x",
+		",\n\f  ,\n\f ",
+		"x,  x",
+		",   ,  ",
+		"x,  \tx",
+		",  \t ,  \t",
+		"x,   x",
+		",    ,   ",
+		"x,    \r x",
+		",    \r  ,    \r ",
+		"x,     x",
+		",      ,     ",
+		"x;x",
+		"; ;",
+		"x;\rx",
+		";\r ;\r",
+		"x; x",
+		";  ; ",
+		"x;  x",
+		";   ;  ",
+	} {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitSeparators(s, n)); got != want {
+				t.Errorf("matchSplitSeparators(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchSplitSeparators(f *testing.F) {
+	re := regexp.MustCompile("\\s*[,;]\\s*")
+
+	for _, s := range []string{
+		"\t   , ",
+		"\t, ",
+		"\n;",
+		" \f \t ;     ",
+		"  \n  ,\t",
+		"     ; ",
+		"  ;      ",
+		" ,\n ",
+		" , ",
+		",",
+		",\n\f ",
+		",  ",
+		",  \t",
+		",   ",
+		",    \r ",
+		",     ",
+		";",
+		";\r",
+		"; ",
+		";  ",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitSeparators(s, n)); got != want {
+				t.Errorf("matchSplitSeparators(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchSplitSeparatorsBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\s*[,;]\\s*")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"\t   , ",
+		"\t, ",
+		"\n;",
+		" \f \t ;     ",
+		"  \n  ,\t",
+		"     ; ",
+		"  ;      ",
+		" ,\n ",
+		" , ",
+		",",
+		",\n\f ",
+		",  ",
+		",  \t",
+		",   ",
+		",    \r ",
+		",     ",
+		";",
+		";\r",
+		"; ",
+		";  ",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\t, 4",
+		"\n",
+		"\nI",
+		"  \t",
+		"  \n  ,\t*",
+		"  \n ,\t",
+		"    ",
+		"  ;      ?",
+		", ",
+		",  \t%",
+		"/  \t",
+		";j",
+		"R",
+		"Z, ",
+		"z ;      ",
+		"é",
+		"日本",
+		"\xff",
+		"x\t   , x",
+		"\t   ,  \t   , ",
+		"x\t, x",
+		"\t,  \t, ",
+		"x\n;x",
+		"\n; \n;",
+		"x \f \t ;     x",
+		" \f \t ;       \f \t ;     ",
+		"x  \n  ,\tx",
+		"  \n  ,\t   \n  ,\t",
+		"x     ; x",
+		"     ;       ; ",
+		"x  ;      x",
+		"  ;         ;      ",
+		"x ,\n x",
+		" ,\n   ,\n ",
+		"x , x",
+		" ,   , ",
+		"x,x",
+		", ,",
+		"x,\n\f x",
+		",\n\f  ,\n\f ",
+		"x,  x",
+		",   ,  ",
+		"x,  \tx",
+		",  \t ,  \t",
+		"x,   x",
+		",    ,   ",
+		"x,    \r x",
+		",    \r  ,    \r ",
+		"x,     x",
+		",      ,     ",
+		"x;x",
+		"; ;",
+		"x;\rx",
+		";\r ;\r",
+		"x; x",
+		";  ; ",
+		"x;  x",
+		";   ;  ",
+	} {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitSeparatorsBytes([]byte(s), n)); got != want {
+				t.Errorf("matchSplitSeparatorsBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchSplitSeparatorsBytes(f *testing.F) {
+	re := regexp.MustCompile("\\s*[,;]\\s*")
+
+	for _, s := range []string{
+		"\t   , ",
+		"\t, ",
+		"\n;",
+		" \f \t ;     ",
+		"  \n  ,\t",
+		"     ; ",
+		"  ;      ",
+		" ,\n ",
+		" , ",
+		",",
+		",\n\f ",
+		",  ",
+		",  \t",
+		",   ",
+		",    \r ",
+		",     ",
+		";",
+		";\r",
+		"; ",
+		";  ",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitSeparatorsBytes([]byte(s), n)); got != want {
+				t.Errorf("matchSplitSeparatorsBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
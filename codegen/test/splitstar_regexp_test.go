@@ -0,0 +1,195 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatchSplitStarAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a*")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaaaaa",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"4",
+		"a=aaaaa",
+		"aaD",
+		"aaa,aaa",
+		"aaaEaa",
+		"aaa_",
+		"aaaaa!",
+		"aaaaaaaa6",
+		"aaaaaaaaaa",
+		"aaaaaaaaaaa",
+		"aaaaaf",
+		"aaaah",
+		"asaaaaa",
+		"b",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xaaaaaax",
+		"aaaaaa aaaaaa",
+		"xaaaaaaax",
+		"aaaaaaa aaaaaaa",
+		"xaaaaaaaax",
+		"aaaaaaaa aaaaaaaa",
+		"xaaaaaaaaaaaax",
+		"aaaaaaaaaaaa aaaaaaaaaaaa",
+	} {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitStar(s, n)); got != want {
+				t.Errorf("matchSplitStar(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchSplitStar(f *testing.F) {
+	re := regexp.MustCompile("a*")
+
+	for _, s := range []string{
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitStar(s, n)); got != want {
+				t.Errorf("matchSplitStar(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchSplitStarBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("a*")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaaaaa",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"4",
+		"a=aaaaa",
+		"aaD",
+		"aaa,aaa",
+		"aaaEaa",
+		"aaa_",
+		"aaaaa!",
+		"aaaaaaaa6",
+		"aaaaaaaaaa",
+		"aaaaaaaaaaa",
+		"aaaaaf",
+		"aaaah",
+		"asaaaaa",
+		"b",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+		"xax",
+		"a a",
+		"xaax",
+		"aa aa",
+		"xaaax",
+		"aaa aaa",
+		"xaaaax",
+		"aaaa aaaa",
+		"xaaaaax",
+		"aaaaa aaaaa",
+		"xaaaaaax",
+		"aaaaaa aaaaaa",
+		"xaaaaaaax",
+		"aaaaaaa aaaaaaa",
+		"xaaaaaaaax",
+		"aaaaaaaa aaaaaaaa",
+		"xaaaaaaaaaaaax",
+		"aaaaaaaaaaaa aaaaaaaaaaaa",
+	} {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitStarBytes([]byte(s), n)); got != want {
+				t.Errorf("matchSplitStarBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchSplitStarBytes(f *testing.F) {
+	re := regexp.MustCompile("a*")
+
+	for _, s := range []string{
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitStarBytes([]byte(s), n)); got != want {
+				t.Errorf("matchSplitStarBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
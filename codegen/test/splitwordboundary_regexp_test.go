@@ -0,0 +1,123 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestMatchSplitWordBoundaryAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\b")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"$",
+		",",
+		"0",
+		"5",
+		"@",
+		"C",
+		"H",
+		"M",
+		"U",
+		"V",
+		"c",
+		"k",
+		"q",
+		"w",
+		"{",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+	} {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitWordBoundary(s, n)); got != want {
+				t.Errorf("matchSplitWordBoundary(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchSplitWordBoundary(f *testing.F) {
+	re := regexp.MustCompile("\\b")
+
+	for _, s := range []string{
+		"",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitWordBoundary(s, n)); got != want {
+				t.Errorf("matchSplitWordBoundary(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
+
+func TestMatchSplitWordBoundaryBytesAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\b")
+
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"$",
+		",",
+		"0",
+		"5",
+		"@",
+		"C",
+		"H",
+		"M",
+		"U",
+		"V",
+		"c",
+		"k",
+		"q",
+		"w",
+		"{",
+		"é",
+		"日本",
+		"\xff",
+		"xx",
+		" ",
+	} {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitWordBoundaryBytes([]byte(s), n)); got != want {
+				t.Errorf("matchSplitWordBoundaryBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzMatchSplitWordBoundaryBytes(f *testing.F) {
+	re := regexp.MustCompile("\\b")
+
+	for _, s := range []string{
+		"",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, n := range []int{-1, 0, 1, 2, 3} {
+			want := fmt.Sprintf("%q", re.Split(s, n))
+			if got := fmt.Sprintf("%q", matchSplitWordBoundaryBytes([]byte(s), n)); got != want {
+				t.Errorf("matchSplitWordBoundaryBytes(%q, %d) = %s, want %s", s, n, got, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,83 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchStarAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:a*)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaaaaa",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"4",
+		"a=aaaaa",
+		"aaD",
+		"aaa,aaa",
+		"aaaEaa",
+		"aaa_",
+		"aaaaa!",
+		"aaaaaaaa6",
+		"aaaaaaaaaa",
+		"aaaaaaaaaaa",
+		"aaaaaf",
+		"aaaah",
+		"asaaaaa",
+		"b",
+		"z",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchStar(s); got != want {
+			t.Errorf("matchStar(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchStar(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:a*)")
+	re.Longest()
+	for _, s := range []string{
+		"",
+		"a",
+		"aa",
+		"aaa",
+		"aaaa",
+		"aaaaa",
+		"aaaaaa",
+		"aaaaaaa",
+		"aaaaaaaa",
+		"aaaaaaaaaaaa",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchStar(s); got != want {
+			t.Errorf("matchStar(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
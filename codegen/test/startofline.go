@@ -11,7 +11,7 @@ func matchStartOfLine(s string) (end int) {
 	i := 0
 	_, _, _ = r, rlen, i
 	switch {
-	case i == 0 || r == '\n':
+	case i == 0 || s[i-1] == '\n':
 		goto s2
 	}
 	return
@@ -0,0 +1,65 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchStartOfLineEmptyAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:(?m)^)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"",
+		// Likely not matching.
+		"\x00",
+		"\n",
+		"$",
+		",",
+		"0",
+		"5",
+		"@",
+		"C",
+		"H",
+		"M",
+		"U",
+		"V",
+		"c",
+		"k",
+		"q",
+		"w",
+		"{",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchStartOfLineEmpty(s); got != want {
+			t.Errorf("matchStartOfLineEmpty(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchStartOfLineEmpty(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:(?m)^)")
+	re.Longest()
+	for _, s := range []string{
+		"",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchStartOfLineEmpty(s); got != want {
+			t.Errorf("matchStartOfLineEmpty(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchStartOfTextAgainstRegexp(t *testing.T) {
+	re := regexp.MustCompile("\\A(?:^a)")
+	re.Longest()
+	for _, s := range []string{
+		// Sampled from the automaton.
+		"a",
+		// Likely not matching.
+		"",
+		"\x00",
+		"\n",
+		"!",
+		"'",
+		"6",
+		"C",
+		"V",
+		"X",
+		"aI",
+		"aK",
+		"aN",
+		"aa",
+		"ah",
+		"c",
+		"f",
+		"h",
+		"é",
+		"日本",
+		"\xff",
+	} {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchStartOfText(s); got != want {
+			t.Errorf("matchStartOfText(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func FuzzMatchStartOfText(f *testing.F) {
+	re := regexp.MustCompile("\\A(?:^a)")
+	re.Longest()
+	for _, s := range []string{
+		"a",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := -1
+		if loc := re.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := matchStartOfText(s); got != want {
+			t.Errorf("matchStartOfText(%q) = %d, want %d", s, got, want)
+		}
+	})
+}
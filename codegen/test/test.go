@@ -1,5 +0,0 @@
-package test
-
-func isWordChar(r byte) bool {
-	return 'A' <= r && r <= 'Z' || 'a' <= r && r <= 'z' || '0' <= r && r <= '9' || r == '_'
-}
@@ -1,6 +1,13 @@
 package test
 
-import "testing"
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/opennota/re2dfa/matcher"
+)
 
 type testCase struct {
 	in   string
@@ -289,6 +296,21 @@ func TestWordBoundary(t *testing.T) {
 	}
 }
 
+func TestNoWordBoundary(t *testing.T) {
+	testCases := []testCase{
+		{"", 0},
+		{" ", 0},
+		{"a", -1},
+		{"é", 0},
+	}
+	for _, tc := range testCases {
+		got := matchNoWordBoundary(tc.in)
+		if got != tc.want {
+			t.Errorf("matchNoWordBoundary(%q) = %d, want %d", tc.in, got, tc.want)
+		}
+	}
+}
+
 func TestLazy1(t *testing.T) {
 	type testCase struct {
 		in   string
@@ -478,3 +500,189 @@ func TestIgnoreCase2(t *testing.T) {
 		}
 	}
 }
+
+func TestMatchFindAllWordsFuncStop(t *testing.T) {
+	var got [][2]int
+	matchFindAllWordsFunc("ab, cd, ef", -1, func(start, end int) bool {
+		got = append(got, [2]int{start, end})
+		return len(got) < 2
+	})
+	if len(got) != 2 || got[0] != [2]int{0, 2} || got[1] != [2]int{4, 6} {
+		t.Errorf("matchFindAllWordsFunc yielded %v, want [[0 2] [4 6]]", got)
+	}
+}
+
+func TestFindAllNullable(t *testing.T) {
+	testCases := []struct {
+		fn   func(string, int) [][2]int
+		name string
+		in   string
+		want [][2]int
+	}{
+		{matchFindAllLazyEmpty, "matchFindAllLazyEmpty", "xa", [][2]int{{0, 0}, {1, 1}, {2, 2}}},
+		{matchCountFindAllEmpty, "matchCountFindAllEmpty", "xaab", [][2]int{{0, 0}, {1, 3}, {3, 4}}},
+	}
+	for _, tc := range testCases {
+		got := tc.fn(tc.in, -1)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s(%q, -1) = %v, want %v", tc.name, tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	testCases := []struct {
+		fn   func(string, int) []string
+		name string
+		in   string
+		n    int
+		want []string
+	}{
+		{matchSplitSeparators, "matchSplitSeparators", "a , b;c", -1, []string{"a", "b", "c"}},
+		{matchSplitSeparators, "matchSplitSeparators", "a , b;c", 2, []string{"a", "b;c"}},
+		{matchSplitSeparators, "matchSplitSeparators", "a , b;c", 0, nil},
+		{matchSplitSeparators, "matchSplitSeparators", ",a,", -1, []string{"", "a", ""}},
+		{matchSplitSeparators, "matchSplitSeparators", "", -1, []string{""}},
+		{matchSplitStar, "matchSplitStar", "abaabaccadaaae", 5, []string{"", "b", "b", "c", "cadaaae"}},
+		{matchSplitStar, "matchSplitStar", "baaac", -1, []string{"b", "c"}},
+		{matchSplitStar, "matchSplitStar", "", -1, []string{""}},
+		{matchSplitEmpty, "matchSplitEmpty", "abc", -1, []string{"a", "b", "c"}},
+		{matchSplitEmpty, "matchSplitEmpty", "abc", 2, []string{"a", "bc"}},
+		{matchSplitEmpty, "matchSplitEmpty", "", -1, []string{}},
+		{matchSplitWordBoundary, "matchSplitWordBoundary", "ab cd", -1, []string{"ab", " ", "cd"}},
+		{matchSplitLazyEmpty, "matchSplitLazyEmpty", "bab", -1, []string{"b", "a", "b"}},
+		{matchSplitLazyEmpty, "matchSplitLazyEmpty", "", -1, []string{""}},
+	}
+	for _, tc := range testCases {
+		got := tc.fn(tc.in, tc.n)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s(%q, %d) = %q, want %q", tc.name, tc.in, tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestLines(t *testing.T) {
+	testCases := []struct {
+		fn   func(string, int) [][2]int
+		name string
+		in   string
+		want [][2]int
+	}{
+		{matchLinesCR, "matchLinesCR", "ab\rc\r", [][2]int{{0, 2}, {3, 4}, {5, 5}}},
+		{matchLinesCR, "matchLinesCR", "a\nb", [][2]int{{0, 3}}},
+		{matchLinesCR, "matchLinesCR", "a\r\nb", [][2]int{{0, 1}, {2, 4}}},
+		{matchLinesCRLF, "matchLinesCRLF", "ab\r\nc\r\n", [][2]int{{0, 2}, {4, 5}, {7, 7}}},
+		{matchLinesCRLF, "matchLinesCRLF", "a\nb\r\nc", [][2]int{{5, 6}}},
+		{matchLinesCRLF, "matchLinesCRLF", "\r\n\r\n", [][2]int{{0, 0}, {2, 2}, {4, 4}}},
+		{matchLinesLFCRLF, "matchLinesLFCRLF", "a\nb\r\nc", [][2]int{{0, 1}, {2, 3}, {5, 6}}},
+		{matchLinesLFCRLF, "matchLinesLFCRLF", "a\r\n\nb", [][2]int{{0, 1}, {3, 3}, {4, 5}}},
+		{matchLinesUnicode, "matchLinesUnicode", "a\u0085b\u2028c\u2029d", [][2]int{{0, 1}, {3, 4}, {7, 8}, {11, 12}}},
+		{matchLinesUnicode, "matchLinesUnicode", "a\nb", [][2]int{{0, 3}}},
+		{matchLinesAll, "matchLinesAll", "a\nb\rc\r\nd\u2028e", [][2]int{{0, 1}, {2, 3}, {4, 5}, {7, 8}, {11, 12}}},
+		{matchLinesAll, "matchLinesAll", "\r\r\n", [][2]int{{0, 0}, {1, 1}, {3, 3}}},
+		{matchLinesLazyCRLF, "matchLinesLazyCRLF", "ab\r\nc\r\n", [][2]int{{0, 2}, {4, 5}, {7, 7}}},
+	}
+	for _, tc := range testCases {
+		got := tc.fn(tc.in, -1)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s(%q, -1) = %v, want %v", tc.name, tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestUnicodeWordBoundaries(t *testing.T) {
+	testCases := []struct {
+		fn   func(string, int) [][2]int
+		name string
+		in   string
+		want [][2]int
+	}{
+		{matchUnicodeWordBoundaries, "matchUnicodeWordBoundaries", "", nil},
+		{matchUnicodeWordBoundaries, "matchUnicodeWordBoundaries", "ab", [][2]int{{0, 0}, {2, 2}}},
+		{matchUnicodeWordBoundaries, "matchUnicodeWordBoundaries", "été à", [][2]int{{0, 0}, {5, 5}, {6, 6}, {8, 8}}},
+		{matchUnicodeWordBoundaries, "matchUnicodeWordBoundaries", "日本 語", [][2]int{{0, 0}, {6, 6}, {7, 7}, {10, 10}}},
+		{matchUnicodeWordBoundaries, "matchUnicodeWordBoundaries", "e\u0301‿x", [][2]int{{0, 0}, {7, 7}}},
+		{matchUnicodeWordBoundaries, "matchUnicodeWordBoundaries", "٣!", [][2]int{{0, 0}, {2, 2}}},
+		{matchUnicodeNoWordBoundaries, "matchUnicodeNoWordBoundaries", "aé b", [][2]int{{1, 1}}},
+		{matchUnicodeNoWordBoundaries, "matchUnicodeNoWordBoundaries", "», «", [][2]int{{0, 0}, {2, 2}, {3, 3}, {4, 4}, {6, 6}}},
+		{matchUnicodeWordASCII, "matchUnicodeWordASCII", "naïve cat", [][2]int{{7, 10}}},
+		{matchUnicodeWordASCII, "matchUnicodeWordASCII", "ab_1 x", [][2]int{{0, 4}, {5, 6}}},
+		{matchUnicodeWordLazy, "matchUnicodeWordLazy", "été, à", [][2]int{{0, 5}, {5, 7}, {7, 9}}},
+	}
+	for _, tc := range testCases {
+		got := tc.fn(tc.in, -1)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s(%q, -1) = %v, want %v", tc.name, tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestMatcherLiteralPrefix(t *testing.T) {
+	testCases := []struct {
+		m        matcher.Matcher
+		prefix   string
+		complete bool
+	}{
+		{MatcherEmail{}, "", false},
+		{MatcherLiteral{}, "abc", true},
+		{MatcherWordBoundary{}, "", false},
+		{MatcherLazy{}, "<!--", false},
+		{MatcherEmpty{}, "", true},
+		{regexp.MustCompile("abc"), "abc", true},
+	}
+	for _, tc := range testCases {
+		prefix, complete := tc.m.LiteralPrefix()
+		if prefix != tc.prefix || complete != tc.complete {
+			t.Errorf("%s: LiteralPrefix() = %q, %v, want %q, %v", tc.m, prefix, complete, tc.prefix, tc.complete)
+		}
+	}
+}
+
+func TestCountedAgainstExpanded(t *testing.T) {
+	var inputs []string
+	for _, unit := range []string{"a", "0", "b", "a1", "ab", "é"} {
+		for n := 0; n <= 24; n++ {
+			s := strings.Repeat(unit, n)
+			for _, suffix := range []string{"", "x", "@x", " ", "b", "aaaaaabbbbbb"} {
+				inputs = append(inputs, s+suffix, suffix+s)
+			}
+		}
+	}
+	matchFuncs := []struct {
+		name              string
+		counted, expanded func(string) int
+	}{
+		{"matchCountRange", matchCountRange, matchCountRangeExpanded},
+		{"matchCountFixed", matchCountFixed, matchCountFixedExpanded},
+		{"matchCountWordBoundary", matchCountWordBoundary, matchCountWordBoundaryExpanded},
+		{"matchCountLoop", matchCountLoop, matchCountLoopExpanded},
+		{"matchCountSequence", matchCountSequence, matchCountSequenceExpanded},
+	}
+	for _, in := range inputs {
+		for _, f := range matchFuncs {
+			if got, want := f.counted(in), f.expanded(in); got != want {
+				t.Errorf("%s(%q) = %d, want %d", f.name, in, got, want)
+			}
+		}
+		if got, want := matchCountBool(in), matchCountBoolExpanded(in); got != want {
+			t.Errorf("matchCountBool(%q) = %v, want %v", in, got, want)
+		}
+		start, end := matchCountSearch(in)
+		wantStart, wantEnd := matchCountSearchExpanded(in)
+		if start != wantStart || end != wantEnd {
+			t.Errorf("matchCountSearch(%q) = %d, %d, want %d, %d", in, start, end, wantStart, wantEnd)
+		}
+		if got, want := matchCountFindAll(in, -1), matchCountFindAllExpanded(in, -1); !reflect.DeepEqual(got, want) {
+			t.Errorf("matchCountFindAll(%q, -1) = %v, want %v", in, got, want)
+		}
+		if got, want := matchCountFindAllEmpty(in, -1), matchCountFindAllEmptyExpanded(in, -1); !reflect.DeepEqual(got, want) {
+			t.Errorf("matchCountFindAllEmpty(%q, -1) = %v, want %v", in, got, want)
+		}
+		if got, want := matchCountReplaceAllEmpty(in), matchCountReplaceAllEmptyExpanded(in); got != want {
+			t.Errorf("matchCountReplaceAllEmpty(%q) = %q, want %q", in, got, want)
+		}
+		if got, want := matchCountSplitEmpty(in, -1), matchCountSplitEmptyExpanded(in, -1); !reflect.DeepEqual(got, want) {
+			t.Errorf("matchCountSplitEmpty(%q, -1) = %q, want %q", in, got, want)
+		}
+	}
+}
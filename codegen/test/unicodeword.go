@@ -0,0 +1,1052 @@
+// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+package test
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+func matchUnicodeWordBoundaries(s string, n int) [][2]int {
+	var matches [][2]int
+	matchUnicodeWordBoundariesFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchUnicodeWordBoundariesFunc(s string, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundaryInString(s, i):
+			end = i
+			goto reverse
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 1114111:
+			goto f1
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundaryInString(s, i):
+			start = i
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchUnicodeWordBoundariesBytes(s []byte, n int) [][2]int {
+	var matches [][2]int
+	matchUnicodeWordBoundariesBytesFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchUnicodeWordBoundariesBytesFunc(s []byte, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundary(s, i):
+			end = i
+			goto reverse
+		}
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 1114111:
+			goto f1
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundary(s, i):
+			start = i
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRune(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchUnicodeNoWordBoundaries(s string, n int) [][2]int {
+	var matches [][2]int
+	matchUnicodeNoWordBoundariesFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchUnicodeNoWordBoundariesFunc(s string, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		switch {
+		case !matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundaryInString(s, i):
+			end = i
+			goto reverse
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 1114111:
+			goto f1
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		switch {
+		case !matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundaryInString(s, i):
+			start = i
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchUnicodeNoWordBoundariesBytes(s []byte, n int) [][2]int {
+	var matches [][2]int
+	matchUnicodeNoWordBoundariesBytesFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchUnicodeNoWordBoundariesBytesFunc(s []byte, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		switch {
+		case !matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundary(s, i):
+			end = i
+			goto reverse
+		}
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 1114111:
+			goto f1
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		switch {
+		case !matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundary(s, i):
+			start = i
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRune(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchUnicodeWordASCII(s string, n int) [][2]int {
+	var matches [][2]int
+	matchUnicodeWordASCIIFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchUnicodeWordASCIIFunc(s string, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundaryInString(s, i):
+			goto f2
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 1114111:
+			goto f1
+		}
+		goto reverse
+	f2:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 47 || r >= 58 && r <= 64 || r >= 91 && r <= 94 || r == 96 || r >= 123:
+			goto f1
+		case r >= 48 && r <= 57 || r >= 65 && r <= 90 || r == 95 || r >= 97 && r <= 122:
+			goto f3
+		}
+		goto reverse
+	f3:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundaryInString(s, i):
+			end = i
+			goto f4
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 47 || r >= 58 && r <= 64 || r >= 91 && r <= 94 || r == 96 || r >= 123:
+			goto f1
+		case r >= 48 && r <= 57 || r >= 65 && r <= 90 || r == 95 || r >= 97 && r <= 122:
+			goto f3
+		}
+		goto reverse
+	f4:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 48 && r <= 57 || r >= 65 && r <= 90 || r == 95 || r >= 97 && r <= 122:
+			goto f5
+		}
+		goto reverse
+	f5:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundaryInString(s, i):
+			end = i
+			goto f4
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 48 && r <= 57 || r >= 65 && r <= 90 || r == 95 || r >= 97 && r <= 122:
+			goto f5
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundaryInString(s, i):
+			goto r2
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57 || r >= 65 && r <= 90 || r == 95 || r >= 97 && r <= 122:
+			goto r3
+		}
+		return
+	r3:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundaryInString(s, i):
+			start = i
+			goto r4
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57 || r >= 65 && r <= 90 || r == 95 || r >= 97 && r <= 122:
+			goto r3
+		}
+		return
+	r4:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57 || r >= 65 && r <= 90 || r == 95 || r >= 97 && r <= 122:
+			goto r5
+		}
+		return
+	r5:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundaryInString(s, i):
+			start = i
+			goto r4
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57 || r >= 65 && r <= 90 || r == 95 || r >= 97 && r <= 122:
+			goto r5
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchUnicodeWordASCIIBytes(s []byte, n int) [][2]int {
+	var matches [][2]int
+	matchUnicodeWordASCIIBytesFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchUnicodeWordASCIIBytesFunc(s []byte, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundary(s, i):
+			goto f2
+		}
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 1114111:
+			goto f1
+		}
+		goto reverse
+	f2:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 47 || r >= 58 && r <= 64 || r >= 91 && r <= 94 || r == 96 || r >= 123:
+			goto f1
+		case r >= 48 && r <= 57 || r >= 65 && r <= 90 || r == 95 || r >= 97 && r <= 122:
+			goto f3
+		}
+		goto reverse
+	f3:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundary(s, i):
+			end = i
+			goto f4
+		}
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 47 || r >= 58 && r <= 64 || r >= 91 && r <= 94 || r == 96 || r >= 123:
+			goto f1
+		case r >= 48 && r <= 57 || r >= 65 && r <= 90 || r == 95 || r >= 97 && r <= 122:
+			goto f3
+		}
+		goto reverse
+	f4:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 48 && r <= 57 || r >= 65 && r <= 90 || r == 95 || r >= 97 && r <= 122:
+			goto f5
+		}
+		goto reverse
+	f5:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundary(s, i):
+			end = i
+			goto f4
+		}
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r >= 48 && r <= 57 || r >= 65 && r <= 90 || r == 95 || r >= 97 && r <= 122:
+			goto f5
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundary(s, i):
+			goto r2
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57 || r >= 65 && r <= 90 || r == 95 || r >= 97 && r <= 122:
+			goto r3
+		}
+		return
+	r3:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundary(s, i):
+			start = i
+			goto r4
+		}
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57 || r >= 65 && r <= 90 || r == 95 || r >= 97 && r <= 122:
+			goto r3
+		}
+		return
+	r4:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57 || r >= 65 && r <= 90 || r == 95 || r >= 97 && r <= 122:
+			goto r5
+		}
+		return
+	r5:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundary(s, i):
+			start = i
+			goto r4
+		}
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r >= 48 && r <= 57 || r >= 65 && r <= 90 || r == 95 || r >= 97 && r <= 122:
+			goto r5
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRune(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchUnicodeWordLazy(s string, n int) [][2]int {
+	var matches [][2]int
+	matchUnicodeWordLazyFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchUnicodeWordLazyFunc(s string, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundaryInString(s, i):
+			goto f2
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 1114111:
+			goto f1
+		}
+		goto reverse
+	f2:
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto f3
+		case r == 10:
+			goto f1
+		}
+		goto reverse
+	f3:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundaryInString(s, i):
+			end = i
+			goto reverse
+		}
+		r, rlen = utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto f3
+		case r == 10:
+			goto f1
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundaryInString(s, i):
+			goto r2
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r3
+		}
+		return
+	r3:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundaryInString(s, i):
+			start = i
+			goto r4
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r3
+		}
+		return
+	r4:
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r5
+		}
+		return
+	r5:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundaryInString(s, i):
+			start = i
+			goto r4
+		}
+		r, rlen = utf8.DecodeLastRuneInString(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r5
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRuneInString(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchUnicodeWordLazyBytes(s []byte, n int) [][2]int {
+	var matches [][2]int
+	matchUnicodeWordLazyBytesFunc(s, n, func(start, end int) bool {
+		matches = append(matches, [2]int{start, end})
+		return true
+	})
+	return matches
+}
+
+func matchUnicodeWordLazyBytesFunc(s []byte, n int, yield func(start, end int) bool) {
+	search := func(at int) (start, end int) {
+		var r rune
+		var rlen int
+		var i int
+		_, _, _ = r, rlen, i
+		i = at
+		end = -1
+	f1:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundary(s, i):
+			goto f2
+		}
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 1114111:
+			goto f1
+		}
+		goto reverse
+	f2:
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto f3
+		case r == 10:
+			goto f1
+		}
+		goto reverse
+	f3:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundary(s, i):
+			end = i
+			goto reverse
+		}
+		r, rlen = utf8.DecodeRune(s[i:])
+		if rlen == 0 {
+			goto reverse
+		}
+		i += rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto f3
+		case r == 10:
+			goto f1
+		}
+		goto reverse
+	reverse:
+		if end < 0 {
+			return -1, -1
+		}
+		start = -1
+		i = end
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundary(s, i):
+			goto r2
+		}
+		return
+	r2:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r3
+		}
+		return
+	r3:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundary(s, i):
+			start = i
+			goto r4
+		}
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r3
+		}
+		return
+	r4:
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r5
+		}
+		return
+	r5:
+		switch {
+		case matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundary(s, i):
+			start = i
+			goto r4
+		}
+		r, rlen = utf8.DecodeLastRune(s[at:i])
+		if rlen == 0 {
+			return
+		}
+		i -= rlen
+		switch {
+		case r <= 9 || r >= 11:
+			goto r5
+		}
+		return
+	}
+	limit := n
+	if limit < 0 {
+		limit = len(s) + 1
+	}
+	for pos, k, prevEnd := 0, 0, -1; k < limit && pos <= len(s); {
+		start, end := search(pos)
+		if start < 0 {
+			break
+		}
+		accept := true
+		if end <= pos {
+			// An empty match; end < pos is not expected, but pos moves forward anyway.
+			if start == prevEnd {
+				accept = false
+			}
+			if _, width := utf8.DecodeRune(s[pos:]); width > 0 {
+				pos += width
+			} else {
+				pos = len(s) + 1
+			}
+		} else {
+			pos = end
+		}
+		prevEnd = end
+		if accept {
+			if !yield(start, end) {
+				return
+			}
+			k++
+		}
+	}
+}
+
+func matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundary(s []byte, i int) bool {
+	before, after := false, false
+	if i > 0 {
+		r, _ := utf8.DecodeLastRune(s[:i])
+		before = matchUnicodeWordBoundaries21a97053IsUnicodeWordChar(r)
+	}
+	if i < len(s) {
+		r, _ := utf8.DecodeRune(s[i:])
+		after = matchUnicodeWordBoundaries21a97053IsUnicodeWordChar(r)
+	}
+	return before != after
+}
+
+func matchUnicodeWordBoundaries21a97053IsUnicodeWordBoundaryInString(s string, i int) bool {
+	before, after := false, false
+	if i > 0 {
+		r, _ := utf8.DecodeLastRuneInString(s[:i])
+		before = matchUnicodeWordBoundaries21a97053IsUnicodeWordChar(r)
+	}
+	if i < len(s) {
+		r, _ := utf8.DecodeRuneInString(s[i:])
+		after = matchUnicodeWordBoundaries21a97053IsUnicodeWordChar(r)
+	}
+	return before != after
+}
+
+func matchUnicodeWordBoundaries21a97053IsUnicodeWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.In(r, unicode.Mark, unicode.Pc)
+}
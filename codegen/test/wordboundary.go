@@ -4,10 +4,6 @@ package test
 
 import "unicode/utf8"
 
-//func isWordChar(r byte) bool {
-//        return 'A' <= r && r <= 'Z' || 'a' <= r && r <= 'z' || '0' <= r && r <= '9' || r == '_'
-//}
-
 func matchWordBoundary(s string) (end int) {
 	end = -1
 	var r rune
@@ -26,8 +22,12 @@ func matchWordBoundary(s string) (end int) {
 	return
 s2:
 	switch {
-	case (i >= rlen && isWordChar(s[i-rlen])) != (rlen > 0 && i < len(s) && isWordChar(s[i])):
+	case (i > 0 && matchWordBoundary6417c9a8IsWordChar(s[i-1])) != (i < len(s) && matchWordBoundary6417c9a8IsWordChar(s[i])):
 		end = i
 	}
 	return
 }
+
+func matchWordBoundary6417c9a8IsWordChar(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_'
+}
@@ -0,0 +1,218 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package codegen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"strconv"
+	"unicode"
+
+	"github.com/opennota/re2dfa/nfa"
+)
+
+// Number of matching and non-matching strings sampled for each function.
+const testSamples = 20
+
+func exportedName(prefix, name string) string {
+	for i, r := range name {
+		return prefix + string(unicode.ToUpper(r)) + name[i+len(string(r)):]
+	}
+	return prefix
+}
+
+func anchoredPattern(pattern string) string {
+	return `\A(?:` + pattern + `)`
+}
+
+// GoGenerateTest generates a Go test file which checks the matching functions against the regexp package
+// on sampled matching and non-matching strings, and a fuzz target for each function which does the same
+// on arbitrary inputs, seeded with the sampled matching strings. The pattern of each function must be set,
+// and the line terminators and word boundaries must be the ones known to the regexp package.
+//
+// The matches are compared using the leftmost-first semantics if Root is leftmost-first, and using
+// the leftmost-longest semantics otherwise: those of ModeMatch for the patterns without lazy quantifiers,
+// and in the other modes those of regexp.CompilePOSIX, or of a counting automaton finding the same matches
+// as the regexp package (see Func.Search).
+//
+// In ModeMatch the regexp is anchored at the beginning of the input. In ModeSearch the regexp is not
+// anchored, and both the start and the end of the match are compared. In ModeFindAll the matches are
+// compared with regexp.FindAllStringIndex for several limits, and in ModeReplaceAll the result is compared
+// with regexp.ReplaceAllString. In ModeSplit the substrings are compared with regexp.Split for several
+// limits. In ModeBool the regexp is anchored, and only the fact of the match is compared. In ModeMatcher
+// the methods of the type are compared with those of *regexp.Regexp. Failures are reported as an *Error.
+func GoGenerateTest(packageName string, funcs ...Func) (string, error) {
+	fmtImport := ""
+	for _, fn := range funcs {
+		if fn.Mode == ModeFindAll || fn.Mode == ModeSplit || fn.Mode == ModeMatcher {
+			fmtImport = "\"fmt\"\n"
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `// Code generated by re2dfa (https://github.com/opennota/re2dfa).
+
+			package %s
+
+			import (
+				%s"regexp"
+				"testing"
+			)
+`, packageName, fmtImport)
+
+	for _, fn := range funcs {
+		if lt := fn.LineTerminators; lt != 0 && lt != nfa.LineLF {
+			return "", &Error{Stage: "generate", Func: fn.Name, Err: errors.New("the regexp package only supports \\n as a line terminator")}
+		}
+		if fn.UnicodeWordBoundary {
+			return "", &Error{Stage: "generate", Func: fn.Name, Err: errors.New("the regexp package only supports ASCII word boundaries")}
+		}
+
+		arg := "s"
+		if fn.Type == "[]byte" {
+			arg = "[]byte(s)"
+		}
+		longest := ""
+		if !fn.Root.LeftmostFirst {
+			longest = "re.Longest()"
+		}
+
+		pattern := anchoredPattern(fn.Pattern)
+		check := fmt.Sprintf(`want := -1
+					if loc := re.FindStringIndex(s); loc != nil {
+						want = loc[1]
+					}
+					if got := %s(%s); got != want {
+						t.Errorf("%[1]s(%%q) = %%d, want %%d", s, got, want)
+					}`, fn.Name, arg)
+		switch fn.Mode {
+		case ModeSearch:
+			pattern = fn.Pattern
+			check = fmt.Sprintf(`want := []int{-1, -1}
+					if loc := re.FindStringIndex(s); loc != nil {
+						want = loc
+					}
+					if start, end := %s(%s); start != want[0] || end != want[1] {
+						t.Errorf("%[1]s(%%q) = %%d, %%d, want %%d, %%d", s, start, end, want[0], want[1])
+					}`, fn.Name, arg)
+		case ModeFindAll:
+			pattern = fn.Pattern
+			check = fmt.Sprintf(`for _, n := range []int{-1, 0, 1, 2} {
+						want := fmt.Sprint(re.FindAllStringIndex(s, n))
+						if got := fmt.Sprint(%s(%s, n)); got != want {
+							t.Errorf("%[1]s(%%q, %%d) = %%s, want %%s", s, n, got, want)
+						}
+					}`, fn.Name, arg)
+		case ModeReplaceAll:
+			pattern = fn.Pattern
+			check = fmt.Sprintf(`want := re.ReplaceAllString(s, %s)
+					if got := string(%s(%s)); got != want {
+						t.Errorf("%[2]s(%%q) = %%q, want %%q", s, got, want)
+					}`, strconv.Quote(fn.Template), fn.Name, arg)
+		case ModeBool:
+			check = fmt.Sprintf(`want := re.MatchString(s)
+					if got := %s(%s); got != want {
+						t.Errorf("%[1]s(%%q) = %%v, want %%v", s, got, want)
+					}`, fn.Name, arg)
+		case ModeMatcher:
+			pattern = fn.Pattern
+			check = fmt.Sprintf(`var m %s
+					if got := m.String(); got != re.String() {
+						t.Errorf("String() = %%q, want %%q", got, re.String())
+					}
+					want := re.FindStringIndex(s)
+					if got := m.FindStringIndex(s); fmt.Sprint(got) != fmt.Sprint(want) {
+						t.Errorf("FindStringIndex(%%q) = %%v, want %%v", s, got, want)
+					}
+					if got := m.FindIndex([]byte(s)); fmt.Sprint(got) != fmt.Sprint(want) {
+						t.Errorf("FindIndex(%%q) = %%v, want %%v", s, got, want)
+					}
+					if got := m.MatchString(s); got != (want != nil) {
+						t.Errorf("MatchString(%%q) = %%v, want %%v", s, got, want != nil)
+					}
+					if got := m.Match([]byte(s)); got != (want != nil) {
+						t.Errorf("Match(%%q) = %%v, want %%v", s, got, want != nil)
+					}
+					prefix, complete := m.LiteralPrefix()
+					if wantPrefix, wantComplete := re.LiteralPrefix(); prefix != wantPrefix || complete != wantComplete {
+						t.Errorf("LiteralPrefix() = %%q, %%v, want %%q, %%v", prefix, complete, wantPrefix, wantComplete)
+					}`, fn.Name)
+		case ModeSplit:
+			pattern = fn.Pattern
+			check = fmt.Sprintf(`for _, n := range []int{-1, 0, 1, 2, 3} {
+						want := fmt.Sprintf("%%q", re.Split(s, n))
+						if got := fmt.Sprintf("%%q", %s(%s, n)); got != want {
+							t.Errorf("%[1]s(%%q, %%d) = %%s, want %%s", s, n, got, want)
+						}
+					}`, fn.Name, arg)
+		}
+
+		matching, nonMatching := sample(fn.Root, testSamples)
+		if fn.Mode != ModeMatch {
+			// Let the matches start in the middle of the input.
+			for _, s := range matching {
+				nonMatching = append(nonMatching, "x"+s+"x", s+" "+s)
+			}
+		}
+
+		fmt.Fprintf(&buf, `
+			func %s(t *testing.T) {
+				re := regexp.MustCompile(%s)
+				%s
+				for _, s := range []string{
+`, exportedName("Test", fn.Name)+"AgainstRegexp", strconv.Quote(pattern), longest)
+
+		fmt.Fprintln(&buf, "// Sampled from the automaton.")
+		for _, s := range matching {
+			fmt.Fprintf(&buf, "%s,\n", strconv.Quote(s))
+		}
+		fmt.Fprintln(&buf, "// Likely not matching.")
+		for _, s := range nonMatching {
+			fmt.Fprintf(&buf, "%s,\n", strconv.Quote(s))
+		}
+
+		fmt.Fprintf(&buf, `} {
+					%s
+				}
+			}
+`, check)
+
+		fmt.Fprintf(&buf, `
+			func %s(f *testing.F) {
+				re := regexp.MustCompile(%s)
+				%s
+				for _, s := range []string{
+`, exportedName("Fuzz", fn.Name), strconv.Quote(pattern), longest)
+		for _, s := range matching {
+			fmt.Fprintf(&buf, "%s,\n", strconv.Quote(s))
+		}
+		fmt.Fprintf(&buf, `} {
+					f.Add(s)
+				}
+				f.Fuzz(func(t *testing.T, s string) {
+					%s
+				})
+			}
+`, check)
+	}
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return "", &Error{Stage: "format", Err: err}
+	}
+
+	return string(source), nil
+}
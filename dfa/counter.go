@@ -0,0 +1,249 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dfa
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/opennota/re2dfa/nfa"
+	"github.com/opennota/re2dfa/runerange"
+)
+
+// A Counter is the register of a bounded repetition of the NFA (see nfa.Counter). It holds the numbers of
+// runes counted by the threads in the repetition, which are all different, so there are at most Max+1 of them.
+// A state of the automaton contains the repetition if and only if its register isn't empty.
+type Counter struct {
+	N        int // number, from 1
+	Min, Max int
+}
+
+// CounterAction is an operation on the register of a counter.
+type CounterAction int
+
+const (
+	// CounterIncrement counts a rune: every number is incremented, and the number exceeding Max is dropped.
+	CounterIncrement CounterAction = iota + 1
+	// CounterReset empties the register: the threads in the repetition don't match the rune.
+	CounterReset
+	// CounterEnter adds 0: a thread enters the repetition.
+	CounterEnter
+)
+
+// A CounterOp is an operation performed on a counter when a transition is taken.
+type CounterOp struct {
+	C      *Counter
+	Action CounterAction
+}
+
+func (op CounterOp) String() string {
+	switch op.Action {
+	case CounterIncrement:
+		return fmt.Sprintf("increment %d", op.C.N)
+	case CounterReset:
+		return fmt.Sprintf("reset %d", op.C.N)
+	case CounterEnter:
+		return fmt.Sprintf("enter %d", op.C.N)
+	}
+	return fmt.Sprintf("unknown %d", op.C.N)
+}
+
+// CounterState is what the register of a counter allows.
+type CounterState int
+
+const (
+	CounterEmpty      CounterState = iota + 1 // no thread is in the repetition
+	CounterBelowMin                           // every number is less than Min: the repetition can't be left yet
+	CounterAtLeastMin                         // the largest number is at least Min: the repetition can be left
+)
+
+// A CounterCond is a condition on a counter, checked after the operations of a transition.
+type CounterCond struct {
+	C     *Counter
+	State CounterState
+}
+
+func (c CounterCond) String() string {
+	switch c.State {
+	case CounterEmpty:
+		return fmt.Sprintf("%d empty", c.C.N)
+	case CounterBelowMin:
+		return fmt.Sprintf("%d < %d", c.C.N, c.C.Min)
+	case CounterAtLeastMin:
+		return fmt.Sprintf("%d >= %d", c.C.N, c.C.Min)
+	}
+	return fmt.Sprintf("%d unknown", c.C.N)
+}
+
+// SplitOps splits the operations of a transition into the ones counting the rune, performed before checking
+// the conditions, and the ones entering the repetitions, performed after.
+func SplitOps(ops []CounterOp) (count, enter []CounterOp) {
+	for i, op := range ops {
+		if op.Action == CounterEnter {
+			return ops[:i], ops[i:]
+		}
+	}
+	return ops, nil
+}
+
+// counterKey returns a string identifying the operations and the conditions of a transition.
+func counterKey(ops []CounterOp, cond []CounterCond) string {
+	if len(ops) == 0 && len(cond) == 0 {
+		return ""
+	}
+	return fmt.Sprint(ops, cond)
+}
+
+// numberCounters returns the counters of the repetitions of the NFA, numbered in the order of a breadth-first
+// traversal, or nil if there are none.
+func numberCounters(nfanode *nfa.Node) map[*nfa.Node]*Counter {
+	var counters map[*nfa.Node]*Counter
+	seen := map[*nfa.Node]bool{nfanode: true}
+	queue := []*nfa.Node{nfanode}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if n.C != nil {
+			if counters == nil {
+				counters = make(map[*nfa.Node]*Counter)
+			}
+			counters[n] = &Counter{N: len(counters) + 1, Min: n.C.Min, Max: n.C.Max}
+		}
+		for _, t := range n.T {
+			if !seen[t.N] {
+				seen[t.N] = true
+				queue = append(queue, t.N)
+			}
+		}
+	}
+	return counters
+}
+
+// enterOps returns the operations entering the repetitions among the states.
+func (ctx *context) enterOps(cls []*nfa.Node) []CounterOp {
+	var ops []CounterOp
+	for _, n := range cls {
+		if n.C != nil {
+			ops = append(ops, CounterOp{ctx.counters[n], CounterEnter})
+		}
+	}
+	sortOps(ops)
+	return ops
+}
+
+// sortOps orders the operations by counter and action.
+func sortOps(ops []CounterOp) {
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].C.N != ops[j].C.N {
+			return ops[i].C.N < ops[j].C.N
+		}
+		return ops[i].Action < ops[j].Action
+	})
+}
+
+// A target is a state a transition on runes moves to under conditions on the counters, with the operations
+// entering the repetitions.
+type target struct {
+	cls  []*nfa.Node
+	cond []CounterCond
+	ops  []CounterOp
+}
+
+// runeTargets returns the operations on the counters performed by the transition on the runes rr from n before
+// checking the conditions, and the states it moves to. The repetitions of n count the runes, or are left if
+// they don't match them. Whether a repetition which has counted the runes is still in the state, and whether
+// it can be left, depend on its register; there is a target for each possibility. The repetitions reached by
+// the threads which move, the ones leaving a repetition included, are entered after checking the conditions.
+func (ctx *context) runeTargets(n *Node, rr []rune) (ops []CounterOp, targets []target) {
+	type possibility struct {
+		reached  [][]*nfa.Node // the states reached by the moving threads
+		counting []*nfa.Node   // the repetitions still counting
+		cond     []CounterCond
+	}
+	possibilities := []possibility{{reached: closuresForRange(n, rr, ctx)}}
+	for _, k := range n.cls {
+		if k.C == nil {
+			continue
+		}
+		c := ctx.counters[k]
+		if !runerange.Contains(k.C.R, rr) {
+			ops = append(ops, CounterOp{c, CounterReset})
+			continue
+		}
+		ops = append(ops, CounterOp{c, CounterIncrement})
+
+		states := []CounterState{CounterEmpty, CounterBelowMin, CounterAtLeastMin}
+		if k.C.Min == 0 {
+			// Every number is at least 1 after counting the rune.
+			states = []CounterState{CounterEmpty, CounterAtLeastMin}
+		}
+		var exits [][]*nfa.Node
+		for _, t := range k.T {
+			exits = append(exits, closure(t.N, ctx.closureCache))
+		}
+
+		var next []possibility
+		for _, p := range possibilities {
+			for _, st := range states {
+				np := possibility{
+					reached:  p.reached,
+					counting: p.counting,
+					cond:     append(p.cond[:len(p.cond):len(p.cond)], CounterCond{c, st}),
+				}
+				if st != CounterEmpty {
+					np.counting = append(p.counting[:len(p.counting):len(p.counting)], k)
+				}
+				if st == CounterAtLeastMin {
+					np.reached = append(p.reached[:len(p.reached):len(p.reached)], exits...)
+				}
+				next = append(next, np)
+			}
+		}
+		possibilities = next
+	}
+	sortOps(ops)
+
+	same := true
+	for _, p := range possibilities {
+		reached := union(p.reached...)
+		t := target{
+			cls:  union(reached, p.counting),
+			cond: p.cond,
+			ops:  ctx.enterOps(reached),
+		}
+		targets = append(targets, t)
+		same = same && labelFromClosure(t.cls) == labelFromClosure(targets[0].cls) &&
+			counterKey(t.ops, nil) == counterKey(targets[0].ops, nil)
+	}
+	if same {
+		// The conditions don't matter.
+		targets = []target{{cls: targets[0].cls, ops: targets[0].ops}}
+	}
+	return ops, targets
+}
+
+// Counts reports whether the automaton has counters.
+func (root *Node) Counts() bool {
+	if len(root.Init) > 0 {
+		return true
+	}
+	for _, n := range root.nodes() {
+		for _, t := range n.T {
+			if len(t.Ops) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -18,6 +18,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/opennota/re2dfa/nfa"
 	"github.com/opennota/re2dfa/runerange"
@@ -28,55 +29,73 @@ type Node struct {
 	F bool // final?
 	T []T  // transitions
 
+	// The automaton finds the leftmost-first match instead of the leftmost-longest one
+	// (the pattern has lazy quantifiers).
+	LeftmostFirst bool
+
+	// On the initial node, the operations on the counters performed before reading the input
+	// (see Counter and T.Ops).
+	Init []CounterOp
+
 	label string
 	cls   []*nfa.Node
+	done  []*nfa.Node // the states whose assertions held at the current position
 }
 
 type T struct {
 	R []rune // rune ranges
 	N *Node  // node
+
+	// If the NFA has counters (see nfa.Counter), the operations on the counters performed when the transition
+	// is taken, and the conditions on the counters under which it is taken. The counters are incremented or
+	// reset, which all the transitions on a rune from a node do alike, then the conditions are checked, and
+	// the rune moves to the target of the transition whose conditions hold, if any, entering the repetitions
+	// of its operations.
+	Ops  []CounterOp
+	Cond []CounterCond
 }
 
 type context struct {
 	state        int
 	nodesByLabel map[string]*Node
 	closureCache map[*nfa.Node][]*nfa.Node
+	counters     map[*nfa.Node]*Counter
 }
 
+// NewFromNFA constructs an automaton finding the longest match at the beginning of the input, or
+// the leftmost-first one if the NFA has lazy transitions (see Node.LeftmostFirst). The counters of
+// the NFA are kept as registers (see Counter), which lazy transitions don't support.
 func NewFromNFA(nfanode *nfa.Node) *Node {
+	if hasLazy(nfanode) {
+		return NewLeftmostFirstFromNFA(nfanode)
+	}
 	ctx := &context{
 		nodesByLabel: make(map[string]*Node),
 		closureCache: make(map[*nfa.Node][]*nfa.Node),
+		counters:     numberCounters(nfanode),
 	}
 	node := firstNode(nfanode, ctx)
 	constructSubset(node, ctx)
 	return node
 }
 
-func recursiveClosure(node *nfa.Node, visited map[*nfa.Node]struct{}) []*nfa.Node {
-	if visited != nil {
-		if _, ok := visited[node]; ok {
-			return nil
-		}
-	}
-
+// closureOf returns the NFA states reachable from node through empty transitions, node included.
+// Every state is visited once, so cycles of empty transitions are followed only once. The transitions
+// leaving a repetition are only followed if it can be left without counting any rune.
+func closureOf(node *nfa.Node) []*nfa.Node {
+	visited := map[*nfa.Node]bool{node: true}
 	cls := []*nfa.Node{node}
-	for _, t := range node.T {
-		if t.R == nil {
-			if visited == nil {
-				visited = make(map[*nfa.Node]struct{})
-				visited[node] = struct{}{}
-			}
-			if c := recursiveClosure(t.N, visited); c != nil {
-				cls = append(cls, c...)
+	for i := 0; i < len(cls); i++ {
+		if c := cls[i].C; c != nil && c.Min > 0 {
+			continue
+		}
+		for _, t := range cls[i].T {
+			if t.R == nil && !visited[t.N] {
+				visited[t.N] = true
+				cls = append(cls, t.N)
 			}
 		}
 	}
-
-	if visited != nil {
-		delete(visited, node)
-	}
-
 	return cls
 }
 
@@ -87,7 +106,7 @@ func closure(node *nfa.Node, cache map[*nfa.Node][]*nfa.Node) []*nfa.Node {
 		}
 	}
 
-	cls := recursiveClosure(node, nil)
+	cls := closureOf(node)
 
 	if cache != nil {
 		cache[node] = cls
@@ -96,6 +115,22 @@ func closure(node *nfa.Node, cache map[*nfa.Node][]*nfa.Node) []*nfa.Node {
 	return cls
 }
 
+// Closure returns the NFA states reachable from node through empty transitions, node included.
+// The closures are memoized in cache if it isn't nil.
+func Closure(node *nfa.Node, cache map[*nfa.Node][]*nfa.Node) []*nfa.Node {
+	return closure(node, cache)
+}
+
+// Union returns the set union of the closures.
+func Union(cls ...[]*nfa.Node) []*nfa.Node {
+	return union(cls...)
+}
+
+// Label returns the label identifying the DFA state of the set of NFA states.
+func Label(cls []*nfa.Node) string {
+	return labelFromClosure(cls)
+}
+
 func intsToStrings(a []int) []string {
 	s := make([]string, 0, len(a))
 	for _, i := range a {
@@ -170,52 +205,129 @@ func closuresForRange(n *Node, rr []rune, ctx *context) (closures [][]*nfa.Node)
 	return
 }
 
+// assertionTarget returns the states after the assertion rr holds: the states waiting for the assertion are
+// replaced by the states they move to, and the other states are kept, so the assertions can be checked one
+// after another before reading the next rune. The states waiting for the assertion are added to done, and are
+// never entered again at the same position, so that the transitions on assertions make progress. The entered
+// states are the ones the waiting states move to.
+func assertionTarget(n *Node, rr []rune, ctx *context) (cls, done, entered []*nfa.Node) {
+	done = append(done, n.done...)
+	var kept []*nfa.Node
+	var closures [][]*nfa.Node
+	for _, n := range n.cls {
+		waits := false
+		for _, t := range n.T {
+			if runerange.Contains(t.R, rr) {
+				closures = append(closures, closure(t.N, ctx.closureCache))
+				waits = true
+			}
+		}
+		if waits {
+			done = append(done, n)
+		} else {
+			kept = append(kept, n)
+		}
+	}
+
+	isDone := make(map[*nfa.Node]bool, len(done))
+	for _, n := range done {
+		isDone[n] = true
+	}
+	for _, n := range union(append(closures, kept)...) {
+		if !isDone[n] {
+			cls = append(cls, n)
+		}
+	}
+	return cls, done, union(closures...)
+}
+
 func constructSubset(root *Node, ctx *context) {
 	var ranges [][]rune
 	for _, n := range root.cls {
 		for _, t := range n.T {
 			ranges = append(ranges, t.R)
 		}
+		if n.C != nil {
+			ranges = append(ranges, n.C.R)
+		}
 	}
 	pairs := runerange.Split(ranges)
 
-	m := make(map[*Node][]rune)
+	// The transitions by target and counter operations and conditions.
+	m := make(map[string]*T)
+	add := func(node *Node, ops []CounterOp, cond []CounterCond, rr []rune) {
+		key := node.label + "|" + counterKey(ops, cond)
+		if t, ok := m[key]; ok {
+			t.R = runerange.Sum(t.R, rr)
+		} else {
+			m[key] = &T{R: runerange.Sum(nil, rr), N: node, Ops: ops, Cond: cond}
+		}
+	}
 
 	for i := 0; i < len(pairs); i += 2 {
-		cls := union(closuresForRange(root, pairs[i:i+2], ctx)...)
-
-		label := labelFromClosure(cls)
-		var node *Node
-		if n, ok := ctx.nodesByLabel[label]; ok {
-			node = n
-		} else {
-			ctx.state++
-			node = &Node{
-				S:     ctx.state,
-				F:     isFinal(cls),
-				label: label,
-				cls:   cls,
+		rr := pairs[i : i+2]
+		if rr[0] < 0 {
+			cls, done, entered := assertionTarget(root, rr, ctx)
+			if len(cls) > 0 {
+				add(ctx.node(cls, done), ctx.enterOps(entered), nil, rr)
 			}
-			ctx.nodesByLabel[label] = node
-			constructSubset(node, ctx)
+			continue
 		}
+		ops, targets := ctx.runeTargets(root, rr)
+		for _, t := range targets {
+			if len(t.cls) > 0 {
+				add(ctx.node(t.cls, nil), append(ops[:len(ops):len(ops)], t.ops...), t.cond, rr)
+			}
+		}
+	}
 
-		m[node] = runerange.Sum(m[node], pairs[i:i+2])
+	for _, t := range m {
+		root.T = append(root.T, *t)
 	}
+	sort.Sort(transitionsByRange(root.T))
+}
 
-	for n, rr := range m {
-		root.T = append(root.T, T{rr, n})
+// node returns the node for the states, constructing it if it doesn't exist yet.
+func (ctx *context) node(cls, done []*nfa.Node) *Node {
+	label := labelFromClosure(cls) + "|" + labelFromClosure(done)
+	if n, ok := ctx.nodesByLabel[label]; ok {
+		return n
+	}
+	ctx.state++
+	n := &Node{
+		S:     ctx.state,
+		F:     isFinal(cls),
+		label: label,
+		cls:   cls,
+		done:  done,
 	}
+	ctx.nodesByLabel[label] = n
+	constructSubset(n, ctx)
+	return n
 }
 
+// transitionsByRange orders the transitions by their first rune, and the transitions on the same runes
+// by their conditions on the counters.
+type transitionsByRange []T
+
+func (t transitionsByRange) Len() int { return len(t) }
+func (t transitionsByRange) Less(i, j int) bool {
+	if t[i].R[0] != t[j].R[0] {
+		return t[i].R[0] < t[j].R[0]
+	}
+	return counterKey(nil, t[i].Cond) < counterKey(nil, t[j].Cond)
+}
+func (t transitionsByRange) Swap(i, j int) { t[i], t[j] = t[j], t[i] }
+
 func firstNode(nfanode *nfa.Node, ctx *context) *Node {
 	cls := closure(nfanode, ctx.closureCache)
-	label := labelFromClosure(cls)
+	label := labelFromClosure(cls) + "|"
 
 	ctx.state++
 	node := &Node{
 		S:     ctx.state,
 		F:     isFinal(cls),
+		Init:  ctx.enterOps(cls),
 		label: label,
 		cls:   cls,
 	}
@@ -223,3 +335,180 @@ func firstNode(nfanode *nfa.Node, ctx *context) *Node {
 
 	return node
 }
+
+// LiteralPrefix returns the literal string every match of the automaton starts with.
+// The complete result is true if the prefix is the only possible match.
+func (root *Node) LiteralPrefix() (prefix string, complete bool) {
+	var buf []byte
+	n := root
+	for !n.F && len(n.T) == 1 && singleRune(n.T[0].R) >= 0 {
+		buf = utf8.AppendRune(buf, singleRune(n.T[0].R))
+		n = n.T[0].N
+	}
+	return string(buf), n.F && len(n.T) == 0
+}
+
+// RequiredFactors returns the literal strings every match of the automaton contains.
+// Factors contained in other factors are omitted; longer factors come first.
+func (root *Node) RequiredFactors() []string {
+	nodes := root.nodes()
+	live := liveNodes(nodes)
+	if !live[root] {
+		return nil
+	}
+
+	var candidates []rune
+	seenRune := make(map[rune]bool)
+	for _, n := range nodes {
+		for _, t := range n.T {
+			if r := singleRune(t.R); r >= 0 && !seenRune[r] {
+				seenRune[r] = true
+				candidates = append(candidates, r)
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	var factors []string
+	for _, r := range candidates {
+		if root.reachesFinalWithout(r) {
+			continue
+		}
+		// Every match takes one of the transitions on r alone. It is followed by the runes which are
+		// the only way to continue after the transition.
+		factor := ""
+		first := true
+		for _, n := range nodes {
+			for _, t := range n.T {
+				if !live[t.N] || singleRune(t.R) != r {
+					continue
+				}
+				f := string(r) + forcedString(t.N)
+				if first {
+					factor = f
+					first = false
+				} else {
+					factor = commonPrefix(factor, f)
+				}
+			}
+		}
+		if !seen[factor] {
+			seen[factor] = true
+			factors = append(factors, factor)
+		}
+	}
+
+	sort.Slice(factors, func(i, j int) bool {
+		if len(factors[i]) != len(factors[j]) {
+			return len(factors[i]) > len(factors[j])
+		}
+		return factors[i] < factors[j]
+	})
+	var maximal []string
+	for _, f := range factors {
+		contained := false
+		for _, g := range maximal {
+			if strings.Contains(g, f) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			maximal = append(maximal, f)
+		}
+	}
+	return maximal
+}
+
+// singleRune returns the rune if rr consists of a single valid rune, or -1 otherwise.
+func singleRune(rr []rune) rune {
+	if len(rr) != 2 || rr[0] != rr[1] || rr[0] < 0 || !utf8.ValidRune(rr[0]) {
+		return -1
+	}
+	return rr[0]
+}
+
+// forcedString returns the string which must follow after reaching n.
+func forcedString(n *Node) string {
+	var buf []byte
+	visited := make(map[*Node]bool)
+	for !n.F && len(n.T) == 1 && singleRune(n.T[0].R) >= 0 && !visited[n] {
+		visited[n] = true
+		buf = utf8.AppendRune(buf, singleRune(n.T[0].R))
+		n = n.T[0].N
+	}
+	return string(buf)
+}
+
+func commonPrefix(a, b string) string {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	for i < len(a) && !utf8.RuneStart(a[i]) {
+		i--
+	}
+	return a[:i]
+}
+
+// nodes returns all the nodes reachable from root.
+func (root *Node) nodes() []*Node {
+	visited := map[*Node]bool{root: true}
+	nodes := []*Node{root}
+	for i := 0; i < len(nodes); i++ {
+		for _, t := range nodes[i].T {
+			if !visited[t.N] {
+				visited[t.N] = true
+				nodes = append(nodes, t.N)
+			}
+		}
+	}
+	return nodes
+}
+
+// liveNodes returns the set of nodes from which a final node is reachable.
+func liveNodes(nodes []*Node) map[*Node]bool {
+	live := make(map[*Node]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, n := range nodes {
+			if live[n] {
+				continue
+			}
+			if n.F {
+				live[n] = true
+				changed = true
+				continue
+			}
+			for _, t := range n.T {
+				if live[t.N] {
+					live[n] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return live
+}
+
+// reachesFinalWithout reports whether a final node is reachable from root without the transitions on r alone.
+func (root *Node) reachesFinalWithout(r rune) bool {
+	visited := map[*Node]bool{root: true}
+	queue := []*Node{root}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if n.F {
+			return true
+		}
+		for _, t := range n.T {
+			if visited[t.N] || singleRune(t.R) == r {
+				continue
+			}
+			visited[t.N] = true
+			queue = append(queue, t.N)
+		}
+	}
+	return false
+}
@@ -0,0 +1,294 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dfa
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/opennota/re2dfa/nfa"
+	"github.com/opennota/re2dfa/runerange"
+)
+
+var (
+	randomAtoms  = []string{"a", "b", "x", ".", "[ab]", "^", "$", `\A`, `\z`, `\b`, `\B`, "(?m:^)", "(?m:$)", ""}
+	randomQuants = []string{"*", "+", "?", "{0,2}", "{1,3}", "{2}"}
+	randomRunes  = []string{"a", "b", "x", " ", "\n"}
+)
+
+// randomPattern returns a random pattern of the given depth, made of assertions, repetitions and alternations.
+func randomPattern(rnd *rand.Rand, depth int) string {
+	if depth == 0 {
+		return randomAtoms[rnd.Intn(len(randomAtoms))]
+	}
+	switch rnd.Intn(4) {
+	case 0:
+		return randomPattern(rnd, depth-1) + randomPattern(rnd, depth-1)
+	case 1:
+		return "(?:" + randomPattern(rnd, depth-1) + "|" + randomPattern(rnd, depth-1) + ")"
+	case 2:
+		q := randomQuants[rnd.Intn(len(randomQuants))]
+		if rnd.Intn(2) == 0 {
+			q += "?"
+		}
+		return "(" + randomPattern(rnd, depth-1) + ")" + q
+	}
+	return randomPattern(rnd, depth-1)
+}
+
+func randomInput(rnd *rand.Rand) string {
+	var sb strings.Builder
+	for i := rnd.Intn(6); i > 0; i-- {
+		sb.WriteString(randomRunes[rnd.Intn(len(randomRunes))])
+	}
+	return sb.String()
+}
+
+// reversedAssertions maps the assertions of a reversed pattern to the ones they stand for.
+var reversedAssertions = map[rune]rune{
+	nfa.RuneBeginText: nfa.RuneEndText,
+	nfa.RuneEndText:   nfa.RuneBeginText,
+	nfa.RuneBeginLine: nfa.RuneEndLine,
+	nfa.RuneEndLine:   nfa.RuneBeginLine,
+}
+
+// A register holds the numbers of runes counted when the threads entered a bounded repetition, oldest first.
+type register struct {
+	entered []int
+	count   int
+}
+
+func (reg *register) do(op CounterOp) {
+	switch op.Action {
+	case CounterIncrement:
+		reg.count++
+		if len(reg.entered) > 0 && reg.count-reg.entered[0] > op.C.Max {
+			reg.entered = reg.entered[1:]
+		}
+	case CounterReset:
+		reg.entered = nil
+	case CounterEnter:
+		if len(reg.entered) == 0 || reg.entered[len(reg.entered)-1] != reg.count {
+			reg.entered = append(reg.entered, reg.count)
+		}
+	}
+}
+
+func (reg *register) holds(cond CounterCond) bool {
+	switch cond.State {
+	case CounterEmpty:
+		return len(reg.entered) == 0
+	case CounterBelowMin:
+		return len(reg.entered) > 0 && reg.count-reg.entered[0] < cond.C.Min
+	case CounterAtLeastMin:
+		return len(reg.entered) > 0 && reg.count-reg.entered[0] >= cond.C.Min
+	}
+	return false
+}
+
+type registers map[*Counter]*register
+
+func (regs registers) do(ops []CounterOp) {
+	for _, op := range ops {
+		if regs[op.C] == nil {
+			regs[op.C] = &register{}
+		}
+		regs[op.C].do(op)
+	}
+}
+
+func (regs registers) holds(cond []CounterCond) bool {
+	for _, c := range cond {
+		reg := regs[c.C]
+		if reg == nil {
+			reg = &register{}
+		}
+		if !reg.holds(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// exec runs the automaton on s from the position at, forward or backward, as the generated code does:
+// the first transition on an assertion which holds is taken before reading a rune. It returns the last
+// position where the automaton was in a final state, or -1.
+func exec(root *Node, s string, at int, backward bool) int {
+	end := -1
+	if root.F {
+		end = at
+	}
+	regs := make(registers)
+	regs.do(root.Init)
+	n, i := root, at
+	for n != nil {
+		var next *Node
+		asserted := false
+		for _, t := range n.T {
+			for k := 0; k < len(t.R) && t.R[k] < 0 && !asserted; k += 2 {
+				r := t.R[k]
+				if swapped, ok := reversedAssertions[r]; ok && backward {
+					r = swapped
+				}
+				if nfa.Assert(r, s, i, 0, false) {
+					asserted = true
+					regs.do(t.Ops)
+					next = t.N
+				}
+			}
+			if asserted {
+				break
+			}
+		}
+		if !asserted {
+			var r rune
+			var rlen int
+			if backward {
+				r, rlen = utf8.DecodeLastRuneInString(s[:i])
+				i -= rlen
+			} else {
+				r, rlen = utf8.DecodeRuneInString(s[i:])
+				i += rlen
+			}
+			if rlen == 0 {
+				break
+			}
+			counted := false
+			for _, t := range n.T {
+				k := 0
+				for k < len(t.R) && t.R[k] < 0 {
+					k += 2
+				}
+				if !runerange.In(t.R[k:], r) {
+					continue
+				}
+				count, enter := SplitOps(t.Ops)
+				if !counted {
+					regs.do(count)
+					counted = true
+				}
+				if regs.holds(t.Cond) {
+					regs.do(enter)
+					next = t.N
+					break
+				}
+			}
+		}
+		if next != nil && next.F {
+			end = i
+		}
+		n = next
+	}
+	return end
+}
+
+// find returns the match found by running the search automaton forward and the reverse one backward from its end.
+func find(search, reverse *Node, s string) (start, end int) {
+	end = exec(search, s, 0, false)
+	if end < 0 {
+		return -1, -1
+	}
+	return exec(reverse, s, end, true), end
+}
+
+func TestRandomAgainstVM(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for p := 0; p < 3000; p++ {
+		pattern := randomPattern(rnd, 1+rnd.Intn(4))
+		n, err := nfa.New(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		first := NewLeftmostFirstFromNFA(n)
+		firstSearch := NewLeftmostFirstSearchFromNFA(n)
+		rn, err := nfa.NewReverse(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reverse := NewSearchFromNFA(rn, true)
+		root := NewFromNFA(n)
+		minimized := Minimize(NewFromNFA(n))
+		lazy := hasLazy(n)
+		vm := nfa.NewVM(n, nfa.VMOptions{Longest: !lazy})
+		firstVM := nfa.NewVM(n, nfa.VMOptions{})
+
+		var counted, search *Node
+		if !lazy {
+			r, err := nfa.Options{CountThreshold: 2}.Parse(pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cn, err := nfa.NewFromRegexp(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			counted = NewFromNFA(cn)
+			search = NewSearchFromNFA(n, false)
+		}
+
+		for i := 0; i < 20; i++ {
+			s := randomInput(rnd)
+			want := firstVM.Match(s)
+			if got := exec(first, s, 0, false); got != want {
+				t.Fatalf("leftmost-first %q on %q: got %d, want %d", pattern, s, got, want)
+			}
+			start, end := find(firstSearch, reverse, s)
+			if wantStart, wantEnd := firstVM.Find(s); start != wantStart || end != wantEnd {
+				t.Fatalf("leftmost-first search %q on %q: got [%d %d], want [%d %d]", pattern, s, start, end, wantStart, wantEnd)
+			}
+
+			want = vm.Match(s)
+			if got := exec(root, s, 0, false); got != want {
+				t.Fatalf("%q on %q: got %d, want %d", pattern, s, got, want)
+			}
+			if got := exec(minimized, s, 0, false); got != want {
+				t.Fatalf("minimized %q on %q: got %d, want %d", pattern, s, got, want)
+			}
+			if lazy {
+				continue
+			}
+			if got := exec(counted, s, 0, false); got != want {
+				t.Fatalf("counted %q on %q: got %d, want %d", pattern, s, got, want)
+			}
+
+			start, end = find(search, reverse, s)
+			if wantStart, wantEnd := vm.Find(s); start != wantStart || end != wantEnd {
+				t.Fatalf("search %q on %q: got [%d %d], want [%d %d]", pattern, s, start, end, wantStart, wantEnd)
+			}
+		}
+	}
+}
+
+func TestLeftmostFirstAssertions(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		s       string
+		want    int
+	}{
+		{`(?:^)?(?:^)?x??(?:^|b)`, "b", 0},
+		{`(?:^^)?x??(?:^|b)`, "b", 0},
+		{`(([^a])??((?m:^)|a))*`, "xa", 0},
+		{`(((\A|x))+?)+`, "xabx", 0},
+	} {
+		n, err := nfa.New(tc.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := exec(NewFromNFA(n), tc.s, 0, false); got != tc.want {
+			t.Errorf("%q on %q: got %d, want %d", tc.pattern, tc.s, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dfa
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opennota/re2dfa/nfa"
+	"github.com/opennota/re2dfa/runerange"
+)
+
+// A firstState is a state of a leftmost-first automaton: the NFA states waiting for a rune or an assertion,
+// or final, ordered by priority as the threads of nfa.VM.
+type firstState struct {
+	threads []*nfa.Node
+	entered []*nfa.Node // the states the threads are expanded from at the current position, in order
+	holds   []rune      // the assertions which hold at the current position, in increasing order
+	matched bool        // a match has been found; no more matches are started
+}
+
+type firstContext struct {
+	state        int
+	start        *nfa.Node // the state where a match is started after every rune, or nil if anchored
+	nodesByLabel map[string]*Node
+	states       map[*Node]*firstState
+	constructed  map[*Node]bool
+}
+
+// NewLeftmostFirstFromNFA constructs an automaton finding the leftmost-first match at the beginning of the input,
+// as the regexp package does, whether the NFA has lazy transitions or not. The threads are kept in the order
+// of their priority, and the threads with a lower priority than a final one are cut, so a lazy quantifier
+// stops at the first match of what follows. Counted repetitions are not supported.
+//
+// A transition on an assertion adds it to the assertions which hold at the current position, and the threads
+// are expanded again from the states entered at the position, following the transitions on all of these
+// assertions, with a single set of visited states, as nfa.VM does. The assertions can thus be checked one
+// after another before reading the next rune, each of them at most once.
+func NewLeftmostFirstFromNFA(nfanode *nfa.Node) *Node {
+	return newLeftmostFirst(nfanode, false)
+}
+
+// NewLeftmostFirstSearchFromNFA constructs an unanchored automaton finding the end of the leftmost-first match,
+// the one of the regexp package. As in nfa.VM, a match is started after every rune until a match is found,
+// with a lower priority than the matches started earlier. The end of the match is the last position where
+// the automaton is in a final state. Counted repetitions are not supported.
+func NewLeftmostFirstSearchFromNFA(nfanode *nfa.Node) *Node {
+	return newLeftmostFirst(nfanode, true)
+}
+
+func newLeftmostFirst(nfanode *nfa.Node, unanchored bool) *Node {
+	ctx := &firstContext{
+		nodesByLabel: make(map[string]*Node),
+		states:       make(map[*Node]*firstState),
+		constructed:  make(map[*Node]bool),
+	}
+	if unanchored {
+		ctx.start = nfanode
+	}
+	root := ctx.node(&firstState{entered: []*nfa.Node{nfanode}})
+	ctx.construct(root)
+	return root
+}
+
+// hasLazy reports whether a lazy transition is reachable from node.
+func hasLazy(node *nfa.Node) bool {
+	seen := map[*nfa.Node]bool{node: true}
+	queue := []*nfa.Node{node}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, t := range n.T {
+			if isLazy(t) {
+				return true
+			}
+			if !seen[t.N] {
+				seen[t.N] = true
+				queue = append(queue, t.N)
+			}
+		}
+	}
+	return false
+}
+
+func isLazy(t nfa.T) bool {
+	return len(t.R) > 0 && t.R[0] == nfa.RuneLazy
+}
+
+// held reports whether the transition is on an assertion among holds.
+func held(t nfa.T, holds []rune) bool {
+	for k := 0; k < len(t.R) && t.R[k] < 0; k += 2 {
+		i := sort.Search(len(holds), func(i int) bool { return holds[i] >= t.R[k] })
+		if i < len(holds) && holds[i] == t.R[k] {
+			return true
+		}
+	}
+	return false
+}
+
+// add appends to threads the states reachable from node through empty transitions, lazy transitions and
+// transitions on the assertions among holds, which wait for a rune or another assertion, or are final,
+// in the order of priority: the transitions are followed in order, except the lazy ones, which are followed
+// last. The states visited at the current position are skipped.
+func add(threads []*nfa.Node, node *nfa.Node, holds []rune, visited map[*nfa.Node]bool) []*nfa.Node {
+	if visited[node] {
+		return threads
+	}
+	visited[node] = true
+
+	waits := node.F
+	for _, t := range node.T {
+		if t.R != nil && !isLazy(t) && !held(t, holds) {
+			waits = true
+		}
+	}
+	if waits {
+		threads = append(threads, node)
+	}
+	for _, t := range node.T {
+		if t.R == nil || !isLazy(t) && held(t, holds) {
+			threads = add(threads, t.N, holds, visited)
+		}
+	}
+	for _, t := range node.T {
+		if isLazy(t) {
+			threads = add(threads, t.N, holds, visited)
+		}
+	}
+	return threads
+}
+
+// node returns the node for the state, expanding its threads and constructing it if it doesn't exist yet.
+func (ctx *firstContext) node(st *firstState) *Node {
+	visited := make(map[*nfa.Node]bool)
+	for _, n := range st.entered {
+		st.threads = add(st.threads, n, st.holds, visited)
+	}
+	for i, n := range st.threads {
+		if n.F {
+			// The threads of a lower priority are cut.
+			st.threads = st.threads[:i+1]
+			st.matched = true
+			break
+		}
+	}
+
+	label := firstLabel(st.threads)
+	if ctx.start != nil {
+		label += "|" + strconv.FormatBool(st.matched)
+	}
+	for _, n := range st.threads {
+		if waitsForAssertion(n) {
+			// The threads will be expanded again.
+			holds := make([]string, len(st.holds))
+			for i, r := range st.holds {
+				holds[i] = strconv.Itoa(int(r))
+			}
+			label += "|" + firstLabel(st.entered) + "|" + strings.Join(holds, ",")
+			break
+		}
+	}
+	if n, ok := ctx.nodesByLabel[label]; ok {
+		return n
+	}
+
+	ctx.state++
+	n := &Node{
+		S:             ctx.state,
+		F:             len(st.threads) > 0 && st.threads[len(st.threads)-1].F,
+		LeftmostFirst: true,
+		label:         label,
+	}
+	ctx.nodesByLabel[label] = n
+	ctx.states[n] = st
+	return n
+}
+
+// firstLabel returns the numbers of the states, in order.
+func firstLabel(nodes []*nfa.Node) string {
+	states := make([]string, len(nodes))
+	for i, n := range nodes {
+		states[i] = strconv.Itoa(n.S)
+	}
+	return strings.Join(states, ",")
+}
+
+// waitsForAssertion reports whether the state has a transition on an assertion.
+func waitsForAssertion(n *nfa.Node) bool {
+	for _, t := range n.T {
+		if len(t.R) > 0 && t.R[0] < 0 && !isLazy(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ctx *firstContext) construct(root *Node) {
+	ctx.constructed[root] = true
+	st := ctx.states[root]
+
+	var ranges [][]rune
+	for _, n := range st.threads {
+		for _, t := range n.T {
+			if t.R != nil && !isLazy(t) {
+				ranges = append(ranges, t.R)
+			}
+		}
+	}
+	if ctx.start != nil && !st.matched {
+		// A match is started after any rune.
+		ranges = append(ranges, []rune{0, nfa.RuneLast})
+	}
+	if len(ranges) == 0 {
+		return
+	}
+	pairs := runerange.Split(ranges)
+
+	m := make(map[*Node][]rune)
+	var targets []*Node
+	for i := 0; i < len(pairs); i += 2 {
+		rr := pairs[i : i+2]
+		next := &firstState{matched: st.matched}
+		if rr[0] < 0 {
+			// The threads are expanded again from the same states, so that the ones reached through
+			// the assertion take their place in the order of priority.
+			next.entered = st.entered
+			next.holds = append(append([]rune(nil), st.holds...), rr[0])
+			sort.Slice(next.holds, func(i, j int) bool { return next.holds[i] < next.holds[j] })
+		} else {
+			for _, n := range st.threads {
+				for _, t := range n.T {
+					if t.R != nil && !isLazy(t) && runerange.Contains(t.R, rr) {
+						next.entered = append(next.entered, t.N)
+					}
+				}
+			}
+			if ctx.start != nil && !st.matched {
+				next.entered = append(next.entered, ctx.start)
+			}
+		}
+
+		node := ctx.node(next)
+		if len(ctx.states[node].threads) == 0 {
+			continue
+		}
+		if _, ok := m[node]; !ok {
+			targets = append(targets, node)
+		}
+		m[node] = runerange.Sum(m[node], rr)
+	}
+
+	for _, n := range targets {
+		root.T = append(root.T, T{R: m[n], N: n})
+	}
+	sort.Sort(transitionsByRange(root.T))
+
+	for _, n := range targets {
+		if !ctx.constructed[n] {
+			ctx.construct(n)
+		}
+	}
+}
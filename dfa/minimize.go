@@ -0,0 +1,140 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dfa
+
+import (
+	"fmt"
+	"sort"
+)
+
+// A blockRange is a range of runes, or a pseudo-rune, moving to a state of a block, with the operations
+// and the conditions on the counters of the transition.
+type blockRange struct {
+	lo, hi   rune
+	block    int
+	counters string
+}
+
+// A blockTarget is the block of a transition of the minimized automaton with its counter operations.
+type blockTarget struct {
+	block    int
+	counters string
+}
+
+// Minimize returns an equivalent automaton with the fewest states. The states from which no final state
+// can be reached are dropped, and the states which can't be told apart by any input are merged (Moore's
+// algorithm; the pseudo-runes of the assertions are treated as runes, and the transitions with different
+// operations or conditions on the counters as transitions on different runes). The original automaton is not
+// modified.
+func Minimize(root *Node) *Node {
+	nodes := root.nodes()
+	live := liveNodes(nodes)
+
+	block := make(map[*Node]int, len(nodes))
+	for _, n := range nodes {
+		if n.F {
+			block[n] = 1
+		}
+	}
+	blocks := 0
+	for {
+		signatures := make(map[string]int)
+		next := make(map[*Node]int, len(nodes))
+		for _, n := range nodes {
+			sig := fmt.Sprint(block[n], blockRanges(n, block, live))
+			b, ok := signatures[sig]
+			if !ok {
+				b = len(signatures)
+				signatures[sig] = b
+			}
+			next[n] = b
+		}
+		block = next
+		if len(signatures) == blocks {
+			break
+		}
+		blocks = len(signatures)
+	}
+
+	// The nodes of the blocks are numbered in the order of a breadth-first traversal from the root.
+	minimized := make(map[int]*Node, blocks)
+	minimized[block[root]] = &Node{S: 1, F: root.F, LeftmostFirst: root.LeftmostFirst, Init: root.Init}
+	queue := []*Node{root}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		m := minimized[block[n]]
+
+		ranges := make(map[blockTarget][]rune)
+		var targets []blockTarget
+		for _, br := range blockRanges(n, block, live) {
+			bt := blockTarget{br.block, br.counters}
+			if _, ok := ranges[bt]; !ok {
+				targets = append(targets, bt)
+			}
+			ranges[bt] = append(ranges[bt], br.lo, br.hi)
+		}
+		for _, bt := range targets {
+			var original T
+			for _, t := range n.T {
+				if block[t.N] == bt.block && counterKey(t.Ops, t.Cond) == bt.counters {
+					original = t
+					break
+				}
+			}
+			target, ok := minimized[bt.block]
+			if !ok {
+				target = &Node{S: len(minimized) + 1, F: original.N.F, LeftmostFirst: original.N.LeftmostFirst}
+				minimized[bt.block] = target
+				queue = append(queue, original.N)
+			}
+			m.T = append(m.T, T{R: ranges[bt], N: target, Ops: original.Ops, Cond: original.Cond})
+		}
+		sort.Sort(transitionsByRange(m.T))
+	}
+	return minimized[block[root]]
+}
+
+// blockRanges returns the transitions of n to live states as ranges sorted by their counter operations and
+// conditions and by their first rune, with the
+// adjacent ranges moving to the same block merged, so that the states whose transitions only differ by how
+// the runes are split between the ranges have the same ranges. The pseudo-runes are never merged.
+func blockRanges(n *Node, block map[*Node]int, live map[*Node]bool) []blockRange {
+	var ranges []blockRange
+	for _, t := range n.T {
+		if !live[t.N] {
+			continue
+		}
+		for i := 0; i < len(t.R); i += 2 {
+			ranges = append(ranges, blockRange{t.R[i], t.R[i+1], block[t.N], counterKey(t.Ops, t.Cond)})
+		}
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		if ranges[i].counters != ranges[j].counters {
+			return ranges[i].counters < ranges[j].counters
+		}
+		return ranges[i].lo < ranges[j].lo
+	})
+
+	merged := ranges[:0]
+	for _, br := range ranges {
+		if k := len(merged) - 1; k >= 0 && merged[k].block == br.block && merged[k].counters == br.counters &&
+			merged[k].hi >= 0 && merged[k].hi+1 == br.lo {
+			merged[k].hi = br.hi
+			continue
+		}
+		merged = append(merged, br)
+	}
+	return merged
+}
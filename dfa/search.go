@@ -0,0 +1,201 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dfa
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opennota/re2dfa/nfa"
+	"github.com/opennota/re2dfa/runerange"
+)
+
+// A searchState is a state of the search automaton: the NFA states grouped by the position
+// where the matching started, from the leftmost.
+type searchState struct {
+	groups  [][]*nfa.Node
+	matched bool        // a match has been found; no more matches are started
+	done    []*nfa.Node // the states whose assertions held at the current position
+}
+
+type searchContext struct {
+	state        int
+	start        []*nfa.Node
+	anchored     bool
+	nodesByLabel map[string]*Node
+	states       map[*Node]*searchState
+	constructed  map[*Node]bool
+	closureCache map[*nfa.Node][]*nfa.Node
+}
+
+// NewSearchFromNFA constructs an automaton finding the end of the leftmost-longest match, like the DFA of RE2.
+// Unless anchored, a match is started at every position until a match is found; the matches started later than
+// the one found are dropped. The end of the match is the last position where the automaton is in a final state.
+//
+// Transitions on assertions keep the states which don't depend on the assertion, so the assertions
+// can be checked one after another before reading the next rune. The states whose assertions held are
+// remembered until the next rune, so that the transitions on assertions make progress. Lazy quantifiers
+// and counted repetitions are not supported.
+func NewSearchFromNFA(nfanode *nfa.Node, anchored bool) *Node {
+	ctx := &searchContext{
+		anchored:     anchored,
+		nodesByLabel: make(map[string]*Node),
+		states:       make(map[*Node]*searchState),
+		constructed:  make(map[*Node]bool),
+		closureCache: make(map[*nfa.Node][]*nfa.Node),
+	}
+	ctx.start = closure(nfanode, ctx.closureCache)
+	root := ctx.node(&searchState{groups: [][]*nfa.Node{ctx.start}})
+	ctx.constructSearch(root)
+	return root
+}
+
+// node returns the node for the state, constructing it if it doesn't exist yet.
+func (ctx *searchContext) node(st *searchState) *Node {
+	// Earlier groups take precedence over the later ones.
+	seen := make(map[*nfa.Node]bool)
+	for _, n := range st.done {
+		seen[n] = true
+	}
+	var groups [][]*nfa.Node
+	for _, g := range st.groups {
+		var group []*nfa.Node
+		for _, n := range g {
+			if !seen[n] {
+				seen[n] = true
+				group = append(group, n)
+			}
+		}
+		if len(group) == 0 {
+			continue
+		}
+		groups = append(groups, group)
+		if isFinal(group) {
+			// The leftmost match found so far.
+			st.matched = true
+			break
+		}
+	}
+	st.groups = groups
+
+	labels := make([]string, len(groups))
+	for i, g := range groups {
+		labels[i] = labelFromClosure(g)
+	}
+	label := strings.Join(labels, "|") + "|" + strconv.FormatBool(st.matched) + "|" + labelFromClosure(st.done)
+	if n, ok := ctx.nodesByLabel[label]; ok {
+		return n
+	}
+
+	ctx.state++
+	n := &Node{
+		S:     ctx.state,
+		F:     len(groups) > 0 && isFinal(groups[len(groups)-1]),
+		label: label,
+	}
+	ctx.nodesByLabel[label] = n
+	ctx.states[n] = st
+	return n
+}
+
+func (ctx *searchContext) constructSearch(root *Node) {
+	ctx.constructed[root] = true
+	st := ctx.states[root]
+
+	var ranges [][]rune
+	for _, g := range st.groups {
+		for _, n := range g {
+			for _, t := range n.T {
+				if t.R != nil {
+					ranges = append(ranges, t.R)
+				}
+			}
+		}
+	}
+	if !st.matched && !ctx.anchored {
+		// A match is started after any rune.
+		ranges = append(ranges, []rune{0, nfa.RuneLast})
+		for _, n := range ctx.start {
+			for _, t := range n.T {
+				if t.R != nil {
+					ranges = append(ranges, t.R)
+				}
+			}
+		}
+	}
+	if len(ranges) == 0 {
+		return
+	}
+	pairs := runerange.Split(ranges)
+
+	m := make(map[*Node][]rune)
+	var targets []*Node
+	for i := 0; i < len(pairs); i += 2 {
+		rr := pairs[i : i+2]
+		next := &searchState{matched: st.matched}
+		progress := rr[0] >= 0
+		if rr[0] < 0 {
+			next.done = append(next.done, st.done...)
+		}
+		for _, g := range st.groups {
+			var group []*nfa.Node
+			for _, n := range g {
+				waits := false
+				for _, t := range n.T {
+					if t.R != nil && runerange.Contains(t.R, rr) {
+						group = append(group, closure(t.N, ctx.closureCache)...)
+						waits = true
+					}
+				}
+				if rr[0] < 0 && waits {
+					next.done = append(next.done, n)
+					progress = true
+				} else if rr[0] < 0 {
+					// An assertion doesn't consume input.
+					group = append(group, n)
+				}
+			}
+			next.groups = append(next.groups, group)
+		}
+		if rr[0] >= 0 && !st.matched && !ctx.anchored {
+			next.groups = append(next.groups, ctx.start)
+		}
+
+		if !progress {
+			// No state waits for the assertion.
+			continue
+		}
+		node := ctx.node(next)
+		if len(ctx.states[node].groups) == 0 {
+			// No match is possible.
+			continue
+		}
+		if _, ok := m[node]; !ok {
+			targets = append(targets, node)
+		}
+		m[node] = runerange.Sum(m[node], rr)
+	}
+
+	for _, n := range targets {
+		root.T = append(root.T, T{R: m[n], N: n})
+	}
+	sort.Sort(transitionsByRange(root.T))
+
+	for _, n := range targets {
+		if !ctx.constructed[n] {
+			ctx.constructSearch(n)
+		}
+	}
+}
@@ -0,0 +1,425 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package lazydfa provides a runtime which determinizes a non-deterministic finite automaton lazily,
+// as the input is scanned, so that only the states actually visited are ever constructed.
+//
+// The constructed states are kept in a cache of bounded size. When the cache is full, it is flushed;
+// when it is flushed too often during a scan, the scan is run again by an nfa.VM.
+package lazydfa
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/opennota/re2dfa/dfa"
+	"github.com/opennota/re2dfa/nfa"
+	"github.com/opennota/re2dfa/runerange"
+)
+
+// DefaultMaxStates is the size of the state cache if Options.MaxStates is zero.
+const DefaultMaxStates = 10000
+
+// minProgress is the number of bytes per cached state which must be scanned between two flushes of the cache
+// in a scan; otherwise the cache is considered to be thrashing.
+const minProgress = 10
+
+// Options control the matching and the size of the state cache.
+type Options struct {
+	LineTerminators     nfa.LineTerminators // the line terminators of ^ and $ in multi-line mode
+	UnicodeWordBoundary bool                // \b and \B consider Unicode word characters
+	MaxStates           int                 // the maximum number of cached states, DefaultMaxStates if zero
+}
+
+// Stats are the counters of a DFA.
+type Stats struct {
+	States    int // states in the cache
+	Misses    int // states constructed
+	Flushes   int // flushes of the cache
+	Fallbacks int // scans run by the VM because the cache was thrashing
+}
+
+// A DFA is a deterministic finite automaton constructed lazily from an NFA. It is safe for concurrent use;
+// scans are serialized.
+type DFA struct {
+	root *nfa.Node
+	opts Options
+	vm   *nfa.VM
+
+	mu       sync.Mutex
+	closures map[*nfa.Node][]*nfa.Node
+	states   map[string]*state
+	start    [2]*state // the first states of the unanchored and the anchored scans
+	stats    Stats
+
+	// The state of the current scan.
+	flushedAt int // position of the last flush, or -1
+	fallback  bool
+}
+
+// A state is a list of sets of NFA states, the groups, ordered by the position where their matches started,
+// earliest first. The nodes of an earlier group are dropped from the later ones, and the groups after a final
+// one are dropped, as they can't yield the leftmost match.
+type state struct {
+	groups   [][]*nfa.Node
+	anchored bool // no group is started at the next positions
+	final    bool // the last group is final
+	assert   bool // some of the nodes have transitions on assertions or lazy transitions
+
+	expanded map[flags]*edge // the states after following the assertions which hold
+	next     map[rune]*edge  // the states after a rune
+}
+
+// An edge leads to the next state of a scan.
+type edge struct {
+	st   *state
+	from []int // the index of the group of the previous state of each group, or -1 if it starts at the position
+}
+
+// flags are the assertions which hold at a position.
+type flags uint8
+
+const (
+	flagBeginText flags = 1 << iota
+	flagEndText
+	flagBeginLine
+	flagEndLine
+	flagWordBoundary
+)
+
+// New returns a DFA for the NFA. No state is constructed until the first scan. Counted repetitions
+// (see nfa.Options.CountThreshold) are not supported.
+func New(root *nfa.Node, opts Options) *DFA {
+	if opts.MaxStates <= 0 {
+		opts.MaxStates = DefaultMaxStates
+	}
+	return &DFA{
+		root: root,
+		opts: opts,
+		vm: nfa.NewVM(root, nfa.VMOptions{
+			LineTerminators:     opts.LineTerminators,
+			UnicodeWordBoundary: opts.UnicodeWordBoundary,
+			Longest:             true,
+		}),
+		closures: make(map[*nfa.Node][]*nfa.Node),
+		states:   make(map[string]*state),
+	}
+}
+
+// Match returns the end of the longest match at the beginning of s, or -1. Lazy quantifiers are treated
+// as greedy ones.
+func (d *DFA) Match(s string) (end int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, end = d.scan(s, true)
+	return end
+}
+
+// Find returns the leftmost-longest match in s, or -1, -1. The input is scanned once: a group of NFA states
+// is started at every position until a match is found, and the positions where the groups started are
+// carried along the scan.
+func (d *DFA) Find(s string) (start, end int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.scan(s, false)
+}
+
+// Stats returns the counters of the DFA.
+func (d *DFA) Stats() Stats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	st := d.stats
+	st.States = len(d.states)
+	return st
+}
+
+// scan returns the leftmost-longest match in s, or -1, -1. If anchored, the match starts at the beginning of s.
+func (d *DFA) scan(s string, anchored bool) (start, end int) {
+	d.flushedAt = -1
+	d.fallback = false
+	k := 0
+	if anchored {
+		k = 1
+	}
+	if d.start[k] == nil {
+		d.start[k] = d.state([][]*nfa.Node{dfa.Closure(d.root, d.closures)}, anchored, 0).st
+	}
+
+	start, end = -1, -1
+	cur, i := d.start[k], 0
+	starts, buf := []int{0}, []int(nil)
+	for {
+		if d.fallback {
+			// The states are still right, but the cache is thrashing.
+			d.stats.Fallbacks++
+			if anchored {
+				if end := d.vm.Match(s); end >= 0 {
+					return 0, end
+				}
+				return -1, -1
+			}
+			return d.vm.Find(s)
+		}
+		if cur.assert {
+			e := d.expand(cur, d.flags(s, i), i)
+			starts, buf = e.positions(starts, buf, i), starts
+			cur = e.st
+		}
+		if cur.final {
+			if at := starts[len(starts)-1]; start < 0 || at < start || at == start && i > end {
+				start, end = at, i
+			}
+		}
+		r, rlen := utf8.DecodeRuneInString(s[i:])
+		if rlen == 0 {
+			return start, end
+		}
+		e := d.step(cur, r, i)
+		i += rlen
+		starts, buf = e.positions(starts, buf, i), starts
+		cur = e.st
+		if len(cur.groups) == 0 {
+			return start, end
+		}
+	}
+}
+
+// positions returns the start positions of the groups of the next state, reusing buf. The new group starts at i.
+func (e *edge) positions(starts, buf []int, i int) []int {
+	buf = buf[:0]
+	for _, k := range e.from {
+		if k < 0 {
+			buf = append(buf, i)
+		} else {
+			buf = append(buf, starts[k])
+		}
+	}
+	return buf
+}
+
+// expand returns the edge to the state after following the assertions which hold in fl, and the lazy transitions.
+func (d *DFA) expand(st *state, fl flags, i int) *edge {
+	if e, ok := st.expanded[fl]; ok {
+		return e
+	}
+
+	groups := make([][]*nfa.Node, len(st.groups))
+	for k, g := range st.groups {
+		nodes := append([]*nfa.Node(nil), g...)
+		seen := make(map[*nfa.Node]bool, len(nodes))
+		for _, n := range nodes {
+			seen[n] = true
+		}
+		for j := 0; j < len(nodes); j++ {
+			for _, t := range nodes[j].T {
+				if !d.holds(t.R, fl) {
+					continue
+				}
+				for _, n := range dfa.Closure(t.N, d.closures) {
+					if !seen[n] {
+						seen[n] = true
+						nodes = append(nodes, n)
+					}
+				}
+			}
+		}
+		groups[k] = nodes
+	}
+
+	e := d.state(groups, st.anchored, i)
+	if st.expanded == nil {
+		st.expanded = make(map[flags]*edge)
+	}
+	st.expanded[fl] = e
+	return e
+}
+
+// step returns the edge to the state after the rune r. Unless the state is anchored, a new group is started
+// after r.
+func (d *DFA) step(st *state, r rune, i int) *edge {
+	if e, ok := st.next[r]; ok {
+		return e
+	}
+
+	var groups [][]*nfa.Node
+	var from []int
+	for k, g := range st.groups {
+		var closures [][]*nfa.Node
+		for _, n := range g {
+			for _, t := range n.T {
+				if runerange.In(t.R, r) {
+					closures = append(closures, dfa.Closure(t.N, d.closures))
+				}
+			}
+		}
+		if len(closures) > 0 {
+			groups = append(groups, dfa.Union(closures...))
+			from = append(from, k)
+		}
+	}
+	if !st.anchored {
+		groups = append(groups, dfa.Closure(d.root, d.closures))
+		from = append(from, -1)
+	}
+
+	e := d.state(groups, st.anchored, i)
+	e.from = remap(e.from, from)
+	if st.next == nil {
+		st.next = make(map[rune]*edge)
+	}
+	st.next[r] = e
+	return e
+}
+
+// remap returns the indices of the groups of the previous state, given the indices kept among the groups
+// passed to state.
+func remap(kept, from []int) []int {
+	m := make([]int, len(kept))
+	for k, j := range kept {
+		m[k] = from[j]
+	}
+	return m
+}
+
+// state returns an edge to the cached state of the groups, constructing it if needed, with the indices of
+// the groups which were kept: the nodes of the earlier groups are dropped from the later ones, and the groups
+// which are empty or follow a final group are dropped. The cache is flushed when it is full; if it was
+// already flushed in the current scan less than minProgress bytes per cached state ago, the state isn't
+// cached, and the scan falls back to the VM.
+func (d *DFA) state(groups [][]*nfa.Node, anchored bool, i int) *edge {
+	seen := make(map[*nfa.Node]bool)
+	var kept []int
+	var nodes [][]*nfa.Node
+	final := false
+	for k, g := range groups {
+		var group []*nfa.Node
+		for _, n := range g {
+			if !seen[n] {
+				seen[n] = true
+				group = append(group, n)
+			}
+		}
+		if len(group) == 0 {
+			continue
+		}
+		kept = append(kept, k)
+		nodes = append(nodes, group)
+		if final = isFinal(group); final {
+			// No match can start later.
+			anchored = true
+			break
+		}
+	}
+
+	labels := make([]string, len(nodes))
+	for k, g := range nodes {
+		labels[k] = dfa.Label(g)
+	}
+	label := strings.Join(labels, "|") + "|" + strconv.FormatBool(anchored)
+	if st, ok := d.states[label]; ok {
+		return &edge{st: st, from: kept}
+	}
+
+	st := &state{groups: nodes, anchored: anchored, final: final}
+	for _, g := range nodes {
+		for _, n := range g {
+			for _, t := range n.T {
+				if len(t.R) > 0 && t.R[0] < 0 {
+					st.assert = true
+				}
+			}
+		}
+	}
+	d.stats.Misses++
+
+	if len(d.states) >= d.opts.MaxStates {
+		if d.flushedAt >= 0 && i-d.flushedAt < minProgress*d.opts.MaxStates {
+			d.fallback = true
+			return &edge{st: st, from: kept}
+		}
+		d.states = make(map[string]*state)
+		d.start = [2]*state{}
+		d.flushedAt = i
+		d.stats.Flushes++
+	}
+	d.states[label] = st
+	return &edge{st: st, from: kept}
+}
+
+// isFinal reports whether any of the nodes is final.
+func isFinal(nodes []*nfa.Node) bool {
+	for _, n := range nodes {
+		if n.F {
+			return true
+		}
+	}
+	return false
+}
+
+// holds reports whether any of the assertions in rr hold in fl. The lazy transitions always hold.
+func (d *DFA) holds(rr []rune, fl flags) bool {
+	for i := 0; i < len(rr) && rr[i] < 0; i += 2 {
+		switch rr[i] {
+		case nfa.RuneBeginText:
+			if fl&flagBeginText != 0 {
+				return true
+			}
+		case nfa.RuneEndText:
+			if fl&flagEndText != 0 {
+				return true
+			}
+		case nfa.RuneBeginLine:
+			if fl&flagBeginLine != 0 {
+				return true
+			}
+		case nfa.RuneEndLine:
+			if fl&flagEndLine != 0 {
+				return true
+			}
+		case nfa.RuneWordBoundary:
+			if fl&flagWordBoundary != 0 {
+				return true
+			}
+		case nfa.RuneNoWordBoundary:
+			if fl&flagWordBoundary == 0 {
+				return true
+			}
+		case nfa.RuneLazy:
+			return true
+		}
+	}
+	return false
+}
+
+// flags returns the assertions which hold at the position i of s.
+func (d *DFA) flags(s string, i int) flags {
+	var fl flags
+	if i == 0 {
+		fl |= flagBeginText
+	}
+	if i == len(s) {
+		fl |= flagEndText
+	}
+	if d.opts.LineTerminators.BeginLine(s, i) {
+		fl |= flagBeginLine
+	}
+	if d.opts.LineTerminators.EndLine(s, i) {
+		fl |= flagEndLine
+	}
+	if nfa.IsWordBoundary(s, i, d.opts.UnicodeWordBoundary) {
+		fl |= flagWordBoundary
+	}
+	return fl
+}
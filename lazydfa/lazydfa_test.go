@@ -0,0 +1,172 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package lazydfa
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/opennota/re2dfa/nfa"
+)
+
+var patterns = []string{
+	"a+",
+	"abc|abd",
+	"(?i)k",
+	"^$",
+	`\bfoo\b`,
+	`\Bo+\B`,
+	"(?m)^[a-z]+$",
+	`\d{2,3}x?`,
+	"[^a]*",
+	"<!--.*?-->",
+	"a*?b",
+	"x*",
+	"é+|ф",
+	"(a|b)*a(a|b)(a|b)(a|b)",
+	// Cycles of empty transitions not passing through the first state.
+	`(?:$*)+`,
+	`(?:\b*a?)*`,
+	`(?:a?^*)*`,
+	`(?:(?m:^){0,2}?.??$*?)*`,
+}
+
+var inputs = []string{
+	"",
+	"a",
+	"aaab",
+	"abd",
+	"xabcx",
+	"K",
+	"foo bar",
+	"foobar foo",
+	"boooo",
+	"ab\ncd\n",
+	"12345",
+	"<!-- a --> -->",
+	"xxab",
+	"ééф",
+	"\xffa",
+	"abababbbaabbab",
+}
+
+func testAgainstRegexp(t *testing.T, opts Options) {
+	for _, pattern := range patterns {
+		n, err := nfa.New(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d := New(n, opts)
+		anchored := regexp.MustCompile(`^(?:` + pattern + `)`)
+		anchored.Longest()
+		re := regexp.MustCompile(pattern)
+		re.Longest()
+		for _, s := range inputs {
+			want := -1
+			if loc := anchored.FindStringIndex(s); loc != nil {
+				want = loc[1]
+			}
+			if got := d.Match(s); got != want {
+				t.Errorf("%q: Match(%q) = %d, want %d", pattern, s, got, want)
+			}
+
+			wantLoc := []int{-1, -1}
+			if loc := re.FindStringIndex(s); loc != nil {
+				wantLoc = loc
+			}
+			if start, end := d.Find(s); start != wantLoc[0] || end != wantLoc[1] {
+				t.Errorf("%q: Find(%q) = %d, %d, want %v", pattern, s, start, end, wantLoc)
+			}
+		}
+	}
+}
+
+func TestMatchAgainstRegexp(t *testing.T) {
+	testAgainstRegexp(t, Options{})
+}
+
+func TestMatchWithSmallCache(t *testing.T) {
+	testAgainstRegexp(t, Options{MaxStates: 2})
+}
+
+func TestMatchOptions(t *testing.T) {
+	tests := []struct {
+		pattern string
+		opts    Options
+		in      string
+		want    int
+	}{
+		{"(?m)a$", Options{LineTerminators: nfa.LineCR}, "a\rb", 1},
+		{"(?m)a$", Options{LineTerminators: nfa.LineCR}, "a\nb", -1},
+		{`.\b`, Options{}, "фa", 2},
+		{`.\b`, Options{UnicodeWordBoundary: true}, "ф a", 2},
+		{`.\b`, Options{UnicodeWordBoundary: true}, "фa", -1},
+	}
+	for _, tst := range tests {
+		n, err := nfa.New(tst.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := New(n, tst.opts).Match(tst.in); got != tst.want {
+			t.Errorf("%q %+v: Match(%q) = %d, want %d", tst.pattern, tst.opts, tst.in, got, tst.want)
+		}
+	}
+}
+
+func TestCache(t *testing.T) {
+	n, err := nfa.New("(a|b)*a(a|b)(a|b)(a|b)(a|b)(a|b)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := strings.Repeat("abaabbbaababbbbaaabbaabab", 100) + "c"
+	want := strings.LastIndex(s[:len(s)-6], "a") + 6 // the last a followed by 5 runes before c
+
+	d := New(n, Options{})
+	if got := d.Match(s); got != want {
+		t.Fatalf("Match = %d, want %d", got, want)
+	}
+	st := d.Stats()
+	if st.Flushes != 0 || st.Fallbacks != 0 || st.States == 0 {
+		t.Errorf("unbounded cache: %+v", st)
+	}
+
+	d = New(n, Options{MaxStates: 8})
+	if got := d.Match(s); got != want {
+		t.Fatalf("Match with a small cache = %d, want %d", got, want)
+	}
+	st = d.Stats()
+	if st.Flushes == 0 || st.Fallbacks != 1 || st.States > 8 {
+		t.Errorf("small cache: %+v", st)
+	}
+}
+
+func TestFindScansOnce(t *testing.T) {
+	n, err := nfa.New("a+b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Restarting the scan at every position would take quadratic time.
+	s := strings.Repeat("a", 100000)
+	d := New(n, Options{})
+	if start, end := d.Find(s); start != -1 || end != -1 {
+		t.Errorf("Find = %d, %d, want -1, -1", start, end)
+	}
+	if start, end := d.Find(s + "b"); start != 0 || end != len(s)+1 {
+		t.Errorf("Find = %d, %d, want 0, %d", start, end, len(s)+1)
+	}
+	if st := d.Stats(); st.Misses > 10 {
+		t.Errorf("%d states constructed", st.Misses)
+	}
+}
@@ -0,0 +1,31 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package matcher defines the interface satisfied by both *regexp.Regexp and the types generated by re2dfa
+// in codegen.ModeMatcher, so that one can replace the other without changing the code using it.
+package matcher
+
+import "regexp"
+
+// Matcher is the subset of the methods of *regexp.Regexp implemented by the generated types, which report
+// the same leftmost-first matches (see codegen.Func).
+type Matcher interface {
+	MatchString(s string) bool
+	Match(b []byte) bool
+	FindStringIndex(s string) []int
+	FindIndex(b []byte) []int
+	String() string
+	LiteralPrefix() (prefix string, complete bool)
+}
+
+var _ Matcher = (*regexp.Regexp)(nil)
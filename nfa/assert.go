@@ -0,0 +1,114 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nfa
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Assert reports whether the assertion of the pseudo-rune r holds at the position i of s, the line
+// terminators being lt. Unless unicodeWord is set, \b and \B consider only [0-9A-Za-z_] word characters,
+// as in the regexp package. RuneLazy always holds.
+func Assert(r rune, s string, i int, lt LineTerminators, unicodeWord bool) bool {
+	switch r {
+	case RuneBeginText:
+		return i == 0
+	case RuneEndText:
+		return i == len(s)
+	case RuneBeginLine:
+		return lt.BeginLine(s, i)
+	case RuneEndLine:
+		return lt.EndLine(s, i)
+	case RuneWordBoundary:
+		return IsWordBoundary(s, i, unicodeWord)
+	case RuneNoWordBoundary:
+		return !IsWordBoundary(s, i, unicodeWord)
+	case RuneLazy:
+		return true
+	}
+	return false
+}
+
+// BeginLine reports whether a line begins at the position i of s.
+func (lt LineTerminators) BeginLine(s string, i int) bool {
+	if lt == 0 {
+		lt = LineLF
+	}
+	switch {
+	case i == 0:
+		return true
+	case lt&LineLF != 0 && s[i-1] == '\n':
+		return true
+	case lt&LineLF == 0 && lt&LineCRLF != 0 && i >= 2 && s[i-2] == '\r' && s[i-1] == '\n':
+		return true
+	case lt&LineCR != 0 && s[i-1] == '\r':
+		// Not between \r and \n.
+		return lt&LineCRLF == 0 || i == len(s) || s[i] != '\n'
+	case lt&LineUnicode != 0:
+		// U+0085 is encoded as C2 85, U+2028 and U+2029 as E2 80 A8 and E2 80 A9.
+		return i >= 2 && s[i-2] == 0xc2 && s[i-1] == 0x85 ||
+			i >= 3 && s[i-3] == 0xe2 && s[i-2] == 0x80 && (s[i-1] == 0xa8 || s[i-1] == 0xa9)
+	}
+	return false
+}
+
+// EndLine reports whether a line ends at the position i of s.
+func (lt LineTerminators) EndLine(s string, i int) bool {
+	if lt == 0 {
+		lt = LineLF
+	}
+	switch {
+	case i == len(s):
+		return true
+	case lt&LineLF != 0 && s[i] == '\n':
+		// Not between \r and \n.
+		return lt&LineCRLF == 0 || i == 0 || s[i-1] != '\r'
+	case lt&LineCR != 0 && s[i] == '\r':
+		return true
+	case lt&LineCR == 0 && lt&LineCRLF != 0 && s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n':
+		return true
+	case lt&LineUnicode != 0:
+		return i+1 < len(s) && s[i] == 0xc2 && s[i+1] == 0x85 ||
+			i+2 < len(s) && s[i] == 0xe2 && s[i+1] == 0x80 && (s[i+2] == 0xa8 || s[i+2] == 0xa9)
+	}
+	return false
+}
+
+// IsWordBoundary reports whether there is a word boundary at the position i of s. Unless unicodeWord is set,
+// the bytes on both sides are checked for being [0-9A-Za-z_]; otherwise the runes are checked for being
+// letters, marks, digits or connector punctuation.
+func IsWordBoundary(s string, i int, unicodeWord bool) bool {
+	if !unicodeWord {
+		return (i > 0 && isWordChar(s[i-1])) != (i < len(s) && isWordChar(s[i]))
+	}
+	before, after := false, false
+	if i > 0 {
+		r, _ := utf8.DecodeLastRuneInString(s[:i])
+		before = isUnicodeWordChar(r)
+	}
+	if i < len(s) {
+		r, _ := utf8.DecodeRuneInString(s[i:])
+		after = isUnicodeWordChar(r)
+	}
+	return before != after
+}
+
+func isWordChar(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_'
+}
+
+func isUnicodeWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.In(r, unicode.Mark, unicode.Pc)
+}
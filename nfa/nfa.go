@@ -15,7 +15,10 @@
 package nfa
 
 import (
+	"errors"
+	"fmt"
 	"regexp/syntax"
+	"strings"
 
 	"github.com/opennota/re2dfa/runerange"
 )
@@ -35,9 +38,19 @@ const (
 )
 
 type Node struct {
-	S int  // state
-	F bool // final?
-	T []T  // transitions
+	S int      // state
+	F bool     // final?
+	T []T      // transitions
+	C *Counter // counter of a bounded repetition, or nil
+}
+
+// A Counter makes a node stand for a bounded repetition of a single character, such as [a-z]{1,200},
+// instead of a chain of copies of the character (see Options.CountThreshold). The node loops on the runes R,
+// counting them, and its transitions, all empty, can only be taken after Min runes have been counted. No more
+// than Max runes are counted.
+type Counter struct {
+	R        []rune // rune ranges
+	Min, Max int
 }
 
 type T struct {
@@ -59,24 +72,475 @@ func (n *Node) copy() *Node {
 		S: n.S,
 		F: n.F,
 		T: make([]T, len(n.T)),
+		C: n.C,
 	}
 	copy(nn.T, n.T)
 	return &nn
 }
 
+// An Error describes a failure to construct an automaton from a pattern.
+type Error struct {
+	Stage   string    // "parse" or "construct"
+	Pattern string    // the pattern, if known
+	Expr    string    // the offending part of the pattern
+	Offset  int       // byte offset of Expr in Pattern, or -1 if unknown (see Options.Locate)
+	Op      syntax.Op // the offending operation, or 0
+	Err     error
+}
+
+func (e *Error) Error() string {
+	msg := e.Stage + ": " + e.Err.Error()
+	if e.Op != 0 {
+		msg += " " + opString(e.Op)
+	}
+	if e.Stage != "parse" && e.Expr != "" {
+		msg += ": `" + e.Expr + "`"
+	}
+	if e.Offset >= 0 {
+		msg += fmt.Sprintf(" at offset %d", e.Offset)
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// errUnsupportedOp is the cause of an Error for an operation the construction doesn't support.
+var errUnsupportedOp = errors.New("unsupported op")
+
+// Locate sets the pattern of err, if it is an *Error whose pattern isn't set yet, and the offset of its Expr
+// in the pattern parsed with the options. A syntax error is reported for Expr at the end of the shortest prefix
+// of the pattern failing with the same error, which is found by parsing the prefixes. The offset of an error
+// of the construct stage stays -1: Expr is printed from the parsed expression, and the expressions parsed from
+// a pattern can all be constructed.
+func (o Options) Locate(err error, pattern string) error {
+	var e *Error
+	if !errors.As(err, &e) || e.Pattern != "" {
+		return err
+	}
+	e.Pattern = pattern
+	e.Offset = -1
+
+	var se *syntax.Error
+	if !errors.As(e.Err, &se) || se.Expr == "" {
+		return err
+	}
+	for k := len(se.Expr); k <= len(pattern); k++ {
+		if !strings.HasSuffix(pattern[:k], se.Expr) {
+			continue
+		}
+		var pe *syntax.Error
+		if _, err := syntax.Parse(pattern[:k], o.Flags()); errors.As(err, &pe) && pe.Code == se.Code && pe.Expr == se.Expr {
+			e.Offset = k - len(se.Expr)
+			break
+		}
+	}
+	return err
+}
+
+// LineTerminators is a set of the sequences ending a line. The zero value means LineLF.
+type LineTerminators uint8
+
+const (
+	LineLF      LineTerminators = 1 << iota // \n
+	LineCR                                  // \r
+	LineCRLF                                // \r\n, a single terminator
+	LineUnicode                             // U+0085 (NEL), U+2028 (LS) and U+2029 (PS)
+)
+
+// Runes returns the rune ranges of the characters occurring in the line terminators.
+func (lt LineTerminators) Runes() []rune {
+	if lt == 0 {
+		lt = LineLF
+	}
+	var rr []rune
+	if lt&(LineLF|LineCRLF) != 0 {
+		rr = append(rr, '\n', '\n')
+	}
+	if lt&(LineCR|LineCRLF) != 0 {
+		rr = append(rr, '\r', '\r')
+	}
+	if lt&LineUnicode != 0 {
+		rr = append(rr, 0x85, 0x85, 0x2028, 0x2029)
+	}
+	return rr
+}
+
+// Options control the parsing of a pattern. The zero value selects the syntax of the regexp package.
+type Options struct {
+	POSIX      bool // POSIX ERE syntax, as in regexp.CompilePOSIX; ^ and $ match at line boundaries
+	Literal    bool // the pattern is a literal string
+	IgnoreCase bool // case-insensitive matching, like (?i)
+	DotNL      bool // . matches \n, like (?s)
+	Ungreedy   bool // the quantifiers are lazy and the lazy ones are greedy, like (?U)
+
+	// Without (?s), . doesn't match the characters occurring in the line terminators (e.g. neither \r
+	// nor \n for LineCRLF). The line terminators must also be passed to the code generator, which
+	// implements the (?m)^ and (?m)$ assertions.
+	LineTerminators LineTerminators
+
+	// The greedy repetitions of a single character, such as [a-z]{1,200} or .{64}, which would be unrolled
+	// into at least CountThreshold copies are constructed as a counter instead (see Counter), which keeps
+	// the automata small. Zero disables the counting, and so do lazy quantifiers in the pattern.
+	CountThreshold int
+}
+
+// Flags returns the regexp/syntax flags corresponding to the options.
+func (o Options) Flags() syntax.Flags {
+	flags := syntax.Perl
+	if o.POSIX {
+		flags = syntax.POSIX
+	}
+	if o.Literal {
+		flags |= syntax.Literal
+	}
+	if o.IgnoreCase {
+		flags |= syntax.FoldCase
+	}
+	if o.DotNL {
+		flags |= syntax.DotNL
+	}
+	if o.Ungreedy {
+		flags |= syntax.NonGreedy
+	}
+	return flags
+}
+
+// Parse parses and simplifies the pattern. Syntax errors are returned as an *Error.
+func (o Options) Parse(pattern string) (*syntax.Regexp, error) {
+	r, err := syntax.Parse(pattern, o.Flags())
+	if err != nil {
+		return nil, o.parseError(pattern, err)
+	}
+	if o.CountThreshold > 0 && !hasNonGreedy(r) {
+		r = simplify(r, o.CountThreshold)
+	} else {
+		r = r.Simplify()
+	}
+	if lt := o.LineTerminators; lt != 0 && lt != LineLF {
+		r = anyCharNotIn(r, runerange.Invert(lt.Runes()))
+	}
+	return r, nil
+}
+
+// hasNonGreedy reports whether r has lazy quantifiers.
+func hasNonGreedy(r *syntax.Regexp) bool {
+	switch r.Op {
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		if r.Flags&syntax.NonGreedy != 0 {
+			return true
+		}
+	}
+	for _, sub := range r.Sub {
+		if hasNonGreedy(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// FirstIsLongest reports whether the leftmost-first matches of the parsed, unsimplified expression r are
+// its leftmost-longest ones, so that an automaton finding the latter, such as a counting one, finds the matches
+// of the regexp package. This is the case of a sequence of strings of single characters and assertions, some
+// of them repeated by greedy quantifiers, where taking as many characters as possible at each repetition in
+// turn gives the longest match: the repeated strings all have the same length, which divides the length
+// of the strings between them.
+func FirstIsLongest(r *syntax.Regexp) bool {
+	repeated := 0 // the length of the repeated strings
+	between := -1 // the length of the strings since the last repeated one, or -1 before the first
+	for _, item := range sequence(r) {
+		if w, ok := fixedWidth(item); ok {
+			if between >= 0 {
+				between += w
+			}
+			continue
+		}
+		switch item.Op {
+		case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		default:
+			return false
+		}
+		w, ok := fixedWidth(item.Sub[0])
+		if !ok || item.Flags&syntax.NonGreedy != 0 {
+			return false
+		}
+		if w == 0 {
+			continue
+		}
+		if repeated == 0 {
+			repeated = w
+		} else if w != repeated || between%repeated != 0 {
+			return false
+		}
+		between = 0
+	}
+	return true
+}
+
+// sequence returns the expressions concatenated by r, without the captures.
+func sequence(r *syntax.Regexp) []*syntax.Regexp {
+	switch r.Op {
+	case syntax.OpCapture:
+		return sequence(r.Sub[0])
+	case syntax.OpConcat:
+		var items []*syntax.Regexp
+		for _, sub := range r.Sub {
+			items = append(items, sequence(sub)...)
+		}
+		return items
+	}
+	return []*syntax.Regexp{r}
+}
+
+// fixedWidth returns the number of characters of the strings matched by r if r is a string of single characters
+// and assertions.
+func fixedWidth(r *syntax.Regexp) (int, bool) {
+	switch r.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return 0, true
+	case syntax.OpLiteral:
+		return len(r.Rune), true
+	case syntax.OpCharClass, syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return 1, true
+	case syntax.OpCapture:
+		return fixedWidth(r.Sub[0])
+	case syntax.OpConcat:
+		width := 0
+		for _, sub := range r.Sub {
+			w, ok := fixedWidth(sub)
+			if !ok {
+				return 0, false
+			}
+			width += w
+		}
+		return width, true
+	case syntax.OpRepeat:
+		if r.Min != r.Max {
+			return 0, false
+		}
+		w, ok := fixedWidth(r.Sub[0])
+		return r.Min * w, ok
+	}
+	return 0, false
+}
+
+// countable reports whether r is a greedy repetition of a single character which Simplify would unroll
+// into at least threshold copies.
+func countable(r *syntax.Regexp, threshold int) bool {
+	if r.Op != syntax.OpRepeat || r.Flags&syntax.NonGreedy != 0 || singleCharRunes(r.Sub[0]) == nil {
+		return false
+	}
+	n := r.Max
+	if n < 0 {
+		n = r.Min
+	}
+	return n >= threshold
+}
+
+func containsCountable(r *syntax.Regexp, threshold int) bool {
+	if countable(r, threshold) {
+		return true
+	}
+	for _, sub := range r.Sub {
+		if containsCountable(sub, threshold) {
+			return true
+		}
+	}
+	return false
+}
+
+// singleCharRunes returns the rune ranges matched by r if it matches a single character, or nil otherwise.
+func singleCharRunes(r *syntax.Regexp) []rune {
+	switch r.Op {
+	case syntax.OpLiteral:
+		if len(r.Rune) != 1 {
+			return nil
+		}
+		if r.Flags&syntax.FoldCase != 0 {
+			return runerange.Fold([]rune{r.Rune[0], r.Rune[0]})
+		}
+		return []rune{r.Rune[0], r.Rune[0]}
+	case syntax.OpCharClass:
+		if len(r.Rune) == 0 {
+			return nil
+		}
+		if r.Flags&syntax.FoldCase != 0 {
+			return runerange.Fold(r.Rune)
+		}
+		return r.Rune
+	case syntax.OpAnyCharNotNL:
+		return []rune{0, 9, 11, RuneLast}
+	case syntax.OpAnyChar:
+		return []rune{0, RuneLast}
+	}
+	return nil
+}
+
+// flagCount marks the repetitions kept by simplify, which NewFromRegexp constructs as counters. The other
+// repetitions, such as the ones of an expression returned by syntax.Parse, are unrolled.
+const flagCount syntax.Flags = 1 << 15
+
+// simplify is like r.Simplify, but keeps the repetitions reported by countable as OpRepeat marked with
+// flagCount, to be constructed as counters; x{n,} is kept as x{n}x*.
+func simplify(r *syntax.Regexp, threshold int) *syntax.Regexp {
+	if countable(r, threshold) {
+		rr := *r
+		rr.Flags |= flagCount
+		if r.Max >= 0 {
+			return &rr
+		}
+		rr.Max = r.Min
+		star := &syntax.Regexp{Op: syntax.OpStar, Flags: r.Flags, Sub: []*syntax.Regexp{r.Sub[0]}}
+		return &syntax.Regexp{Op: syntax.OpConcat, Flags: r.Flags, Sub: []*syntax.Regexp{&rr, star}}
+	}
+	if !containsCountable(r, threshold) {
+		return r.Simplify()
+	}
+
+	rr := *r
+	rr.Sub = make([]*syntax.Regexp, len(r.Sub))
+	for i, sub := range r.Sub {
+		rr.Sub[i] = simplify(sub, threshold)
+	}
+	if r.Op == syntax.OpRepeat {
+		return unroll(&rr)
+	}
+	return &rr
+}
+
+// unroll expands the repetition r of a simplified expression x as Simplify does: x{n,m} becomes n copies
+// of x followed by m-n nested optional copies, and x{n,} becomes n-1 copies of x followed by x+.
+func unroll(r *syntax.Regexp) *syntax.Regexp {
+	x := r.Sub[0]
+	op := func(op syntax.Op, subs ...*syntax.Regexp) *syntax.Regexp {
+		return &syntax.Regexp{Op: op, Flags: r.Flags, Sub: subs}
+	}
+	if r.Max < 0 {
+		if r.Min == 0 {
+			return op(syntax.OpStar, x)
+		}
+		var subs []*syntax.Regexp
+		for i := 1; i < r.Min; i++ {
+			subs = append(subs, x)
+		}
+		return op(syntax.OpConcat, append(subs, op(syntax.OpPlus, x))...)
+	}
+	if r.Max == 0 {
+		return &syntax.Regexp{Op: syntax.OpEmptyMatch}
+	}
+
+	var subs []*syntax.Regexp
+	for i := 0; i < r.Min; i++ {
+		subs = append(subs, x)
+	}
+	var suffix *syntax.Regexp
+	for i := r.Min; i < r.Max; i++ {
+		if suffix == nil {
+			suffix = op(syntax.OpQuest, x)
+		} else {
+			suffix = op(syntax.OpQuest, op(syntax.OpConcat, x, suffix))
+		}
+	}
+	if suffix != nil {
+		subs = append(subs, suffix)
+	}
+	if len(subs) == 1 {
+		return subs[0]
+	}
+	return op(syntax.OpConcat, subs...)
+}
+
+// anyCharNotIn returns a copy of r with . (without (?s)) replaced by the character class cc.
+func anyCharNotIn(r *syntax.Regexp, cc []rune) *syntax.Regexp {
+	rr := *r
+	if r.Op == syntax.OpAnyCharNotNL {
+		rr.Op = syntax.OpCharClass
+		rr.Rune = cc
+	}
+	if len(r.Sub) > 0 {
+		rr.Sub = make([]*syntax.Regexp, len(r.Sub))
+		for i, sub := range r.Sub {
+			rr.Sub[i] = anyCharNotIn(sub, cc)
+		}
+	}
+	return &rr
+}
+
+// Pattern returns a pattern in the syntax of the regexp package matching the same strings as the pattern
+// parsed with the options, so that the automaton can be checked against the regexp package. The pattern is
+// returned as is unless the syntax flags differ from the ones of the regexp package.
+func (o Options) Pattern(pattern string) (string, error) {
+	if o.Flags() == syntax.Perl || pattern == "" {
+		return pattern, nil
+	}
+	r, err := syntax.Parse(pattern, o.Flags())
+	if err != nil {
+		return "", o.parseError(pattern, err)
+	}
+	return r.String(), nil
+}
+
+// parseError wraps an error returned by syntax.Parse for the pattern parsed with the options.
+func (o Options) parseError(pattern string, err error) error {
+	e := &Error{Stage: "parse", Err: err}
+	var se *syntax.Error
+	if errors.As(err, &se) {
+		e.Expr = se.Expr
+	}
+	return o.Locate(e, pattern)
+}
+
+// New returns an automaton for the pattern parsed with the default options.
 func New(pattern string) (*Node, error) {
-	r, err := syntax.Parse(pattern, syntax.Perl)
+	r, err := Options{}.Parse(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := NewFromRegexp(r)
+	return n, Options{}.Locate(err, pattern)
+}
+
+// NewGreedy returns an automaton for the pattern with the lazy quantifiers made greedy. It matches
+// the same strings as the pattern, so it can be used where only the fact of the match matters.
+func NewGreedy(pattern string) (*Node, error) {
+	r, err := Options{}.Parse(pattern)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewFromRegexp(r.Simplify()), nil
+	n, err := NewGreedyFromRegexp(r)
+	return n, Options{}.Locate(err, pattern)
+}
+
+// NewGreedyFromRegexp returns an automaton for r with the lazy quantifiers made greedy.
+func NewGreedyFromRegexp(r *syntax.Regexp) (*Node, error) {
+	return NewFromRegexp(greedy(r))
+}
+
+// greedy returns a copy of r with the NonGreedy flag cleared.
+func greedy(r *syntax.Regexp) *syntax.Regexp {
+	rr := *r
+	rr.Flags &^= syntax.NonGreedy
+	if len(r.Sub) > 0 {
+		rr.Sub = make([]*syntax.Regexp, len(r.Sub))
+		for i, sub := range r.Sub {
+			rr.Sub[i] = greedy(sub)
+		}
+	}
+	return &rr
 }
 
-func NewFromRegexp(r *syntax.Regexp) *Node {
-	begin, end := recursiveNewFromRegexp(r, &context{})
+// NewFromRegexp returns an automaton for r. Operations which can't be converted are reported as an *Error
+// in the construct stage, without a position in the pattern (see Options.Locate). Only the repetitions kept
+// by Options.Parse with a CountThreshold are constructed as counters.
+func NewFromRegexp(r *syntax.Regexp) (*Node, error) {
+	begin, end, err := recursiveNewFromRegexp(r, &context{})
+	if err != nil {
+		return nil, err
+	}
 	end.F = true
-	return begin
+	return begin, nil
 }
 
 func opString(op syntax.Op) string {
@@ -123,7 +587,56 @@ func opString(op syntax.Op) string {
 	return "OpUnknown"
 }
 
-func recursiveNewFromRegexp(r *syntax.Regexp, ctx *context) (begin *Node, end *Node) {
+// NewReverse returns an automaton matching the reversed strings matched by the pattern.
+func NewReverse(pattern string) (*Node, error) {
+	r, err := Options{}.Parse(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := NewReverseFromRegexp(r)
+	return n, Options{}.Locate(err, pattern)
+}
+
+// NewReverseFromRegexp returns an automaton matching the reversed strings matched by r.
+// The beginning and end assertions are swapped, and the lazy quantifiers are made greedy.
+func NewReverseFromRegexp(r *syntax.Regexp) (*Node, error) {
+	return NewFromRegexp(greedy(reverse(r)))
+}
+
+// reverse returns a copy of r matching the reversed strings.
+func reverse(r *syntax.Regexp) *syntax.Regexp {
+	rr := *r
+	switch r.Op {
+	case syntax.OpLiteral:
+		rr.Rune = make([]rune, len(r.Rune))
+		for i, c := range r.Rune {
+			rr.Rune[len(r.Rune)-1-i] = c
+		}
+	case syntax.OpBeginLine:
+		rr.Op = syntax.OpEndLine
+	case syntax.OpEndLine:
+		rr.Op = syntax.OpBeginLine
+	case syntax.OpBeginText:
+		rr.Op = syntax.OpEndText
+	case syntax.OpEndText:
+		rr.Op = syntax.OpBeginText
+	}
+	if len(r.Sub) > 0 {
+		rr.Sub = make([]*syntax.Regexp, len(r.Sub))
+		for i, sub := range r.Sub {
+			rr.Sub[i] = reverse(sub)
+		}
+		if r.Op == syntax.OpConcat {
+			for i, j := 0, len(rr.Sub)-1; i < j; i, j = i+1, j-1 {
+				rr.Sub[i], rr.Sub[j] = rr.Sub[j], rr.Sub[i]
+			}
+		}
+	}
+	return &rr
+}
+
+func recursiveNewFromRegexp(r *syntax.Regexp, ctx *context) (begin *Node, end *Node, err error) {
 	caseInsensitive := r.Flags&syntax.FoldCase != 0
 	nonGreedy := r.Flags&syntax.NonGreedy != 0
 
@@ -204,7 +717,10 @@ func recursiveNewFromRegexp(r *syntax.Regexp, ctx *context) (begin *Node, end *N
 		}
 		begin = ctx.node()
 		end = ctx.node()
-		b, e := recursiveNewFromRegexp(r.Sub[0], ctx)
+		b, e, err := recursiveNewFromRegexp(r.Sub[0], ctx)
+		if err != nil {
+			return nil, nil, err
+		}
 		begin.T = append(begin.T, T{R: lazy, N: b})
 		begin.T = append(begin.T, T{N: end})
 		e.T = append(e.T, T{R: lazy, N: b})
@@ -217,7 +733,10 @@ func recursiveNewFromRegexp(r *syntax.Regexp, ctx *context) (begin *Node, end *N
 		}
 		begin = ctx.node()
 		end = ctx.node()
-		b, e := recursiveNewFromRegexp(r.Sub[0], ctx)
+		b, e, err := recursiveNewFromRegexp(r.Sub[0], ctx)
+		if err != nil {
+			return nil, nil, err
+		}
 		begin.T = append(begin.T, T{N: b})
 		e.T = append(e.T, T{R: lazy, N: b})
 		e.T = append(e.T, T{N: end})
@@ -229,13 +748,28 @@ func recursiveNewFromRegexp(r *syntax.Regexp, ctx *context) (begin *Node, end *N
 		}
 		begin = ctx.node()
 		end = ctx.node()
-		b, e := recursiveNewFromRegexp(r.Sub[0], ctx)
+		b, e, err := recursiveNewFromRegexp(r.Sub[0], ctx)
+		if err != nil {
+			return nil, nil, err
+		}
 		begin.T = append(begin.T, T{R: lazy, N: b})
 		begin.T = append(begin.T, T{N: end})
 		e.T = append(e.T, T{N: end})
 
 	case syntax.OpRepeat:
-		toRepeat, e := recursiveNewFromRegexp(r.Sub[0], ctx)
+		if rr := singleCharRunes(r.Sub[0]); rr != nil && r.Flags&flagCount != 0 && r.Max >= r.Min && !nonGreedy {
+			// A repetition kept by Options.Parse to be counted.
+			begin = ctx.node()
+			end = ctx.node()
+			begin.C = &Counter{R: rr, Min: r.Min, Max: r.Max}
+			begin.T = append(begin.T, T{N: end})
+			break
+		}
+
+		toRepeat, e, err := recursiveNewFromRegexp(r.Sub[0], ctx)
+		if err != nil {
+			return nil, nil, err
+		}
 
 		var prev *Node
 		for i := 0; i < r.Min; i++ {
@@ -265,7 +799,10 @@ func recursiveNewFromRegexp(r *syntax.Regexp, ctx *context) (begin *Node, end *N
 		var cur *Node
 		for _, r := range r.Sub {
 			var b *Node
-			b, end = recursiveNewFromRegexp(r, ctx)
+			b, end, err = recursiveNewFromRegexp(r, ctx)
+			if err != nil {
+				return nil, nil, err
+			}
 			if begin == nil {
 				begin = b
 			}
@@ -279,13 +816,16 @@ func recursiveNewFromRegexp(r *syntax.Regexp, ctx *context) (begin *Node, end *N
 		begin = ctx.node()
 		end = ctx.node()
 		for _, r := range r.Sub {
-			b, e := recursiveNewFromRegexp(r, ctx)
+			b, e, err := recursiveNewFromRegexp(r, ctx)
+			if err != nil {
+				return nil, nil, err
+			}
 			begin.T = append(begin.T, T{N: b})
 			e.T = append(e.T, T{N: end})
 		}
 
 	default:
-		panic("unsupported op: " + opString(r.Op))
+		return nil, nil, &Error{Stage: "construct", Expr: r.String(), Offset: -1, Op: r.Op, Err: errUnsupportedOp}
 	}
 
 	return
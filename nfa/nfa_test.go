@@ -0,0 +1,162 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nfa
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestFirstIsLongest(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		want    bool
+	}{
+		{"abc", true},
+		{`^\d{4}-\d{2}-\d{2}$`, true},
+		{`[a-z]{1,100}\b`, true},
+		{"(a)*(b?)", true},
+		{"a|ab", false},
+		{"a*?b", false},
+		{"a*(?:ab)?", false},
+		{"(?:[0-9a-f]{2})+", true},
+		{"(?:ab)*cd(?:ab)?", true},
+		{"(?:ab)*c", true},
+		{"a*(?:ab)*", false},
+		{"a*(?:ab)?", false},
+		{"(?:ab)*(?:abc)?", false},
+		{"(?:a|bc)+", false},
+	} {
+		r, err := syntax.Parse(tc.pattern, syntax.Perl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := FirstIsLongest(r); got != tc.want {
+			t.Errorf("FirstIsLongest(%q) = %v, want %v", tc.pattern, got, tc.want)
+		}
+	}
+
+	items := []string{"a", "b", "[ab]", "[^a]", ".", `\b`, `\B`, "^", "$", "(?m:^)", "(?m:$)"}
+	pairs := []string{"(?:ab)", "(?:[ab]b)", "(?:..)", `(?:a\b.)`, "(?:a$b)", `\b`, "^", "a", "(?:aba)"}
+	quants := []string{"", "?", "*", "+", "{0,2}", "{1,3}", "{2}"}
+	rnd := rand.New(rand.NewSource(1))
+	for p := 0; p < 10000; p++ {
+		elems := items
+		if p%2 == 1 {
+			elems = pairs
+		}
+		pattern := ""
+		for i := 1 + rnd.Intn(5); i > 0; i-- {
+			pattern += elems[rnd.Intn(len(elems))] + quants[rnd.Intn(len(quants))]
+		}
+		r, err := syntax.Parse(pattern, syntax.Perl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !FirstIsLongest(r) {
+			continue
+		}
+		first := regexp.MustCompile(pattern)
+		longest := regexp.MustCompile(pattern)
+		longest.Longest()
+		for i := 0; i < 10; i++ {
+			var s []byte
+			for j := rnd.Intn(8); j > 0; j-- {
+				s = append(s, "ab \n"[rnd.Intn(4)])
+			}
+			got, want := first.FindAllIndex(s, -1), longest.FindAllIndex(s, -1)
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Fatalf("%q on %q: leftmost-first %v, leftmost-longest %v", pattern, s, got, want)
+			}
+		}
+	}
+}
+
+func TestCountOnlyParsed(t *testing.T) {
+	for _, pattern := range []string{"[a-z]{1,100}", "a{50}", "x.{3,9}y", `\d{8,}`} {
+		r, err := syntax.Parse(pattern, syntax.Perl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n, err := NewFromRegexp(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if counts(n, make(map[*Node]bool)) {
+			t.Errorf("%q: the repetition of the expression returned by syntax.Parse is counted", pattern)
+		}
+
+		r, err = Options{CountThreshold: 2}.Parse(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n, err = NewFromRegexp(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !counts(n, make(map[*Node]bool)) {
+			t.Errorf("%q: the repetition kept by Options.Parse isn't counted", pattern)
+		}
+	}
+}
+
+func TestLocate(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		opts    Options
+		expr    string
+		offset  int
+	}{
+		{`a(b`, Options{}, "a(b", 0},
+		{`ab\q`, Options{}, `\q`, 2},
+		{`x{2,1}`, Options{}, "{2,1}", 1},
+		{`\{2,1}x{2,1}`, Options{}, "{2,1}", 7},
+		{`a(*)`, Options{}, "*", 2},
+		{`[a]*+`, Options{}, "*+", 3},
+		{`ab)`, Options{}, "ab)", 0},
+		{`(?i)[z-a]`, Options{}, "z-a", 5},
+		{`a\pX`, Options{}, `\pX`, 1},
+		{`a(?P<n!>b)`, Options{}, "(?P<n!>", 1},
+		{`\d`, Options{POSIX: true}, `\d`, 0},
+	} {
+		_, err := tc.opts.Parse(tc.pattern)
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Errorf("%q: want an *Error, got %v", tc.pattern, err)
+			continue
+		}
+		if e.Stage != "parse" || e.Expr != tc.expr || e.Offset != tc.offset || e.Pattern != tc.pattern {
+			t.Errorf("%q: stage %q, expr %q, offset %d, pattern %q, want parse, %q, %d", tc.pattern, e.Stage, e.Expr, e.Offset, e.Pattern, tc.expr, tc.offset)
+		}
+	}
+
+	// The construction of a parsed pattern doesn't fail, and the expressions built by hand have no position.
+	r := &syntax.Regexp{Op: syntax.OpConcat, Sub: []*syntax.Regexp{
+		{Op: syntax.OpLiteral, Rune: []rune("ab")},
+		{Op: syntax.OpNoMatch},
+	}}
+	_, err := NewFromRegexp(r)
+	err = Options{}.Locate(err, "ab")
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatalf("want an *Error, got %v", err)
+	}
+	if e.Stage != "construct" || e.Offset != -1 || e.Pattern == "" {
+		t.Errorf("construct: stage %q, offset %d, pattern %q, want construct, -1 and the pattern", e.Stage, e.Offset, e.Pattern)
+	}
+}
@@ -0,0 +1,221 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nfa
+
+import (
+	"unicode/utf8"
+
+	"github.com/opennota/re2dfa/runerange"
+)
+
+// VMOptions control the matching of a VM.
+type VMOptions struct {
+	LineTerminators     LineTerminators // the line terminators of ^ and $ in multi-line mode
+	UnicodeWordBoundary bool            // \b and \B consider Unicode word characters
+	Longest             bool            // leftmost-longest instead of leftmost-first matches
+}
+
+// A VM executes an automaton directly, without determinizing it, by running all of its threads in lockstep
+// (a Pike VM). The threads are ordered by priority: the transitions of a state are followed in order,
+// except the lazy transitions, which are followed last. Unless VMOptions.Longest is set, the threads
+// with a lower priority than a matching thread are cut, as in the regexp package.
+//
+// A VM runs in time linear in the length of the input. It is slower than the generated code, but serves
+// as a reference for it, and lazydfa falls back to it when its cache of states thrashes. The automata
+// constructed upfront (see the dfa and program packages) have no such fallback: their size isn't bounded.
+type VM struct {
+	root *Node
+	opts VMOptions
+}
+
+// A thread is a pending transition on a rune, or a match if t is nil.
+type thread struct {
+	t     *T
+	start int
+}
+
+// NewVM returns a VM executing the automaton. The counted repetitions (see Options.CountThreshold) are
+// unrolled into a copy of the automaton.
+func NewVM(root *Node, opts VMOptions) *VM {
+	if counts(root, make(map[*Node]bool)) {
+		root = unrollCounters(root, make(map[*Node]*Node))
+	}
+	return &VM{root: root, opts: opts}
+}
+
+// counts reports whether a counter node is reachable from n.
+func counts(n *Node, visited map[*Node]bool) bool {
+	if visited[n] {
+		return false
+	}
+	visited[n] = true
+	if n.C != nil {
+		return true
+	}
+	for _, t := range n.T {
+		if counts(t.N, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// unrollCounters returns a copy of the automaton from n with each counter node replaced by a chain of Max+1 nodes
+// linked by transitions on the counted runes, the transitions of the counter node being taken from the
+// Min-th node on, after the transition on the runes, as the repetition is greedy.
+func unrollCounters(n *Node, copies map[*Node]*Node) *Node {
+	if c, ok := copies[n]; ok {
+		return c
+	}
+	if n.C == nil {
+		c := &Node{S: n.S, F: n.F}
+		copies[n] = c
+		for _, t := range n.T {
+			c.T = append(c.T, T{R: t.R, N: unrollCounters(t.N, copies)})
+		}
+		return c
+	}
+
+	chain := make([]*Node, n.C.Max+1)
+	for i := range chain {
+		chain[i] = &Node{S: n.S, F: n.F && i >= n.C.Min}
+	}
+	copies[n] = chain[0]
+	var exits []T
+	for _, t := range n.T {
+		exits = append(exits, T{R: t.R, N: unrollCounters(t.N, copies)})
+	}
+	for i, c := range chain {
+		if i < n.C.Max {
+			c.T = append(c.T, T{R: n.C.R, N: chain[i+1]})
+		}
+		if i >= n.C.Min {
+			c.T = append(c.T, exits...)
+		}
+	}
+	return chain[0]
+}
+
+// Match returns the end of the match at the beginning of s, or -1.
+func (vm *VM) Match(s string) (end int) {
+	return vm.MatchAt(s, 0)
+}
+
+// MatchAt returns the end of the match of s starting at the position at, or -1.
+// The assertions see the whole of s.
+func (vm *VM) MatchAt(s string, at int) (end int) {
+	_, end = vm.run(s, at, false)
+	return end
+}
+
+// Find returns the leftmost match in s, or -1, -1.
+func (vm *VM) Find(s string) (start, end int) {
+	return vm.run(s, 0, true)
+}
+
+// run runs the threads from the position at, starting a new thread at every position if unanchored
+// until a match is found, and returns the match or -1, -1.
+func (vm *VM) run(s string, at int, unanchored bool) (start, end int) {
+	start, end = -1, -1
+	var clist, nlist []thread
+	visited := make(map[*Node]bool)
+
+	clist = vm.add(clist, vm.root, at, s, at, visited)
+	for i := at; ; {
+		var r rune
+		rlen := 0
+		if i < len(s) {
+			r, rlen = utf8.DecodeRuneInString(s[i:])
+		}
+
+		for k := range visited {
+			delete(visited, k)
+		}
+		nlist = nlist[:0]
+		for _, th := range clist {
+			if th.t == nil {
+				if vm.opts.Longest {
+					if start < 0 || th.start < start || th.start == start && i > end {
+						start, end = th.start, i
+					}
+					continue
+				}
+				start, end = th.start, i
+				// The remaining threads have a lower priority.
+				break
+			}
+			if start >= 0 && th.start > start {
+				break
+			}
+			if rlen > 0 && runerange.In(th.t.R, r) {
+				nlist = vm.add(nlist, th.t.N, th.start, s, i+rlen, visited)
+			}
+		}
+		if rlen == 0 {
+			break
+		}
+		i += rlen
+		if unanchored && start < 0 {
+			nlist = vm.add(nlist, vm.root, i, s, i, visited)
+		} else if len(nlist) == 0 {
+			break
+		}
+		clist, nlist = nlist, clist
+	}
+	return start, end
+}
+
+// add appends the threads of node at the position i of s to the list in the order of their priority,
+// following the empty transitions, the assertions which hold and the lazy transitions.
+func (vm *VM) add(list []thread, node *Node, start int, s string, i int, visited map[*Node]bool) []thread {
+	if visited[node] {
+		return list
+	}
+	visited[node] = true
+
+	if node.F {
+		list = append(list, thread{nil, start})
+	}
+	for k := range node.T {
+		t := &node.T[k]
+		switch {
+		case len(t.R) == 0:
+			list = vm.add(list, t.N, start, s, i, visited)
+		case t.R[0] == RuneLazy:
+			// Followed last.
+		case t.R[0] < 0:
+			if vm.holds(t.R, s, i) {
+				list = vm.add(list, t.N, start, s, i, visited)
+			}
+		default:
+			list = append(list, thread{t, start})
+		}
+	}
+	for k := range node.T {
+		if t := &node.T[k]; len(t.R) > 0 && t.R[0] == RuneLazy {
+			list = vm.add(list, t.N, start, s, i, visited)
+		}
+	}
+	return list
+}
+
+// holds reports whether any of the assertions in rr hold at the position i of s.
+func (vm *VM) holds(rr []rune, s string, i int) bool {
+	for k := 0; k < len(rr) && rr[k] < 0; k += 2 {
+		if Assert(rr[k], s, i, vm.opts.LineTerminators, vm.opts.UnicodeWordBoundary) {
+			return true
+		}
+	}
+	return false
+}
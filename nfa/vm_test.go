@@ -0,0 +1,139 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nfa
+
+import (
+	"regexp"
+	"testing"
+)
+
+var vmPatterns = []string{
+	"a+",
+	"abc|abd",
+	"ab|abc",
+	"(?i)k",
+	"^$",
+	`\bfoo\b`,
+	`\Bo+\B`,
+	"(?m)^[a-z]+$",
+	`\d{2,3}x?`,
+	"[^a]*",
+	"<!--.*?-->",
+	"a*?b",
+	"a+?",
+	"(a|ab)(c|bcd)",
+	"x*",
+	"é+|ф",
+	"(a*)*b",
+	"a??a",
+	"[a-c]{2,4}d",
+	"(?:x{3}y)*",
+	"a{2,}",
+	".{0,3}$",
+}
+
+var vmInputs = []string{
+	"",
+	"a",
+	"aaab",
+	"abd",
+	"xabcx",
+	"abcd",
+	"K",
+	"foo bar",
+	"foobar foo",
+	"boooo",
+	"ab\ncd\n",
+	"12345",
+	"<!-- a --> -->",
+	"xxab",
+	"ééф",
+	"\xffa",
+	"abcabd",
+	"xxxyxxxyxxy",
+	"aaaaa",
+}
+
+func TestVMAgainstRegexp(t *testing.T) {
+	for _, threshold := range []int{0, 2} {
+		for _, longest := range []bool{false, true} {
+			for _, pattern := range vmPatterns {
+				testVMAgainstRegexp(t, pattern, Options{CountThreshold: threshold}, longest)
+			}
+		}
+	}
+}
+
+func testVMAgainstRegexp(t *testing.T, pattern string, opts Options, longest bool) {
+	r, err := opts.Parse(pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := NewFromRegexp(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm := NewVM(n, VMOptions{Longest: longest})
+	anchored := regexp.MustCompile(`^(?:` + pattern + `)`)
+	re := regexp.MustCompile(pattern)
+	if longest {
+		anchored.Longest()
+		re.Longest()
+	}
+	for _, s := range vmInputs {
+		want := -1
+		if loc := anchored.FindStringIndex(s); loc != nil {
+			want = loc[1]
+		}
+		if got := vm.Match(s); got != want {
+			t.Errorf("%q %+v (longest: %v): Match(%q) = %d, want %d", pattern, opts, longest, s, got, want)
+		}
+
+		wantLoc := []int{-1, -1}
+		if loc := re.FindStringIndex(s); loc != nil {
+			wantLoc = loc
+		}
+		if start, end := vm.Find(s); start != wantLoc[0] || end != wantLoc[1] {
+			t.Errorf("%q %+v (longest: %v): Find(%q) = %d, %d, want %v", pattern, opts, longest, s, start, end, wantLoc)
+		}
+	}
+}
+
+func TestVMOptions(t *testing.T) {
+	tests := []struct {
+		pattern string
+		opts    VMOptions
+		in      string
+		at      int
+		want    int
+	}{
+		{"(?m)a$", VMOptions{LineTerminators: LineCR}, "a\rb", 0, 1},
+		{"(?m)a$", VMOptions{LineTerminators: LineCR}, "a\nb", 0, -1},
+		{`.\b`, VMOptions{}, "фa", 0, 2},
+		{`.\b`, VMOptions{UnicodeWordBoundary: true}, "ф a", 0, 2},
+		{`.\b`, VMOptions{UnicodeWordBoundary: true}, "фa", 0, -1},
+		{`^a`, VMOptions{}, "aa", 1, -1},
+		{`\ba`, VMOptions{}, "aa", 1, -1},
+		{`a$`, VMOptions{}, "aa", 1, 2},
+	}
+	for _, tst := range tests {
+		n, err := New(tst.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := NewVM(n, tst.opts).MatchAt(tst.in, tst.at); got != tst.want {
+			t.Errorf("%q %+v: MatchAt(%q, %d) = %d, want %d", tst.pattern, tst.opts, tst.in, tst.at, got, tst.want)
+		}
+	}
+}
@@ -0,0 +1,273 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package program
+
+import (
+	"unicode/utf8"
+
+	"github.com/opennota/re2dfa/dfa"
+	"github.com/opennota/re2dfa/nfa"
+	"github.com/opennota/re2dfa/runerange"
+)
+
+// A step describes what is done in a state of the automaton, in the order of the generated code.
+type step struct {
+	empty []edge // transitions on assertions
+	runes []edge // transitions on runes
+}
+
+type edge struct {
+	r    []rune // a single pseudo-rune pair for assertions, or positive rune ranges
+	n    *dfa.Node
+	ops  []dfa.CounterOp
+	cond []dfa.CounterCond
+}
+
+// steps returns the steps of the states of the automaton.
+func steps(root *dfa.Node) map[*dfa.Node]*step {
+	m := make(map[*dfa.Node]*step)
+	for _, n := range dfaNodes(root) {
+		st := &step{}
+		for _, t := range n.T {
+			i := 0
+			for ; i < len(t.R) && t.R[i] < 0; i += 2 {
+				st.empty = append(st.empty, edge{t.R[i : i+2], t.N, t.Ops, t.Cond})
+			}
+			if i < len(t.R) {
+				st.runes = append(st.runes, edge{t.R[i:], t.N, t.Ops, t.Cond})
+			}
+		}
+		m[n] = st
+	}
+	return m
+}
+
+// Match returns the end of the match at the beginning of s, or -1, like a function generated
+// in codegen.ModeMatch.
+func (p *Program) Match(s string) (end int) {
+	return p.exec(p.Root, p.steps, s, 0, false)
+}
+
+// Find returns the leftmost match in s (see SearchAutomata), or -1, -1, like a function generated in
+// codegen.ModeSearch. The input is scanned once forward with the search automaton to find the end of the match,
+// and then backward from the end with the reverse automaton to find the start. Without search automata,
+// the automaton finding the match at the beginning of the input is tried at every position.
+func (p *Program) Find(s string) (start, end int) {
+	first, search, reverse, m := p.searchAutomata()
+	if search != nil {
+		end = p.exec(search, m, s, 0, false)
+		if end < 0 {
+			return -1, -1
+		}
+		return p.exec(reverse, m, s, end, true), end
+	}
+
+	// The automaton counts, or the pattern of a deserialized program doesn't compile.
+	if first == nil {
+		first, m = p.Root, p.steps
+	}
+	for start <= len(s) {
+		if end := p.exec(first, m, s, start, false); end >= 0 {
+			return start, end
+		}
+		_, rlen := utf8.DecodeRuneInString(s[start:])
+		if rlen == 0 {
+			break
+		}
+		start += rlen
+	}
+	return -1, -1
+}
+
+// searchAutomata returns the automata of SearchAutomata and the steps of their states, or nils if they can't be
+// constructed.
+func (p *Program) searchAutomata() (first, search, reverse *dfa.Node, m map[*dfa.Node]*step) {
+	first, search, reverse, err := p.SearchAutomata()
+	if err != nil {
+		return nil, nil, nil, nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.searchSteps == nil {
+		p.searchSteps = steps(first)
+		for _, root := range []*dfa.Node{search, reverse} {
+			if root == nil {
+				continue
+			}
+			for n, st := range steps(root) {
+				p.searchSteps[n] = st
+			}
+		}
+	}
+	return first, search, reverse, p.searchSteps
+}
+
+// reversedAssertions maps the assertions of a reversed pattern to the ones they stand for.
+var reversedAssertions = map[rune]rune{
+	nfa.RuneBeginText: nfa.RuneEndText,
+	nfa.RuneEndText:   nfa.RuneBeginText,
+	nfa.RuneBeginLine: nfa.RuneEndLine,
+	nfa.RuneEndLine:   nfa.RuneBeginLine,
+}
+
+// A register holds the numbers of runes counted by the threads in a bounded repetition (see dfa.Counter),
+// as the numbers of runes counted by the register when they entered the repetition, oldest first.
+type register struct {
+	entered []int // ring buffer of Max+1 numbers
+	head, n int
+	count   int // the number of runes counted by the register
+}
+
+func (reg *register) do(op dfa.CounterOp) {
+	switch op.Action {
+	case dfa.CounterIncrement:
+		reg.count++
+		if reg.n > 0 && reg.count-reg.entered[reg.head] > op.C.Max {
+			reg.head = (reg.head + 1) % len(reg.entered)
+			reg.n--
+		}
+	case dfa.CounterReset:
+		reg.n = 0
+	case dfa.CounterEnter:
+		if reg.n > 0 && reg.entered[(reg.head+reg.n-1)%len(reg.entered)] == reg.count {
+			// A thread has already entered the repetition at this position.
+			return
+		}
+		reg.entered[(reg.head+reg.n)%len(reg.entered)] = reg.count
+		reg.n++
+	}
+}
+
+func (reg *register) holds(cond dfa.CounterCond) bool {
+	switch cond.State {
+	case dfa.CounterEmpty:
+		return reg.n == 0
+	case dfa.CounterBelowMin:
+		return reg.n > 0 && reg.count-reg.entered[reg.head] < cond.C.Min
+	case dfa.CounterAtLeastMin:
+		return reg.n > 0 && reg.count-reg.entered[reg.head] >= cond.C.Min
+	}
+	return false
+}
+
+// registers holds the registers of the counters of an automaton; they are allocated when they are first used.
+type registers map[*dfa.Counter]*register
+
+func (regs *registers) do(ops []dfa.CounterOp) {
+	for _, op := range ops {
+		reg, ok := (*regs)[op.C]
+		if !ok {
+			if *regs == nil {
+				*regs = make(registers)
+			}
+			reg = &register{entered: make([]int, op.C.Max+1)}
+			(*regs)[op.C] = reg
+		}
+		reg.do(op)
+	}
+}
+
+func (regs registers) holds(cond []dfa.CounterCond) bool {
+	for _, c := range cond {
+		reg, ok := regs[c.C]
+		if !ok {
+			// The register is empty.
+			reg = &register{}
+		}
+		if !reg.holds(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// exec runs the automaton from root on s from the position at, forward or backward to the beginning of s,
+// and returns the last position where the automaton was in a final state, or -1.
+func (p *Program) exec(root *dfa.Node, steps map[*dfa.Node]*step, s string, at int, backward bool) int {
+	end := -1
+	if root.F {
+		end = at
+	}
+	var regs registers
+	regs.do(root.Init)
+	n, i := root, at
+	for {
+		st := steps[n]
+		var next *dfa.Node
+		asserted := false
+		for _, e := range st.empty {
+			r := e.r[0]
+			if backward {
+				if swapped, ok := reversedAssertions[r]; ok {
+					r = swapped
+				}
+			}
+			if p.assert(r, s, i) {
+				asserted = true
+				regs.do(e.ops)
+				if e.n.F {
+					end = i
+				}
+				if len(e.n.T) > 0 {
+					next = e.n
+				}
+				break
+			}
+		}
+		if !asserted && len(st.runes) > 0 {
+			var r rune
+			var rlen int
+			if backward {
+				r, rlen = utf8.DecodeLastRuneInString(s[:i])
+				i -= rlen
+			} else {
+				r, rlen = utf8.DecodeRuneInString(s[i:])
+				i += rlen
+			}
+			if rlen > 0 {
+				counted := false
+				for _, e := range st.runes {
+					if !runerange.In(e.r, r) {
+						continue
+					}
+					count, enter := dfa.SplitOps(e.ops)
+					if !counted {
+						// The transitions on r count it alike.
+						regs.do(count)
+						counted = true
+					}
+					if regs.holds(e.cond) {
+						regs.do(enter)
+						if e.n.F {
+							end = i
+						}
+						if len(e.n.T) > 0 {
+							next = e.n
+						}
+						break
+					}
+				}
+			}
+		}
+		if next == nil {
+			return end
+		}
+		n = next
+	}
+}
+
+// assert reports whether the assertion holds at the position i of s.
+func (p *Program) assert(r rune, s string, i int) bool {
+	return nfa.Assert(r, s, i, p.Options.LineTerminators, p.Options.UnicodeWordBoundary)
+}
@@ -0,0 +1,368 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package program compiles regular expressions into programs: the automata from which the code of any of
+// the backends can be generated, and which can be serialized, inspected and run in-process.
+package program
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"sync"
+
+	"github.com/opennota/re2dfa/codegen"
+	"github.com/opennota/re2dfa/dfa"
+	"github.com/opennota/re2dfa/nfa"
+	"github.com/opennota/re2dfa/runerange"
+)
+
+// Options control the compilation of a pattern. The zero value selects the semantics of the regexp package.
+type Options struct {
+	nfa.Options
+
+	// Evaluate \b and \B against the Unicode word characters instead of the ASCII ones (Go only).
+	UnicodeWordBoundary bool
+}
+
+// A Program is a compiled pattern.
+type Program struct {
+	Pattern string  // the pattern passed to Compile
+	Options Options // the options passed to Compile
+
+	Root *dfa.Node // the automaton matching at the beginning of the input
+
+	nfa   *nfa.Node // nil if the program has been deserialized
+	steps map[*dfa.Node]*step
+
+	mu                     sync.Mutex
+	first, search, reverse *dfa.Node // constructed by SearchAutomata
+	searchSteps            map[*dfa.Node]*step
+}
+
+// Compile parses the pattern and constructs the automata. Errors are returned as an *nfa.Error.
+func Compile(pattern string, opts Options) (*Program, error) {
+	r, err := opts.Parse(pattern)
+	if err != nil {
+		return nil, err
+	}
+	nfanode, err := nfa.NewFromRegexp(r)
+	if err != nil {
+		return nil, opts.Locate(err, pattern)
+	}
+
+	p := &Program{
+		Pattern: pattern,
+		Options: opts,
+		Root:    dfa.NewFromNFA(nfanode),
+		nfa:     nfanode,
+	}
+	p.steps = steps(p.Root)
+	return p, nil
+}
+
+// SearchAutomata returns the automata of the modes finding the matches in the input (see codegen.Func):
+// the automaton finding the match of the regexp package at the beginning of the input, which is Root if Root
+// finds the same matches, and the automata for the forward-then-reverse scan. The matches are the leftmost-first
+// ones, or the leftmost-longest ones with the POSIX option, as in regexp.CompilePOSIX. As the automata can be
+// much larger than Root, they are only constructed when they are needed for the first time.
+//
+// If Root counts (see nfa.Options.CountThreshold), it is kept if its leftmost-longest matches are the ones
+// to find, with the POSIX option or a pattern for which nfa.FirstIsLongest holds, and there are no search
+// automata, as the matches started at every position would have to be counted apart. Otherwise
+// the repetitions are unrolled.
+func (p *Program) SearchAutomata() (first, search, reverse *dfa.Node, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.first != nil {
+		return p.first, p.search, p.reverse, nil
+	}
+
+	opts := p.Options.Options
+	nfanode := p.nfa
+	if p.Root.Counts() {
+		if r, err := syntax.Parse(p.Pattern, opts.Flags()); opts.POSIX || err == nil && nfa.FirstIsLongest(r) {
+			p.first = p.Root
+			return p.first, nil, nil, nil
+		}
+		opts.CountThreshold = 0
+		nfanode = nil
+	}
+	r, err := opts.Parse(p.Pattern)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if nfanode == nil {
+		nfanode, err = nfa.NewFromRegexp(r)
+		if err != nil {
+			return nil, nil, nil, opts.Locate(err, p.Pattern)
+		}
+	}
+	reverseNFA, err := nfa.NewReverseFromRegexp(r)
+	if err != nil {
+		return nil, nil, nil, opts.Locate(err, p.Pattern)
+	}
+	p.first = p.Root
+	if opts.POSIX {
+		p.search = dfa.NewSearchFromNFA(nfanode, false)
+	} else {
+		if !p.Root.LeftmostFirst {
+			p.first = dfa.NewLeftmostFirstFromNFA(nfanode)
+		}
+		p.search = dfa.NewLeftmostFirstSearchFromNFA(nfanode)
+	}
+	p.reverse = dfa.NewSearchFromNFA(reverseNFA, true)
+	return p.first, p.search, p.reverse, nil
+}
+
+// A Target describes the code to generate.
+type Target struct {
+	Lang     string       // go (the default), c, rust, js or ts
+	Package  string       // name of the package of the Go file
+	Name     string       // name of the function
+	Type     string       // type of the argument of the Go function: string (the default) or []byte
+	Mode     codegen.Mode // kind of the function; the backends other than Go only generate codegen.ModeMatch
+	Template string       // replacement template in codegen.ModeReplaceAll
+}
+
+// Func returns the description of the Go function generated for the target.
+func (p *Program) Func(t Target) (codegen.Func, error) {
+	pattern, err := p.Options.Pattern(p.Pattern)
+	if err != nil {
+		return codegen.Func{}, err
+	}
+	typ := t.Type
+	if typ == "" {
+		typ = "string"
+	}
+	fn := codegen.Func{
+		Name:    t.Name,
+		Type:    typ,
+		Mode:    t.Mode,
+		Pattern: pattern,
+		Root:    p.Root,
+
+		Template:            t.Template,
+		LineTerminators:     p.Options.LineTerminators,
+		UnicodeWordBoundary: p.Options.UnicodeWordBoundary,
+	}
+	switch t.Mode {
+	case codegen.ModeMatch:
+	case codegen.ModeBool:
+		// Laziness doesn't matter if the position of the match isn't reported.
+		fn.Root, err = p.greedy()
+		if err != nil {
+			return codegen.Func{}, err
+		}
+	default:
+		fn.Root, fn.Search, fn.Reverse, err = p.SearchAutomata()
+		if err != nil {
+			return codegen.Func{}, err
+		}
+	}
+	return fn, nil
+}
+
+// greedy returns the automaton with the lazy quantifiers made greedy.
+func (p *Program) greedy() (*dfa.Node, error) {
+	r, err := p.Options.Parse(p.Pattern)
+	if err != nil {
+		return nil, err
+	}
+	nfanode, err := nfa.NewGreedyFromRegexp(r)
+	if err != nil {
+		return nil, p.Options.Locate(err, p.Pattern)
+	}
+	return dfa.NewFromNFA(nfanode), nil
+}
+
+// Generate generates the source code of the function described by the target.
+func (p *Program) Generate(t Target) (string, error) {
+	if t.Lang == "" || t.Lang == "go" {
+		fn, err := p.Func(t)
+		if err != nil {
+			return "", err
+		}
+		return codegen.GoGenerateFile(t.Package, fn)
+	}
+
+	if t.Mode != codegen.ModeMatch {
+		return "", fmt.Errorf("%s: only the match mode is supported", t.Lang)
+	}
+	if lt := p.Options.LineTerminators; lt != 0 && lt != nfa.LineLF {
+		return "", fmt.Errorf("%s: only \\n is supported as a line terminator", t.Lang)
+	}
+	if p.Options.UnicodeWordBoundary {
+		return "", fmt.Errorf("%s: Unicode word boundaries are not supported", t.Lang)
+	}
+	if p.Root.Counts() {
+		return "", fmt.Errorf("%s: counted repetitions are not supported", t.Lang)
+	}
+	switch t.Lang {
+	case "c":
+		return codegen.CGenerate(p.Root, t.Name)
+	case "rust":
+		return codegen.RustGenerate(p.Root, t.Name)
+	case "js":
+		return codegen.JSGenerate(p.Root, t.Name)
+	case "ts":
+		return codegen.TSGenerate(p.Root, t.Name)
+	}
+	return "", fmt.Errorf("unknown language: %s", t.Lang)
+}
+
+// GenerateTest generates a Go test file checking the function described by the target against the regexp
+// package (see codegen.GoGenerateTest).
+func (p *Program) GenerateTest(t Target) (string, error) {
+	if t.Lang != "" && t.Lang != "go" {
+		return "", fmt.Errorf("%s: tests can only be generated for Go", t.Lang)
+	}
+	fn, err := p.Func(t)
+	if err != nil {
+		return "", err
+	}
+	return codegen.GoGenerateTest(t.Package, fn)
+}
+
+// Stats describes the size of a program.
+type Stats struct {
+	NFAStates            int            `json:"nfa_states"`               // states of the NFA, or 0 if the program has been deserialized
+	LazyStates           int            `json:"lazy_states"`              // states of the NFA with lazy transitions (lazy quantifiers)
+	DFAStates            int            `json:"dfa_states"`               // states of the automaton matching at the beginning of the input
+	DFATransitions       int            `json:"dfa_transitions"`          // transitions of the automaton matching at the beginning of the input
+	MinimizedStates      int            `json:"minimized_states"`         // states of the automaton after minimization (see dfa.Minimize)
+	MinimizedTransitions int            `json:"minimized_transitions"`    // transitions of the automaton after minimization
+	LeftmostFirst        bool           `json:"leftmost_first"`           // the automaton finds the leftmost-first match (the pattern has lazy quantifiers)
+	Assertions           map[string]int `json:"assertions,omitempty"`     // transitions of the automaton on each kind of assertion
+	AlphabetClasses      int            `json:"alphabet_classes"`         // classes of runes which the automaton doesn't tell apart
+	SearchStates         int            `json:"search_states,omitempty"`  // states of the search automaton, or 0 if it hasn't been constructed
+	ReverseStates        int            `json:"reverse_states,omitempty"` // states of the automaton of the reversed pattern, or 0 if it hasn't been constructed
+}
+
+// The names of the assertions in Stats.Assertions.
+var assertionNames = map[rune]string{
+	nfa.RuneBeginText:      "begin_text",
+	nfa.RuneEndText:        "end_text",
+	nfa.RuneBeginLine:      "begin_line",
+	nfa.RuneEndLine:        "end_line",
+	nfa.RuneWordBoundary:   "word_boundary",
+	nfa.RuneNoWordBoundary: "no_word_boundary",
+}
+
+// Stats returns the size of the program.
+func (p *Program) Stats() Stats {
+	var st Stats
+	if p.nfa != nil {
+		nodes := nfaNodes(p.nfa, make(map[*nfa.Node]bool))
+		st.NFAStates = len(nodes)
+		for _, n := range nodes {
+			for _, t := range n.T {
+				if len(t.R) > 0 && t.R[0] == nfa.RuneLazy {
+					st.LazyStates++
+					break
+				}
+			}
+		}
+	}
+	st.DFAStates, st.DFATransitions = countAutomaton(p.Root)
+	st.MinimizedStates, st.MinimizedTransitions = countAutomaton(dfa.Minimize(p.Root))
+	st.LeftmostFirst = p.Root.LeftmostFirst
+
+	nodes := dfaNodes(p.Root)
+	index := make(map[*dfa.Node]int, len(nodes))
+	// The elementary ranges cover all the runes, including those without any transition.
+	ranges := [][]rune{{0, nfa.RuneLast}}
+	for k, n := range nodes {
+		index[n] = k
+		for _, t := range n.T {
+			var rr []rune
+			for i := 0; i < len(t.R); i += 2 {
+				if t.R[i] >= 0 {
+					rr = append(rr, t.R[i:i+2]...)
+				} else if name, ok := assertionNames[t.R[i]]; ok {
+					if st.Assertions == nil {
+						st.Assertions = make(map[string]int)
+					}
+					st.Assertions[name]++
+				}
+			}
+			if rr != nil {
+				ranges = append(ranges, rr)
+			}
+		}
+	}
+	st.AlphabetClasses = alphabetClasses(nodes, index, runerange.Split(ranges))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.search != nil {
+		st.SearchStates = len(dfaNodes(p.search))
+		st.ReverseStates = len(dfaNodes(p.reverse))
+	}
+	return st
+}
+
+// alphabetClasses returns the number of classes of runes which lead to the same states from every state,
+// the dead state included, given the elementary ranges which the transitions are made of.
+func alphabetClasses(nodes []*dfa.Node, index map[*dfa.Node]int, pieces []rune) int {
+	classes := make(map[string]bool)
+	for i := 0; i < len(pieces); i += 2 {
+		targets := make([]int, len(nodes))
+		for k, n := range nodes {
+			targets[k] = -1
+			for _, t := range n.T {
+				if runerange.In(t.R, pieces[i]) {
+					targets[k] = index[t.N]
+					break
+				}
+			}
+		}
+		classes[fmt.Sprint(targets)] = true
+	}
+	return len(classes)
+}
+
+// countAutomaton returns the number of states and transitions of the automaton.
+func countAutomaton(root *dfa.Node) (states, transitions int) {
+	nodes := dfaNodes(root)
+	for _, n := range nodes {
+		transitions += len(n.T)
+	}
+	return len(nodes), transitions
+}
+
+func nfaNodes(n *nfa.Node, visited map[*nfa.Node]bool) []*nfa.Node {
+	if visited[n] {
+		return nil
+	}
+	visited[n] = true
+	nodes := []*nfa.Node{n}
+	for _, t := range n.T {
+		nodes = append(nodes, nfaNodes(t.N, visited)...)
+	}
+	return nodes
+}
+
+// dfaNodes returns the nodes reachable from root, in the order of a breadth-first traversal.
+func dfaNodes(root *dfa.Node) []*dfa.Node {
+	visited := map[*dfa.Node]bool{root: true}
+	nodes := []*dfa.Node{root}
+	for i := 0; i < len(nodes); i++ {
+		for _, t := range nodes[i].T {
+			if !visited[t.N] {
+				visited[t.N] = true
+				nodes = append(nodes, t.N)
+			}
+		}
+	}
+	return nodes
+}
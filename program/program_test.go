@@ -0,0 +1,484 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package program
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opennota/re2dfa/codegen"
+	"github.com/opennota/re2dfa/dfa"
+	"github.com/opennota/re2dfa/nfa"
+)
+
+var patterns = []string{
+	"a+",
+	"abc|abd",
+	"(?i)k",
+	"^$",
+	`\bfoo\b`,
+	`\Bo+`,
+	"(?m)^[a-z]+$",
+	`\d{2,3}x?`,
+	"[^a]*",
+	"<!--.*?-->",
+	"a*?b",
+	"x*",
+	"é+|ф",
+	"a+?",
+	"(a+?)(b|ab)",
+	`\b.+?\b`,
+	`.*?\bfoo`,
+	"(?m)^.*?$",
+	"(a|ab)(c|bcd)",
+	"a|ab",
+	`(?:$*)+`,
+	`(?:\b*a?)*`,
+	`(?:a?^*)*`,
+	`(?:(?m:^){0,2}?.??$*?)*`,
+}
+
+var inputs = []string{
+	"",
+	"a",
+	"aaab",
+	"abd",
+	"xabcx",
+	"abcd",
+	"K",
+	"foo bar",
+	"foobar foo",
+	"boooo",
+	"ab\ncd\n",
+	"12345",
+	"<!-- a --> -->",
+	"xxab",
+	"ééф",
+	"\xffa",
+}
+
+func TestMatchAgainstRegexp(t *testing.T) {
+	for _, pattern := range patterns {
+		p, err := Compile(pattern, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		anchored := regexp.MustCompile(`^(?:` + pattern + `)`)
+		re := regexp.MustCompile(pattern)
+		if !strings.Contains(pattern, "?") {
+			anchored.Longest()
+		}
+		for _, s := range inputs {
+			want := -1
+			if loc := anchored.FindStringIndex(s); loc != nil {
+				want = loc[1]
+			}
+			if got := p.Match(s); got != want {
+				t.Errorf("%q: Match(%q) = %d, want %d", pattern, s, got, want)
+			}
+
+			wantLoc := []int{-1, -1}
+			if loc := re.FindStringIndex(s); loc != nil {
+				wantLoc = loc
+			}
+			if start, end := p.Find(s); start != wantLoc[0] || end != wantLoc[1] {
+				t.Errorf("%q: Find(%q) = %d, %d, want %v", pattern, s, start, end, wantLoc)
+			}
+		}
+	}
+}
+
+func TestMatchAgainstVM(t *testing.T) {
+	for _, pattern := range patterns {
+		p, err := Compile(pattern, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		n, err := nfa.New(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vm := nfa.NewVM(n, nfa.VMOptions{Longest: !p.Root.LeftmostFirst})
+		firstVM := nfa.NewVM(n, nfa.VMOptions{})
+		for _, s := range inputs {
+			if got, want := p.Match(s), vm.Match(s); got != want {
+				t.Errorf("%q: Match(%q) = %d, want %d", pattern, s, got, want)
+			}
+			start, end := p.Find(s)
+			if wantStart, wantEnd := firstVM.Find(s); start != wantStart || end != wantEnd {
+				t.Errorf("%q: Find(%q) = %d, %d, want %d, %d", pattern, s, start, end, wantStart, wantEnd)
+			}
+		}
+	}
+}
+
+func TestFindScansOnce(t *testing.T) {
+	// Restarting the scan at every position would take quadratic time.
+	s := strings.Repeat("a", 100000)
+	for _, pattern := range []string{"a+b", "a+?b"} {
+		p, err := Compile(pattern, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if start, end := p.Find(s); start != -1 || end != -1 {
+			t.Errorf("%q: Find = %d, %d, want -1, -1", pattern, start, end)
+		}
+		if start, end := p.Find(s + "b"); start != 0 || end != len(s)+1 {
+			t.Errorf("%q: Find = %d, %d, want 0, %d", pattern, start, end, len(s)+1)
+		}
+	}
+}
+
+func TestFindPOSIX(t *testing.T) {
+	for _, pattern := range []string{"a|ab", "(a|ab)(c|bcd)", "x[0-9]{1,8}|x[0-9]{1,8}y"} {
+		re := regexp.MustCompilePOSIX(pattern)
+		for _, threshold := range []int{0, 4} {
+			p, err := Compile(pattern, Options{Options: nfa.Options{POSIX: true, CountThreshold: threshold}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range []string{"ab", "xabcd", "x123y", "x12"} {
+				wantLoc := []int{-1, -1}
+				if loc := re.FindStringIndex(s); loc != nil {
+					wantLoc = loc
+				}
+				if start, end := p.Find(s); start != wantLoc[0] || end != wantLoc[1] {
+					t.Errorf("%q with the threshold %d: Find(%q) = %d, %d, want %v", pattern, threshold, s, start, end, wantLoc)
+				}
+			}
+		}
+	}
+}
+
+func TestMatchOptions(t *testing.T) {
+	tests := []struct {
+		pattern string
+		opts    Options
+		in      string
+		want    int
+	}{
+		{"abc", Options{Options: nfa.Options{IgnoreCase: true}}, "ABC", 3},
+		{"a.c", Options{Options: nfa.Options{Literal: true}}, "abc", -1},
+		{"a.c", Options{Options: nfa.Options{Literal: true}}, "a.c", 3},
+		{"a+", Options{Options: nfa.Options{Ungreedy: true}}, "aaa", 1},
+		{"(?m)a$", Options{Options: nfa.Options{LineTerminators: nfa.LineCR}}, "a\rb", 1},
+		{"(?m)a$", Options{Options: nfa.Options{LineTerminators: nfa.LineCR}}, "a\nb", -1},
+		{"(?m)a.$", Options{Options: nfa.Options{LineTerminators: nfa.LineCRLF}}, "ab\r\n", 2},
+		{"(?m)a.$", Options{Options: nfa.Options{LineTerminators: nfa.LineCRLF}}, "a\r\n", -1},
+		{"(?m)a$", Options{Options: nfa.Options{LineTerminators: nfa.LineUnicode}}, "a ", 1},
+		{`.\b`, Options{}, "фa", 2},
+		{`.\b`, Options{UnicodeWordBoundary: true}, "ф a", 2},
+		{`.\b`, Options{UnicodeWordBoundary: true}, "фa", -1},
+	}
+	for _, tst := range tests {
+		p, err := Compile(tst.pattern, tst.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := p.Match(tst.in); got != tst.want {
+			t.Errorf("%q %+v: Match(%q) = %d, want %d", tst.pattern, tst.opts, tst.in, got, tst.want)
+		}
+	}
+}
+
+func TestCompileError(t *testing.T) {
+	_, err := Compile("a(", Options{})
+	var e *nfa.Error
+	if !errors.As(err, &e) || e.Stage != "parse" {
+		t.Errorf("Compile: want a parse error, got %v", err)
+	}
+
+	_, err = Compile(`\{2,1}x{2,1}`, Options{Options: nfa.Options{CountThreshold: 2}})
+	if !errors.As(err, &e) || e.Expr != "{2,1}" || e.Offset != 7 {
+		t.Errorf("Compile: want {2,1} at offset 7, got %v", err)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	p, err := Compile("a*?b", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []Target{
+		{Package: "test", Name: "matchA"},
+		{Package: "test", Name: "matchA", Type: "[]byte", Mode: codegen.ModeFindAll},
+		{Package: "test", Name: "matchA", Mode: codegen.ModeBool},
+		{Lang: "c", Name: "match_a"},
+		{Lang: "rust", Name: "match_a"},
+		{Lang: "js", Name: "matchA"},
+		{Lang: "ts", Name: "matchA"},
+	} {
+		source, err := p.Generate(tt)
+		if err != nil {
+			t.Errorf("%+v: %v", tt, err)
+			continue
+		}
+		if !strings.Contains(source, tt.Name) {
+			t.Errorf("%+v: the function is missing:\n%s", tt, source)
+		}
+	}
+	if _, err := p.GenerateTest(Target{Package: "test", Name: "matchA"}); err != nil {
+		t.Error(err)
+	}
+
+	for _, tt := range []Target{
+		{Lang: "c", Name: "match_a", Mode: codegen.ModeSearch},
+		{Lang: "cobol", Name: "match_a"},
+		{Package: "test", Name: "matchA", Type: "rune"},
+	} {
+		if _, err := p.Generate(tt); err == nil {
+			t.Errorf("%+v: want an error", tt)
+		}
+	}
+}
+
+func TestFuncPattern(t *testing.T) {
+	for _, tst := range []struct {
+		pattern string
+		opts    nfa.Options
+		want    string
+	}{
+		{"a$", nfa.Options{}, "a$"},
+		{"a$", nfa.Options{LineTerminators: nfa.LineCRLF}, "a$"},
+		{"^a{1,10}", nfa.Options{CountThreshold: 4}, "^a{1,10}"},
+		{"a$", nfa.Options{IgnoreCase: true}, "(?i-m:A$)"},
+		{"^a", nfa.Options{POSIX: true}, "(?m:^a)"},
+	} {
+		p, err := Compile(tst.pattern, Options{Options: tst.opts})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fn, err := p.Func(Target{Package: "test", Name: "Matcher", Mode: codegen.ModeMatcher})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fn.Pattern != tst.want {
+			t.Errorf("%q %+v: got pattern %q, want %q", tst.pattern, tst.opts, fn.Pattern, tst.want)
+		}
+	}
+}
+
+func TestSerialize(t *testing.T) {
+	for i, pattern := range patterns {
+		p, err := Compile(pattern, Options{UnicodeWordBoundary: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i%2 == 0 {
+			// Otherwise they are constructed after deserializing.
+			if _, _, _, err := p.SearchAutomata(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		data, err := json.Marshal(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var q Program
+		if err := json.Unmarshal(data, &q); err != nil {
+			t.Fatal(err)
+		}
+		if q.Pattern != p.Pattern || q.Options != p.Options {
+			t.Errorf("%q: got %q %+v, want %q %+v", pattern, q.Pattern, q.Options, p.Pattern, p.Options)
+		}
+		for _, s := range inputs {
+			if got, want := q.Match(s), p.Match(s); got != want {
+				t.Errorf("%q: Match(%q) = %d after deserializing, want %d", pattern, s, got, want)
+			}
+			start, end := q.Find(s)
+			if wantStart, wantEnd := p.Find(s); start != wantStart || end != wantEnd {
+				t.Errorf("%q: Find(%q) = %d, %d after deserializing, want %d, %d", pattern, s, start, end, wantStart, wantEnd)
+			}
+		}
+		for _, mode := range []codegen.Mode{codegen.ModeMatch, codegen.ModeSearch} {
+			target := Target{Package: "test", Name: "matchA", Mode: mode}
+			want, err := p.Generate(target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := q.Generate(target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("%q, mode %d: the generated code differs after deserializing", pattern, mode)
+			}
+		}
+	}
+
+	for _, data := range []string{
+		`{"pattern": "a"}`,
+		`{"pattern": "a", "root": [{"state": 1, "transitions": [{"runes": [97, 97], "to": 1}]}]}`,
+		`{"pattern": "a", "root": [{"state": 1, "transitions": [{"runes": [97], "to": 0}]}]}`,
+	} {
+		var p Program
+		if err := json.Unmarshal([]byte(data), &p); err == nil {
+			t.Errorf("%s: want an error", data)
+		}
+	}
+}
+
+func TestStats(t *testing.T) {
+	p, err := Compile("a*?b", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st := p.Stats(); st.SearchStates != 0 {
+		t.Errorf("Stats() = %+v, want no search automata", st)
+	}
+	if _, _, _, err := p.SearchAutomata(); err != nil {
+		t.Fatal(err)
+	}
+	st := p.Stats()
+	if st.NFAStates == 0 || st.DFAStates == 0 || st.DFATransitions == 0 || st.SearchStates == 0 || st.ReverseStates == 0 {
+		t.Errorf("Stats() = %+v, want nonzero counts", st)
+	}
+	if !st.LeftmostFirst {
+		t.Errorf("Stats() = %+v, want a leftmost-first automaton", st)
+	}
+
+	p, err = Compile("", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st := p.Stats(); st.DFAStates != 1 || st.DFATransitions != 0 || st.MinimizedStates != 1 {
+		t.Errorf("Stats() = %+v, want a single state", st)
+	}
+
+	for _, tst := range []struct {
+		pattern   string
+		minimized int
+		classes   int
+		asserts   map[string]int
+	}{
+		{"a", 2, 2, nil},
+		{"[ac]", 2, 2, nil},
+		{"[a-z]+[0-9]", 3, 3, nil},
+		{"ab|cb", 3, 4, nil},
+		{"[a-c]x|b", 4, 4, nil},
+		{`\bfoo\b`, 6, 3, map[string]int{"word_boundary": 2}},
+		{"(?m)^a$", 4, 2, map[string]int{"begin_line": 1, "end_line": 1}},
+	} {
+		p, err := Compile(tst.pattern, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		st := p.Stats()
+		if st.MinimizedStates != tst.minimized || st.AlphabetClasses != tst.classes || !reflect.DeepEqual(st.Assertions, tst.asserts) {
+			t.Errorf("%q: Stats() = %+v, want %d minimized states, %d alphabet classes and assertions %v",
+				tst.pattern, st, tst.minimized, tst.classes, tst.asserts)
+		}
+	}
+}
+
+func TestMinimize(t *testing.T) {
+	for _, pattern := range patterns {
+		p, err := Compile(pattern, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		q := &Program{Pattern: pattern, Root: dfa.Minimize(p.Root)}
+		q.steps = steps(q.Root)
+		if st, qst := p.Stats(), q.Stats(); qst.DFAStates > st.DFAStates || qst.DFAStates != st.MinimizedStates {
+			t.Errorf("%q: %d states after minimization, want %d, at most %d", pattern, qst.DFAStates, st.MinimizedStates, st.DFAStates)
+		}
+		for _, s := range inputs {
+			if got, want := q.Match(s), p.Match(s); got != want {
+				t.Errorf("%q: Match(%q) = %d after minimization, want %d", pattern, s, got, want)
+			}
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	for _, pattern := range []string{
+		"[a-z]{1,5}",
+		"a.{3}x",
+		"x?[0-9]{2,}y",
+		"(?:[0-9a-f]{2})+",
+		"a{3,6}a{2,4}",
+		`\b[a-z]{0,3}\b`,
+		"[0-9]{2,3}(?:y|yz)?",
+	} {
+		p, err := Compile(pattern, Options{Options: nfa.Options{CountThreshold: 2}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !p.Root.Counts() {
+			t.Errorf("%q: the automaton doesn't count", pattern)
+		}
+		data, err := json.Marshal(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var q Program
+		if err := json.Unmarshal(data, &q); err != nil {
+			t.Fatal(err)
+		}
+		anchored := regexp.MustCompile(`^(?:` + pattern + `)`)
+		anchored.Longest()
+		re := regexp.MustCompile(pattern)
+		for _, s := range []string{"", "abc", "abcdefgh", "aaaax", "a12x", "12345y", "12yz", "x0y", "0a1b2c3", "aaaaaaaaaa", "a\nbx"} {
+			want := -1
+			if loc := anchored.FindStringIndex(s); loc != nil {
+				want = loc[1]
+			}
+			wantLoc := []int{-1, -1}
+			if loc := re.FindStringIndex(s); loc != nil {
+				wantLoc = loc
+			}
+			for _, p := range []*Program{p, &q} {
+				if got := p.Match(s); got != want {
+					t.Errorf("%q: Match(%q) = %d, want %d", pattern, s, got, want)
+				}
+				if start, end := p.Find(s); start != wantLoc[0] || end != wantLoc[1] {
+					t.Errorf("%q: Find(%q) = %d, %d, want %v", pattern, s, start, end, wantLoc)
+				}
+			}
+		}
+	}
+
+	// The automata don't grow with the bound, nor does the time to construct them.
+	for _, pattern := range []string{"[a-z]{1,%d}", "a.{%d}x", "(?:[0-9a-f]{%d})+"} {
+		var want Stats
+		for i, bound := range []int{10, 100, 1000} {
+			start := time.Now()
+			p, err := Compile(fmt.Sprintf(pattern, bound), Options{Options: nfa.Options{CountThreshold: 8}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := p.Generate(Target{Package: "test", Name: "match", Mode: codegen.ModeSearch}); err != nil {
+				t.Fatal(err)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("%q with the bound %d: compiled in %v", pattern, bound, elapsed)
+			}
+			st := p.Stats()
+			if i == 0 {
+				want = st
+			} else if st.NFAStates != want.NFAStates || st.DFAStates != want.DFAStates || st.DFATransitions != want.DFATransitions {
+				t.Errorf("%q with the bound %d: Stats() = %+v, want the sizes of %+v", pattern, bound, st, want)
+			}
+			if st.SearchStates != 0 {
+				t.Errorf("%q with the bound %d: Stats() = %+v, want no search automata", pattern, bound, st)
+			}
+		}
+	}
+}
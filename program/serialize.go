@@ -0,0 +1,257 @@
+// This program is free software: you can redistribute it and/or modify it
+// under the terms of the GNU General Public License as published by the Free
+// Software Foundation, either version 3 of the License, or (at your option)
+// any later version.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU General
+// Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package program
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/opennota/re2dfa/dfa"
+)
+
+// jsonProgram is the serialized form of a program. The states of each automaton are listed
+// in the order of a breadth-first traversal from the initial state.
+type jsonProgram struct {
+	Pattern  string        `json:"pattern"`
+	Options  Options       `json:"options"`
+	Root     []jsonState   `json:"root"`
+	Counters []jsonCounter `json:"counters,omitempty"` // counters of the root automaton
+	First    []jsonState   `json:"first,omitempty"`    // the leftmost-first automaton, if it isn't the root one
+	Search   []jsonState   `json:"search,omitempty"`
+	Reverse  []jsonState   `json:"reverse,omitempty"`
+}
+
+type jsonState struct {
+	S             int              `json:"state"`
+	F             bool             `json:"final,omitempty"`
+	LeftmostFirst bool             `json:"leftmost_first,omitempty"`
+	T             []jsonTransition `json:"transitions,omitempty"`
+	Init          []jsonOp         `json:"init,omitempty"` // operations entering the initial state
+}
+
+type jsonTransition struct {
+	R    []rune     `json:"runes"`
+	N    int        `json:"to"` // index of the target in the list of states
+	Ops  []jsonOp   `json:"ops,omitempty"`
+	Cond []jsonCond `json:"cond,omitempty"`
+}
+
+type jsonCounter struct {
+	N   int `json:"counter"`
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+type jsonOp struct {
+	C      int               `json:"counter"`
+	Action dfa.CounterAction `json:"action"`
+}
+
+type jsonCond struct {
+	C     int              `json:"counter"`
+	State dfa.CounterState `json:"state"`
+}
+
+// MarshalJSON returns the pattern, the options and the automata of the program. The NFA isn't serialized,
+// and neither are the automata of SearchAutomata unless they have been constructed.
+func (p *Program) MarshalJSON() ([]byte, error) {
+	jp := jsonProgram{
+		Pattern: p.Pattern,
+		Options: p.Options,
+		Root:    encodeAutomaton(p.Root),
+	}
+	for _, c := range automatonCounters(p.Root) {
+		jp.Counters = append(jp.Counters, jsonCounter{N: c.N, Min: c.Min, Max: c.Max})
+	}
+	p.mu.Lock()
+	if p.search != nil {
+		if p.first != p.Root {
+			jp.First = encodeAutomaton(p.first)
+		}
+		jp.Search = encodeAutomaton(p.search)
+		jp.Reverse = encodeAutomaton(p.reverse)
+	}
+	p.mu.Unlock()
+	return json.Marshal(jp)
+}
+
+// UnmarshalJSON restores a program serialized with MarshalJSON without compiling the pattern again.
+func (p *Program) UnmarshalJSON(data []byte) error {
+	var jp jsonProgram
+	if err := json.Unmarshal(data, &jp); err != nil {
+		return err
+	}
+	counters := make(map[int]*dfa.Counter, len(jp.Counters))
+	for _, c := range jp.Counters {
+		if c.N < 1 || c.Min < 0 || c.Max < c.Min || c.Max < 1 || counters[c.N] != nil {
+			return fmt.Errorf("invalid counter %d {%d,%d}", c.N, c.Min, c.Max)
+		}
+		counters[c.N] = &dfa.Counter{N: c.N, Min: c.Min, Max: c.Max}
+	}
+	root, err := decodeAutomaton(jp.Root, counters)
+	if err != nil {
+		return fmt.Errorf("root: %v", err)
+	}
+	if root == nil {
+		return errors.New("root: no states")
+	}
+	first, err := decodeAutomaton(jp.First, nil)
+	if err != nil {
+		return fmt.Errorf("first: %v", err)
+	}
+	search, err := decodeAutomaton(jp.Search, nil)
+	if err != nil {
+		return fmt.Errorf("search: %v", err)
+	}
+	reverse, err := decodeAutomaton(jp.Reverse, nil)
+	if err != nil {
+		return fmt.Errorf("reverse: %v", err)
+	}
+	if (search == nil) != (reverse == nil) {
+		return errors.New("either both search automata or none must be present")
+	}
+	if first != nil && search == nil {
+		return errors.New("the leftmost-first automaton must come with the search automata")
+	}
+	if first == nil && search != nil {
+		first = root
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Pattern = jp.Pattern
+	p.Options = jp.Options
+	p.Root = root
+	p.nfa = nil
+	p.steps = steps(root)
+	p.first, p.search, p.reverse = first, search, reverse
+	p.searchSteps = nil
+	return nil
+}
+
+func encodeAutomaton(root *dfa.Node) []jsonState {
+	nodes := dfaNodes(root)
+	index := make(map[*dfa.Node]int, len(nodes))
+	for i, n := range nodes {
+		index[n] = i
+	}
+
+	states := make([]jsonState, len(nodes))
+	for i, n := range nodes {
+		states[i] = jsonState{S: n.S, F: n.F, LeftmostFirst: n.LeftmostFirst, Init: encodeOps(n.Init)}
+		for _, t := range n.T {
+			jt := jsonTransition{R: t.R, N: index[t.N], Ops: encodeOps(t.Ops)}
+			for _, c := range t.Cond {
+				jt.Cond = append(jt.Cond, jsonCond{C: c.C.N, State: c.State})
+			}
+			states[i].T = append(states[i].T, jt)
+		}
+	}
+	return states
+}
+
+func encodeOps(ops []dfa.CounterOp) []jsonOp {
+	var jops []jsonOp
+	for _, op := range ops {
+		jops = append(jops, jsonOp{C: op.C.N, Action: op.Action})
+	}
+	return jops
+}
+
+// automatonCounters returns the counters of the automaton, ordered by number.
+func automatonCounters(root *dfa.Node) []*dfa.Counter {
+	seen := make(map[*dfa.Counter]bool)
+	var counters []*dfa.Counter
+	add := func(c *dfa.Counter) {
+		if !seen[c] {
+			seen[c] = true
+			counters = append(counters, c)
+		}
+	}
+	for _, n := range dfaNodes(root) {
+		for _, op := range n.Init {
+			add(op.C)
+		}
+		for _, t := range n.T {
+			for _, op := range t.Ops {
+				add(op.C)
+			}
+			for _, c := range t.Cond {
+				add(c.C)
+			}
+		}
+	}
+	sort.Slice(counters, func(i, j int) bool { return counters[i].N < counters[j].N })
+	return counters
+}
+
+func decodeOps(jops []jsonOp, counters map[int]*dfa.Counter) ([]dfa.CounterOp, error) {
+	var ops []dfa.CounterOp
+	for _, op := range jops {
+		c := counters[op.C]
+		if c == nil {
+			return nil, fmt.Errorf("nonexistent counter %d", op.C)
+		}
+		if op.Action < dfa.CounterIncrement || op.Action > dfa.CounterEnter {
+			return nil, fmt.Errorf("counter %d: invalid action %d", op.C, op.Action)
+		}
+		ops = append(ops, dfa.CounterOp{C: c, Action: op.Action})
+	}
+	return ops, nil
+}
+
+// decodeAutomaton returns the initial state of the automaton, or nil if there are no states.
+// The operations and the conditions refer to the counters by number.
+func decodeAutomaton(states []jsonState, counters map[int]*dfa.Counter) (*dfa.Node, error) {
+	if len(states) == 0 {
+		return nil, nil
+	}
+	nodes := make([]*dfa.Node, len(states))
+	for i, st := range states {
+		nodes[i] = &dfa.Node{S: st.S, F: st.F, LeftmostFirst: st.LeftmostFirst}
+		init, err := decodeOps(st.Init, counters)
+		if err != nil {
+			return nil, fmt.Errorf("state %d: %v", st.S, err)
+		}
+		nodes[i].Init = init
+	}
+	for i, st := range states {
+		for _, t := range st.T {
+			if t.N < 0 || t.N >= len(nodes) {
+				return nil, fmt.Errorf("state %d: transition to a nonexistent state %d", st.S, t.N)
+			}
+			if len(t.R)%2 != 0 {
+				return nil, fmt.Errorf("state %d: odd number of rune range bounds", st.S)
+			}
+			ops, err := decodeOps(t.Ops, counters)
+			if err != nil {
+				return nil, fmt.Errorf("state %d: %v", st.S, err)
+			}
+			var cond []dfa.CounterCond
+			for _, c := range t.Cond {
+				if counters[c.C] == nil {
+					return nil, fmt.Errorf("state %d: nonexistent counter %d", st.S, c.C)
+				}
+				if c.State < dfa.CounterEmpty || c.State > dfa.CounterAtLeastMin {
+					return nil, fmt.Errorf("state %d: counter %d: invalid state %d", st.S, c.C, c.State)
+				}
+				cond = append(cond, dfa.CounterCond{C: counters[c.C], State: c.State})
+			}
+			nodes[i].T = append(nodes[i].T, dfa.T{R: t.R, N: nodes[t.N], Ops: ops, Cond: cond})
+		}
+	}
+	return nodes[0], nil
+}
@@ -15,80 +15,303 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
-	"regexp"
+	"sort"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/opennota/re2dfa/codegen"
-	"github.com/opennota/re2dfa/dfa"
 	"github.com/opennota/re2dfa/nfa"
+	"github.com/opennota/re2dfa/program"
 )
 
 func main() {
 	log.SetFlags(0)
 
 	output := flag.String("o", "", "Output to file")
+	withTest := flag.Bool("test", false, "Write a test file next to the output file")
+	lang := flag.String("lang", "go", "Output language")
+	mode := flag.String("mode", "match", "Kind of the generated function: match, search, findall, replaceall, split, bool or matcher")
+	template := flag.String("replace", "", "Replacement template for -mode replaceall")
+	var opts nfa.Options
+	flag.BoolVar(&opts.POSIX, "posix", false, "Use the POSIX ERE syntax")
+	flag.BoolVar(&opts.Literal, "literal", false, "Treat the regexp as a literal string")
+	flag.BoolVar(&opts.IgnoreCase, "i", false, "Case-insensitive matching")
+	flag.BoolVar(&opts.DotNL, "s", false, "Let . match \\n")
+	flag.BoolVar(&opts.Ungreedy, "U", false, "Swap the meaning of x* and x*?, x+ and x+?, etc.")
+	unicodeWord := flag.Bool("unicodeword", false, "Unicode-aware \\b and \\B")
+	lines := flag.String("lines", "lf", "Comma-separated line terminators: lf, cr, crlf, unicode")
+	flag.IntVar(&opts.CountThreshold, "count", 0, "Count the bounded repetitions of at least N characters in a register")
+	stats := flag.Bool("stats", false, "Print the sizes of the automata and of the generated code")
+	asJSON := flag.Bool("json", false, "Print the report of -stats as JSON")
 	flag.Usage = func() {
-		fmt.Println(`Usage: re2dfa [options] regexp package.function string|[]byte
+		fmt.Print(`Usage: re2dfa [options] regexp package.function string|[]byte
+       re2dfa -mode matcher [options] regexp package.Type
+       re2dfa -lang c|rust|js|ts [options] regexp function
 
 Options:
     -o FILE    Output to FILE instead of standard output
+    -lang LANG Output language: go (default), c, rust, js or ts
+    -mode MODE match (default): return the end of the match at the
+               beginning of the input; search: return the start and the
+               end of the leftmost match in the input; findall: return
+               the successive non-overlapping matches, like
+               regexp.FindAllStringIndex; replaceall: return a copy of
+               the input with the matches replaced by the template given
+               with -replace, like regexp.ReplaceAllString; split:
+               return the substrings between the matches, like
+               regexp.Split; bool: report whether there is a match at
+               the beginning of the input, returning as soon as it is
+               found; matcher: generate a type with the MatchString,
+               Match, FindStringIndex, FindIndex, String and
+               LiteralPrefix methods of *regexp.Regexp (all modes but
+               match require -lang go)
+    -replace TEMPLATE
+               Replacement template for -mode replaceall; $0 or ${0}
+               expands to the match, $$ to $ (capture groups are not
+               supported)
+    -posix     Use the POSIX ERE syntax, as regexp.CompilePOSIX does (^ and
+               $ match at line boundaries, and the modes but match and
+               bool find the leftmost-longest matches)
+    -literal   Treat the regexp as a literal string
+    -i         Case-insensitive matching, like (?i)
+    -s         Let . match \n, like (?s)
+    -U         Ungreedy: swap x* and x*?, x+ and x+?, etc., like (?U)
+    -lines LIST
+               Line terminators recognized by (?m)^, (?m)$ and ., separated
+               by commas: lf (default), cr, crlf (\r\n as one terminator)
+               and unicode (U+0085, U+2028 and U+2029); . doesn't match
+               any of their characters (requires -lang go)
+    -unicodeword
+               Evaluate \b and \B against the Unicode word characters
+               (letters, marks, digits and connector punctuation) instead
+               of [0-9A-Za-z_] (requires -lang go)
+    -count N   Count the greedy repetitions of a single character, such as
+               [a-z]{1,100}, which would be unrolled into N or more states,
+               in a register of the automaton instead; the search modes
+               then try the automaton at every position, or unroll the
+               repetitions unless the leftmost-longest matches are the
+               leftmost-first ones, and lazy quantifiers disable the
+               counting (requires -lang go)
+    -stats     Print a report instead of the code: the number of states of
+               the NFA and of those with lazy transitions, the number of
+               states and transitions of the DFA before and after
+               minimization, the transitions on each kind of assertion,
+               the number of classes of runes the DFA tells apart and the
+               size of the generated code (the code is still written with
+               -o)
+    -json      Print the report of -stats as JSON
+    -test      Also write FILE_test.go checking the generated function
+               against the regexp package on sampled inputs, with a fuzz
+               target for go test -fuzz (requires -o and -lang go)
 
 EXAMPLE: re2dfa ^a+$ main.matchAPlus string
 `)
 	}
 	flag.Parse()
-	if len(flag.Args()) != 3 {
-		flag.Usage()
-		os.Exit(1)
+
+	var pkg, fun, typ string
+	switch *lang {
+	case "go":
+		// A matcher type has methods for both strings and byte slices.
+		nargs := 3
+		if *mode == "matcher" {
+			nargs = 2
+		}
+		if len(flag.Args()) != nargs {
+			flag.Usage()
+			os.Exit(1)
+		}
+
+		pkgfun := strings.Split(flag.Arg(1), ".")
+		if len(pkgfun) != 2 {
+			flag.Usage()
+			os.Exit(1)
+		}
+		pkg = pkgfun[0]
+		fun = pkgfun[1]
+		if nargs == 3 {
+			typ = flag.Arg(2)
+			if !(typ == "string" || typ == "[]byte") {
+				flag.Usage()
+				os.Exit(1)
+			}
+		}
+
+	case "c", "rust", "js", "ts":
+		if len(flag.Args()) != 2 {
+			flag.Usage()
+			os.Exit(1)
+		}
+		fun = flag.Arg(1)
+
+	default:
+		log.Fatalf("unknown language: %s", *lang)
 	}
 
-	expr := flag.Arg(0)
-	_, err := regexp.Compile(expr)
+	lt, err := parseLineTerminators(*lines)
 	if err != nil {
-		log.Fatal(fmt.Sprintf("invalid regexp: %q", expr))
+		log.Fatal(err)
 	}
+	opts.LineTerminators = lt
 
-	pkgfun := strings.Split(flag.Arg(1), ".")
-	if len(pkgfun) != 2 {
-		flag.Usage()
-		os.Exit(1)
+	if *withTest && (*output == "" || *lang != "go") {
+		log.Fatal("-test requires -o and -lang go")
+	}
+	if *asJSON && !*stats {
+		log.Fatal("-json requires -stats")
 	}
-	pkg := pkgfun[0]
-	fun := pkgfun[1]
-	typ := flag.Arg(2)
 
-	if !(typ == "string" || typ == "[]byte") {
-		flag.Usage()
-		os.Exit(1)
+	var m codegen.Mode
+	switch *mode {
+	case "match":
+		m = codegen.ModeMatch
+	case "search":
+		m = codegen.ModeSearch
+	case "findall":
+		m = codegen.ModeFindAll
+	case "replaceall":
+		m = codegen.ModeReplaceAll
+	case "split":
+		m = codegen.ModeSplit
+	case "bool":
+		m = codegen.ModeBool
+	case "matcher":
+		m = codegen.ModeMatcher
+	default:
+		log.Fatalf("unknown mode: %s", *mode)
 	}
 
-	nfanode, err := nfa.New(expr)
+	p, err := program.Compile(flag.Arg(0), program.Options{Options: opts, UnicodeWordBoundary: *unicodeWord})
 	if err != nil {
 		log.Fatal(err)
 	}
-
-	node := dfa.NewFromNFA(nfanode)
-	source := codegen.GoGenerate(node, pkg, fun, typ)
-	if *output == "" {
-		fmt.Println(source)
-	} else {
-		f, err := os.Create(*output)
+	target := program.Target{
+		Lang:     *lang,
+		Package:  pkg,
+		Name:     fun,
+		Type:     typ,
+		Mode:     m,
+		Template: *template,
+	}
+	source, err := p.Generate(target)
+	if err != nil {
+		log.Fatal(err)
+	}
+	switch {
+	case *stats:
+		err = printStats(os.Stdout, p, source, *asJSON)
 		if err != nil {
 			log.Fatal(err)
 		}
+	case *output == "":
+		fmt.Println(source)
+	}
+	if *output == "" {
+		return
+	}
+
+	err = writeFile(*output, source)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-		_, err = f.WriteString(source)
+	if *withTest {
+		source, err := p.GenerateTest(target)
 		if err != nil {
 			log.Fatal(err)
 		}
-
-		err = f.Close()
+		err = writeFile(strings.TrimSuffix(*output, ".go")+"_test.go", source)
 		if err != nil {
 			log.Fatal(err)
 		}
 	}
 }
+
+// A report is the output of -stats.
+type report struct {
+	Pattern string `json:"pattern"`
+	program.Stats
+	CodeBytes int `json:"code_bytes"` // size of the generated code
+	CodeLines int `json:"code_lines"`
+}
+
+func printStats(w io.Writer, p *program.Program, source string, asJSON bool) error {
+	r := report{
+		Pattern:   p.Pattern,
+		Stats:     p.Stats(),
+		CodeBytes: len(source),
+		CodeLines: strings.Count(source, "\n"),
+	}
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+
+	var assertions []string
+	for name, n := range r.Assertions {
+		assertions = append(assertions, fmt.Sprintf("%s %d", name, n))
+	}
+	sort.Strings(assertions)
+	if len(assertions) == 0 {
+		assertions = []string{"none"}
+	}
+	matches := "leftmost-longest"
+	if r.LeftmostFirst {
+		matches = "leftmost-first"
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "pattern:\t%s\n", r.Pattern)
+	fmt.Fprintf(tw, "NFA states:\t%d (%d with lazy transitions)\n", r.NFAStates, r.LazyStates)
+	fmt.Fprintf(tw, "DFA states:\t%d (%d after minimization)\n", r.DFAStates, r.MinimizedStates)
+	fmt.Fprintf(tw, "DFA transitions:\t%d (%d after minimization)\n", r.DFATransitions, r.MinimizedTransitions)
+	fmt.Fprintf(tw, "matches:\t%s\n", matches)
+	fmt.Fprintf(tw, "assertions:\t%s\n", strings.Join(assertions, ", "))
+	fmt.Fprintf(tw, "alphabet classes:\t%d\n", r.AlphabetClasses)
+	if r.SearchStates > 0 {
+		fmt.Fprintf(tw, "search states:\t%d (%d reverse)\n", r.SearchStates, r.ReverseStates)
+	}
+	fmt.Fprintf(tw, "generated code:\t%d bytes, %d lines\n", r.CodeBytes, r.CodeLines)
+	return tw.Flush()
+}
+
+func parseLineTerminators(s string) (nfa.LineTerminators, error) {
+	var lt nfa.LineTerminators
+	for _, name := range strings.Split(s, ",") {
+		switch name {
+		case "lf":
+			lt |= nfa.LineLF
+		case "cr":
+			lt |= nfa.LineCR
+		case "crlf":
+			lt |= nfa.LineCRLF
+		case "unicode":
+			lt |= nfa.LineUnicode
+		default:
+			return 0, fmt.Errorf("unknown line terminator: %s", name)
+		}
+	}
+	return lt, nil
+}
+
+func writeFile(fn, s string) error {
+	f, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.WriteString(s)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -119,6 +119,22 @@ func Sum(a, b []rune) []rune {
 	return d
 }
 
+// Invert returns a range containing all the runes which are not in the original range. The original range is not modified.
+func Invert(ranges []rune) []rune {
+	inv := make([]rune, 0, len(ranges)+2)
+	lo := rune(0)
+	for i := 0; i < len(ranges); i += 2 {
+		if ranges[i] > lo {
+			inv = append(inv, lo, ranges[i]-1)
+		}
+		lo = ranges[i+1] + 1
+	}
+	if lo <= unicode.MaxRune {
+		inv = append(inv, lo, unicode.MaxRune)
+	}
+	return inv
+}
+
 // Fold returns a range containing all the runes from the original range and all the runes that can be obtained from them by using unicode case folding. The original range is not modified.
 func Fold(ranges []rune) []rune {
 	if len(ranges) == 0 {
@@ -210,8 +226,8 @@ outer:
 					continue outer
 				}
 			} else {
-				if result[0] <= r0-1 {
-					queue = append(queue, result[0], r0-1)
+				if result[i] <= r0-1 {
+					queue = append(queue, result[i], r0-1)
 				}
 				queue = append(queue, r0, r1)
 				if r1+1 <= result[i+1] {
@@ -16,6 +16,7 @@ package runerange
 import (
 	"reflect"
 	"testing"
+	"unicode"
 )
 
 func TestIn(t *testing.T) {
@@ -70,6 +71,26 @@ func TestAdd(t *testing.T) {
 	}
 }
 
+func TestInvert(t *testing.T) {
+	type testCase struct {
+		a    []rune
+		want []rune
+	}
+	testCases := []testCase{
+		{[]rune{}, []rune{0, unicode.MaxRune}},
+		{[]rune{0, unicode.MaxRune}, []rune{}},
+		{[]rune{'\n', '\n'}, []rune{0, '\n' - 1, '\n' + 1, unicode.MaxRune}},
+		{[]rune{0, 'a', 'z', unicode.MaxRune}, []rune{'b', 'y'}},
+		{[]rune{'\n', '\n', '\r', '\r'}, []rune{0, 9, 11, 12, 14, unicode.MaxRune}},
+	}
+	for _, tc := range testCases {
+		got := Invert(tc.a)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Invert(%q) = %q, want %q", string(tc.a), string(got), string(tc.want))
+		}
+	}
+}
+
 func TestSum(t *testing.T) {
 	type testCase struct {
 		a, b []rune
@@ -139,6 +160,7 @@ func TestSplit(t *testing.T) {
 		{[][]rune{{'a', 'p'}, {'n', 'z'}}, []rune{'a', 'm', 'n', 'p', 'q', 'z'}},
 		{[][]rune{{'a', 'c'}, {'d', 'f'}, {'g', 'i'}}, []rune{'a', 'c', 'd', 'f', 'g', 'i'}},
 		{[][]rune{{'a', 'd'}, {'d', 'f'}, {'f', 'i'}}, []rune{'a', 'c', 'd', 'd', 'e', 'e', 'f', 'f', 'g', 'i'}},
+		{[][]rune{{'n', 'n'}, {'a', 'z'}, {'0', '0'}}, []rune{'0', '0', 'a', 'm', 'n', 'n', 'o', 'z'}},
 	}
 	for _, tc := range testCases {
 		got := Split(tc.in)